@@ -0,0 +1,487 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Anytone's CPS imports and exports a codeplug as a set of CSV files,
+// one per table.  Records are referred to by name, and lists of records
+// are separated by "|".
+
+// anytoneChannelFieldTypes lists the channel fields that are
+// represented in Anytone CSV files.
+var anytoneChannelFieldTypes = []FieldType{
+	FtCiName,
+	FtCiRxFrequency,
+	FtCiTxFrequencyOffset,
+	FtCiChannelMode,
+	FtCiPower,
+	FtCiBandwidth,
+	FtCiCtcssDecode,
+	FtCiCtcssEncode,
+	FtCiContactName,
+	FtCiAdmitCriteria,
+	FtCiColorCode,
+	FtCiRepeaterSlot,
+	FtCiScanList_md380,
+	FtCiGroupList,
+	FtCiRxOnly,
+	FtCiPrivateCallConfirmed,
+	FtCiTalkaround,
+	FtCiLoneWorker,
+}
+
+// anytoneValues maps codeplug field values to Anytone values, for those
+// fields where they differ.
+var anytoneValues = map[FieldType]map[string]string{
+	FtCiChannelMode: map[string]string{
+		"Analog":  "A-Analog",
+		"Digital": "D-Digital",
+	},
+	FtCiPower: map[string]string{
+		"Low":    "Low",
+		"Medium": "Mid",
+		"High":   "High",
+	},
+	FtCiBandwidth: map[string]string{
+		"12.5": "12.5K",
+		"25":   "25K",
+	},
+	FtCiCtcssDecode: map[string]string{
+		"None": "Off",
+	},
+	FtCiCtcssEncode: map[string]string{
+		"None": "Off",
+	},
+	FtDcCallType: map[string]string{
+		"Group":   "Group Call",
+		"Private": "Private Call",
+		"All":     "All Call",
+	},
+	FtDcCallReceiveTone: map[string]string{
+		"No":  "None",
+		"Yes": "Ring",
+	},
+	FtSlTxDesignatedChannel_md380: map[string]string{
+		"Selected":            "Selected",
+		"Last Active Channel": "Last Used",
+	},
+}
+
+// anytoneAdmit maps admit criteria to Anytone's Busy Lock/TX Permit
+// values, for digital and analog channels.  An empty value marks a
+// criterion that Anytone lacks for that channel type.
+var anytoneAdmit = map[string][2]string{
+	"Always":       {"Always", "Off"},
+	"Channel free": {"ChannelFree", "ChannelFree"},
+	"Color code":   {"Same Color Code", "Off"},
+	"CTCSS/DCS":    {"", ""},
+}
+
+// anytoneFile holds the contents of one of Anytone's CSV files.
+type anytoneFile struct {
+	name   string
+	header []string
+	rows   [][]string
+}
+
+func (af *anytoneFile) addRow(values ...string) {
+	row := append([]string{strconv.Itoa(len(af.rows) + 1)}, values...)
+	af.rows = append(af.rows, row)
+}
+
+// write writes the file into dir, with every value quoted and CRLF
+// line endings, as Anytone's CPS does.
+func (af *anytoneFile) write(dir string) (err error) {
+	file, err := os.Create(filepath.Join(dir, af.name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	w := bufio.NewWriter(file)
+	for _, row := range append([][]string{af.header}, af.rows...) {
+		quoted := make([]string, len(row))
+		for i, s := range row {
+			quoted[i] = `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+		}
+		fmt.Fprint(w, strings.Join(quoted, ",")+"\r\n")
+	}
+
+	return w.Flush()
+}
+
+// toAnytone returns the Anytone value for a field's value.
+func toAnytone(f *Field) string {
+	if f == nil {
+		return ""
+	}
+	if v, ok := anytoneValues[f.fType][f.String()]; ok {
+		return v
+	}
+
+	return f.String()
+}
+
+// anytoneFrequencies returns a channel's receive and transmit
+// frequencies in Anytone's format.
+func anytoneFrequencies(r *Record) (rx string, tx string) {
+	rxFreq, _ := strconv.ParseFloat(r.Field(FtCiRxFrequency).String(), 64)
+	offset, _ := strconv.ParseFloat(r.Field(FtCiTxFrequencyOffset).String(), 64)
+
+	return fmt.Sprintf("%.5f", rxFreq), fmt.Sprintf("%.5f", rxFreq+offset)
+}
+
+// ExportAnytone writes the codeplug into dir, creating it if necessary,
+// as the set of CSV files imported by Anytone's CPS: Channel, Zone,
+// ScanList, TalkGroups, ReceiveGroupCallList and RadioIDList.  It
+// returns a description of each of the codeplug's settings that cannot
+// be represented in them.
+func (cp *Codeplug) ExportAnytone(dir string) (unsupported []string, err error) {
+	report := func(r *Record, format string, v ...interface{}) {
+		msg := fmt.Sprintf("%s: %s: ", r.rType, r.Name()) + fmt.Sprintf(format, v...)
+		unsupported = append(unsupported, msg)
+	}
+
+	channels := make(map[string]*Record)
+	for _, r := range cp.records(RtChannels_md380) {
+		channels[r.Name()] = r
+	}
+
+	contacts := make(map[string]*Record)
+	for _, r := range cp.records(RtContacts) {
+		contacts[r.Name()] = r
+	}
+
+	contactName := func(r *Record) string {
+		return removeSuffix(r.NameField(), r.Name())
+	}
+
+	// memberLists returns a list of channels' names and frequencies.
+	memberLists := func(names []string) (string, string, string) {
+		var rxs, txs []string
+		for _, name := range names {
+			rx, tx := anytoneFrequencies(channels[name])
+			rxs = append(rxs, rx)
+			txs = append(txs, tx)
+		}
+		return strings.Join(names, "|"), strings.Join(rxs, "|"), strings.Join(txs, "|")
+	}
+
+	fieldNames := func(r *Record, fType FieldType) []string {
+		var names []string
+		for _, f := range r.Fields(fType) {
+			if channels[f.String()] != nil {
+				names = append(names, f.String())
+			}
+		}
+		return names
+	}
+
+	radioIDList := &anytoneFile{
+		name:   "RadioIDList.CSV",
+		header: []string{"No.", "Radio ID", "Name"},
+	}
+	radioName := ""
+	if gs := cp.record(RtGeneralSettings_md380); gs != nil {
+		if f := gs.Field(FtGsRadioName); f != nil {
+			radioName = f.String()
+		}
+		for i, fType := range qdmrRadioIDs {
+			f := gs.Field(fType)
+			if f == nil {
+				continue
+			}
+			name := radioName
+			switch {
+			case name == "":
+				name = f.String()
+			case i > 0:
+				name = fmt.Sprintf("%s %d", radioName, i+1)
+			}
+			radioIDList.addRow(f.String(), name)
+		}
+	}
+
+	// Channels use the first radio ID.
+	radioIDName := ""
+	if len(radioIDList.rows) > 0 {
+		radioIDName = radioIDList.rows[0][2]
+	}
+
+	talkGroups := &anytoneFile{
+		name:   "TalkGroups.CSV",
+		header: []string{"No.", "Radio ID", "Name", "Call Type", "Call Alert"},
+	}
+	for _, r := range cp.records(RtContacts) {
+		talkGroups.addRow(
+			r.Field(FtDcCallID).String(),
+			contactName(r),
+			toAnytone(r.Field(FtDcCallType)),
+			toAnytone(r.Field(FtDcCallReceiveTone)))
+	}
+
+	groupCallLists := &anytoneFile{
+		name:   "ReceiveGroupCallList.CSV",
+		header: []string{"No.", "Group Name", "Contact", "Contact TG/DMR ID"},
+	}
+	for _, r := range cp.records(RtGroupLists) {
+		var names, ids []string
+		for _, f := range r.Fields(FtGlContact) {
+			c := contacts[f.String()]
+			if c == nil {
+				continue
+			}
+			names = append(names, contactName(c))
+			ids = append(ids, c.Field(FtDcCallID).String())
+		}
+		groupCallLists.addRow(r.Name(),
+			strings.Join(names, "|"), strings.Join(ids, "|"))
+	}
+
+	channelFile := &anytoneFile{
+		name: "Channel.CSV",
+		header: []string{
+			"No.", "Channel Name", "Receive Frequency",
+			"Transmit Frequency", "Channel Type", "Transmit Power",
+			"Band Width", "CTCSS/DCS Decode", "CTCSS/DCS Encode",
+			"Contact", "Contact Call Type", "Contact TG/DMR ID",
+			"Radio ID", "Busy Lock/TX Permit", "Squelch Mode",
+			"Optional Signal", "DTMF ID", "2Tone ID", "5Tone ID",
+			"PTT ID", "Color Code", "Slot", "Scan List",
+			"Receive Group List", "PTT Prohibit", "Reverse",
+			"Simplex TDMA", "Slot Suit", "AES Digital Encryption",
+			"Digital Encryption", "Call Confirmation",
+			"Talk Around(Simplex)", "Work Alone", "Custom CTCSS",
+			"2TONE Decode", "Ranging", "Through Mode", "APRS RX",
+			"Analog APRS PTT Mode", "Digital APRS PTT Mode",
+			"APRS Report Type", "Digital APRS Report Channel",
+			"Correct Frequency[Hz]", "SMS Confirmation",
+			"Exclude channel from roaming", "DMR MODE",
+			"DataACK Disable", "R5toneBot", "R5ToneEot",
+		},
+	}
+	for _, r := range cp.records(RtChannels_md380) {
+		digital := r.Field(FtCiChannelMode).String() == "Digital"
+		rx, tx := anytoneFrequencies(r)
+
+		bandwidth := toAnytone(r.Field(FtCiBandwidth))
+		if !strings.HasSuffix(bandwidth, "K") {
+			report(r, "%s KHz bandwidth is not supported", bandwidth)
+			bandwidth = "25K"
+		}
+
+		var contact, callType, callID string
+		if c := contacts[r.Field(FtCiContactName).String()]; c != nil {
+			contact = contactName(c)
+			callType = toAnytone(c.Field(FtDcCallType))
+			callID = c.Field(FtDcCallID).String()
+		}
+
+		admit := r.Field(FtCiAdmitCriteria).String()
+		busyLock := anytoneAdmit[admit][1]
+		if digital {
+			busyLock = anytoneAdmit[admit][0]
+		}
+		if busyLock == "" {
+			report(r, "%s admit criteria is not supported", admit)
+			busyLock = anytoneAdmit["Always"][1]
+			if digital {
+				busyLock = anytoneAdmit["Always"][0]
+			}
+		}
+
+		squelchMode := "Carrier"
+		if r.Field(FtCiCtcssDecode).String() != "None" {
+			squelchMode = "CTCSS/DCS"
+		}
+
+		dmrMode := "0"
+		if rx != tx {
+			dmrMode = "1"
+		}
+
+		listName := func(fType FieldType) string {
+			f := r.Field(fType)
+			if f == nil || f.String() == "None" {
+				return "None"
+			}
+			return f.String()
+		}
+
+		channelFile.addRow(
+			r.Name(), rx, tx,
+			toAnytone(r.Field(FtCiChannelMode)),
+			toAnytone(r.Field(FtCiPower)),
+			bandwidth,
+			toAnytone(r.Field(FtCiCtcssDecode)),
+			toAnytone(r.Field(FtCiCtcssEncode)),
+			contact, callType, callID,
+			radioIDName, busyLock, squelchMode,
+			"Off", "1", "1", "1", "Off",
+			r.Field(FtCiColorCode).String(),
+			r.Field(FtCiRepeaterSlot).String(),
+			listName(FtCiScanList_md380),
+			listName(FtCiGroupList),
+			r.Field(FtCiRxOnly).String(),
+			"Off", "Off", "Off", "Normal Encryption", "Off",
+			r.Field(FtCiPrivateCallConfirmed).String(),
+			r.Field(FtCiTalkaround).String(),
+			r.Field(FtCiLoneWorker).String(),
+			"251.1", "1", "Off", "Off", "Off", "Off", "Off", "Off",
+			"1", "0", "Off", "0", dmrMode, "0", "0", "0")
+	}
+
+	zoneFile := &anytoneFile{
+		name: "Zone.CSV",
+		header: []string{
+			"No.", "Zone Name", "Zone Channel Member",
+			"Zone Channel Member RX Frequency",
+			"Zone Channel Member TX Frequency",
+			"A Channel", "A Channel RX Frequency",
+			"A Channel TX Frequency",
+			"B Channel", "B Channel RX Frequency",
+			"B Channel TX Frequency",
+		},
+	}
+	for _, r := range cp.records(RtZones_md380) {
+		namesA := fieldNames(r, FtZiChannel_md380)
+		namesA = append(namesA, fieldNames(r, FtZiChannelA_uv380)...)
+		namesB := fieldNames(r, FtZiChannelB_uv380)
+
+		var members []string
+		seen := make(map[string]bool)
+		for _, name := range append(namesA, namesB...) {
+			if !seen[name] {
+				seen[name] = true
+				members = append(members, name)
+			}
+		}
+		if len(members) == 0 {
+			report(r, "zone has no channels")
+			continue
+		}
+		if len(namesA) == 0 {
+			namesA = members
+		}
+		if len(namesB) == 0 {
+			namesB = namesA
+		}
+		names, rxs, txs := memberLists(members)
+		rxA, txA := anytoneFrequencies(channels[namesA[0]])
+		rxB, txB := anytoneFrequencies(channels[namesB[0]])
+		zoneFile.addRow(r.Name(), names, rxs, txs,
+			namesA[0], rxA, txA, namesB[0], rxB, txB)
+	}
+
+	scanListFile := &anytoneFile{
+		name: "ScanList.CSV",
+		header: []string{
+			"No.", "Scan List Name", "Scan Channel Member",
+			"Scan Channel Member RX Frequency",
+			"Scan Channel Member TX Frequency", "Scan Mode",
+			"Priority Channel Select", "Priority Channel 1",
+			"Priority Channel 1 RX Frequency",
+			"Priority Channel 1 TX Frequency", "Priority Channel 2",
+			"Priority Channel 2 RX Frequency",
+			"Priority Channel 2 TX Frequency", "Revert Channel",
+			"Look Back Time A[s]", "Look Back Time B[s]",
+			"Dropout Delay Time[s]", "Dwell Time[s]",
+		},
+	}
+	for _, r := range cp.records(RtScanLists_md380) {
+		names, rxs, txs := memberLists(fieldNames(r, FtSlChannel_md380))
+
+		var selects []string
+		priority := func(n int, fType FieldType) (string, string, string) {
+			str := r.Field(fType).String()
+			switch {
+			case str == "None":
+				return "Off", "", ""
+			case str == "Selected":
+				selects = append(selects, fmt.Sprintf("Priority Channel Select%d", n))
+				return "Current Channel", "", ""
+			case channels[str] != nil:
+				selects = append(selects, fmt.Sprintf("Priority Channel Select%d", n))
+				rx, tx := anytoneFrequencies(channels[str])
+				return str, rx, tx
+			}
+			return "Off", "", ""
+		}
+		p1, rx1, tx1 := priority(1, FtSlPriorityChannel1_md380)
+		p2, rx2, tx2 := priority(2, FtSlPriorityChannel2_md380)
+		prioritySelect := "Off"
+		if len(selects) > 0 {
+			prioritySelect = strings.Join(selects, " + ")
+		}
+
+		revert, ok := anytoneValues[FtSlTxDesignatedChannel_md380][r.Field(FtSlTxDesignatedChannel_md380).String()]
+		if !ok {
+			report(r, "Tx Designated Channel %s is not supported", r.Field(FtSlTxDesignatedChannel_md380).String())
+			revert = "Selected"
+		}
+
+		scanListFile.addRow(r.Name(), names, rxs, txs, "Off",
+			prioritySelect, p1, rx1, tx1, p2, rx2, tx2, revert,
+			"2.0", "3.0", "3.1", "3.1")
+	}
+
+	unsupported = append(unsupported, cp.unsupportedFields(RtChannels_md380, anytoneChannelFieldTypes)...)
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return unsupported, err
+	}
+
+	for _, af := range []*anytoneFile{
+		channelFile,
+		zoneFile,
+		scanListFile,
+		talkGroups,
+		groupCallLists,
+		radioIDList,
+	} {
+		err = af.write(dir)
+		if err != nil {
+			return unsupported, err
+		}
+	}
+
+	return unsupported, nil
+}
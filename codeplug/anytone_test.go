@@ -0,0 +1,214 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// exportTestCodeplug returns an MD-UV380 codeplug containing a digital
+// channel, "DMR Rpt", and an analog channel, "FM Rpt", in a zone,
+// "Both", along with the digital channel's contact, "Worldwide", and
+// group list, "Local".
+func exportTestCodeplug(t *testing.T) *Codeplug {
+	t.Helper()
+
+	cp := newTestCodeplug(t, "MD-UV380")
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	contact := NewContact(insertTestRecord(t, cp, RtContacts, "Worldwide"))
+	check(contact.SetCallID(91))
+	check(contact.SetCallType(DcCallTypeGroup))
+
+	groupList := NewGroupList(insertTestRecord(t, cp, RtGroupLists, "Local"))
+	check(groupList.SetContact([]*Contact{contact}))
+
+	digital := NewChannel_uv380(insertTestRecord(t, cp, RtChannels_md380, "DMR Rpt"))
+	check(digital.SetChannelMode(CiChannelModeDigital))
+	check(digital.SetRxFrequency(cp.lowFrequency + 10))
+	check(digital.SetTxFrequencyOffset(0.6))
+	check(digital.SetContactName(contact))
+	check(digital.SetGroupList(groupList))
+	check(digital.SetColorCode(3))
+	check(digital.SetRepeaterSlot(CiRepeaterSlot2))
+	check(digital.SetAdmitCriteria(CiAdmitCriteriaColorCode))
+	check(digital.SetPower(CiPower_uv380High))
+
+	analog := NewChannel_uv380(insertTestRecord(t, cp, RtChannels_md380, "FM Rpt"))
+	check(analog.SetChannelMode(CiChannelModeAnalog))
+	check(analog.SetRxFrequency(cp.lowFrequency + 11))
+	check(analog.SetBandwidth(CiBandwidth25))
+	check(analog.SetCtcssEncode("100.0"))
+	check(analog.SetCtcssDecode("D023N"))
+	check(analog.SetAdmitCriteria(CiAdmitCriteriaCTCSS_DCS))
+	check(analog.SetPower(CiPower_uv380Medium))
+
+	zone := NewZone_uv380(insertTestRecord(t, cp, RtZones_md380, "Both"))
+	check(zone.SetChannelA([]*Channel_uv380{digital, analog}))
+
+	return cp
+}
+
+// readExportedCSV returns the rows of the named CSV file in dir, each
+// as a map from the header's column names to the row's values, keyed
+// by the value in column key.
+func readExportedCSV(t *testing.T, dir string, name string, key string) map[string]map[string]string {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(map[string]map[string]string)
+	for _, row := range rows[1:] {
+		m := make(map[string]string)
+		for i, name := range rows[0] {
+			m[name] = row[i]
+		}
+		values[m[key]] = m
+	}
+
+	return values
+}
+
+// checkColumns fails the test if any of the row's columns doesn't have
+// the wanted value.
+func checkColumns(t *testing.T, name string, row map[string]string, want map[string]string) {
+	t.Helper()
+
+	if row == nil {
+		t.Errorf("%s: no row", name)
+		return
+	}
+	for column, value := range want {
+		if row[column] != value {
+			t.Errorf("%s: %s is %q, want %q", name, column, row[column], value)
+		}
+	}
+}
+
+func TestExportAnytone(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		tmp, cleanup := tempDir(t)
+		defer cleanup()
+
+		dir := filepath.Join(tmp, "anytone")
+		unsupported, err := cp.ExportAnytone(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range unsupported {
+			t.Log("export:", msg)
+		}
+
+		checkFiles(t, dir, "Channel.CSV", "Zone.CSV", "ScanList.CSV",
+			"TalkGroups.CSV", "ReceiveGroupCallList.CSV", "RadioIDList.CSV")
+	})
+}
+
+func TestExportAnytoneValues(t *testing.T) {
+	cp := exportTestCodeplug(t)
+	tmp, cleanup := tempDir(t)
+	defer cleanup()
+
+	unsupported, err := cp.ExportAnytone(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rx := frequencyToString(cp.lowFrequency + 10)
+	tx := frequencyToString(cp.lowFrequency + 10.6)
+	rxFM := frequencyToString(cp.lowFrequency + 11)
+
+	channels := readExportedCSV(t, tmp, "Channel.CSV", "Channel Name")
+	checkColumns(t, "DMR Rpt", channels["DMR Rpt"], map[string]string{
+		"Receive Frequency":   rx,
+		"Transmit Frequency":  tx,
+		"Channel Type":        "D-Digital",
+		"Transmit Power":      "High",
+		"Contact":             "Worldwide",
+		"Contact Call Type":   "Group Call",
+		"Contact TG/DMR ID":   "91",
+		"Busy Lock/TX Permit": "Same Color Code",
+		"Color Code":          "3",
+		"Slot":                "2",
+		"Receive Group List":  "Local",
+		"DMR MODE":            "1",
+	})
+	checkColumns(t, "FM Rpt", channels["FM Rpt"], map[string]string{
+		"Receive Frequency":   rxFM,
+		"Transmit Frequency":  rxFM,
+		"Channel Type":        "A-Analog",
+		"Transmit Power":      "Mid",
+		"Band Width":          "25K",
+		"CTCSS/DCS Decode":    "D023N",
+		"CTCSS/DCS Encode":    "100.0",
+		"Squelch Mode":        "CTCSS/DCS",
+		"Busy Lock/TX Permit": "Off",
+		"DMR MODE":            "0",
+	})
+
+	talkGroups := readExportedCSV(t, tmp, "TalkGroups.CSV", "Name")
+	checkColumns(t, "Worldwide", talkGroups["Worldwide"], map[string]string{
+		"Radio ID":  "91",
+		"Call Type": "Group Call",
+	})
+
+	groupLists := readExportedCSV(t, tmp, "ReceiveGroupCallList.CSV", "Group Name")
+	checkColumns(t, "Local", groupLists["Local"], map[string]string{
+		"Contact":           "Worldwide",
+		"Contact TG/DMR ID": "91",
+	})
+
+	zones := readExportedCSV(t, tmp, "Zone.CSV", "Zone Name")
+	checkColumns(t, "Both", zones["Both"], map[string]string{
+		"Zone Channel Member":              "DMR Rpt|FM Rpt",
+		"Zone Channel Member RX Frequency": rx + "|" + rxFM,
+		"Zone Channel Member TX Frequency": tx + "|" + rxFM,
+		"A Channel":                        "DMR Rpt",
+	})
+
+	want := []string{"Channels: FM Rpt: CTCSS/DCS admit criteria is not supported"}
+	if !reflect.DeepEqual(unsupported, want) {
+		t.Errorf("got unsupported %q, want %q", unsupported, want)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"errors"
+	"sort"
+)
+
+// A BinDiff describes the differences between the bytes of two codeplugs
+// of the same type.
+type BinDiff struct {
+	Fields  []*FieldDiff
+	Unknown []*UnknownDiff
+}
+
+// A ByteDiff describes a byte that differs between two codeplugs.
+// Mask contains the differing bits of the byte.
+type ByteDiff struct {
+	Offset int
+	Old    byte
+	New    byte
+	Mask   byte
+}
+
+// A FieldDiff describes a field whose bits differ between two codeplugs.
+// The record and field indexes are the slots within the codeplug's
+// bytes, whether or not the record or field is in use.
+type FieldDiff struct {
+	RecordType     RecordType
+	RecordTypeName string
+	RecordIndex    int
+	RecordName     string
+	FieldType      FieldType
+	FieldTypeName  string
+	FieldIndex     int
+	MaxFields      int
+	Offset         int
+	BitOffset      int
+	BitSize        int
+	OldValue       string
+	NewValue       string
+	Bytes          []ByteDiff
+}
+
+// An UnknownDiff describes a run of differing bytes whose differing bits
+// are not covered by any field definition.  The context slices hold the
+// bytes of each codeplug surrounding the run, starting at ContextOffset.
+type UnknownDiff struct {
+	Bytes         []ByteDiff
+	ContextOffset int
+	OldContext    []byte
+	NewContext    []byte
+}
+
+// BinaryDiff compares the bytes of two loaded codeplugs of the same type
+// and maps each differing bit to the fields that contain it.  Differing
+// bits not contained in any field are reported as unknown regions with
+// up to context bytes of surrounding data.
+func BinaryDiff(oldCp *Codeplug, newCp *Codeplug, context int) (*BinDiff, error) {
+	if !oldCp.Loaded() || !newCp.Loaded() {
+		return nil, errors.New("codeplug not loaded")
+	}
+
+	if oldCp.codeplugInfo != newCp.codeplugInfo {
+		return nil, errors.New("codeplugs are of different types")
+	}
+
+	oldBytes := oldCp.bytes
+	newBytes := newCp.bytes
+	if len(oldBytes) != len(newBytes) {
+		return nil, errors.New("codeplugs are of different sizes")
+	}
+
+	diff := new(BinDiff)
+	covered := oldCp.fieldBits()
+
+	for _, ri := range oldCp.codeplugInfo.RecordInfos {
+		for rIndex := 0; rIndex < ri.max; rIndex++ {
+			for _, fi := range ri.fieldInfos {
+				mask := fi.byteMask()
+				for fIndex := 0; fIndex < fi.max; fIndex++ {
+					offset := fi.rdtOffset(ri, rIndex, fIndex)
+					size := fi.size()
+					if offset+size > len(oldBytes) {
+						continue
+					}
+
+					var byteDiffs []ByteDiff
+					for i := offset; i < offset+size; i++ {
+						bits := (oldBytes[i] ^ newBytes[i]) & mask
+						if bits != 0 {
+							byteDiffs = append(byteDiffs, ByteDiff{
+								Offset: i,
+								Old:    oldBytes[i],
+								New:    newBytes[i],
+								Mask:   bits,
+							})
+						}
+					}
+
+					if byteDiffs == nil {
+						continue
+					}
+
+					fieldDiff := &FieldDiff{
+						RecordType:     ri.rType,
+						RecordTypeName: ri.typeName,
+						RecordIndex:    rIndex,
+						FieldType:      fi.fType,
+						FieldTypeName:  fi.typeName,
+						FieldIndex:     fIndex,
+						MaxFields:      fi.max,
+						Offset:         offset,
+						BitOffset:      fi.bitOffset,
+						BitSize:        fi.bitSize,
+						OldValue:       oldCp.slotField(ri, rIndex, fi, fIndex).String(),
+						NewValue:       newCp.slotField(ri, rIndex, fi, fIndex).String(),
+						Bytes:          byteDiffs,
+					}
+
+					if ri.max > 1 {
+						fieldDiff.RecordName = newCp.slotRecordName(ri, rIndex)
+						if fieldDiff.RecordName == "" {
+							fieldDiff.RecordName = oldCp.slotRecordName(ri, rIndex)
+						}
+					}
+
+					diff.Fields = append(diff.Fields, fieldDiff)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(diff.Fields, func(i, j int) bool {
+		return diff.Fields[i].Bytes[0].Offset < diff.Fields[j].Bytes[0].Offset
+	})
+
+	var unknown *UnknownDiff
+	for i := range oldBytes {
+		bits := (oldBytes[i] ^ newBytes[i]) &^ covered[i]
+		if bits == 0 {
+			unknown = nil
+			continue
+		}
+
+		if unknown == nil {
+			unknown = new(UnknownDiff)
+			diff.Unknown = append(diff.Unknown, unknown)
+		}
+
+		unknown.Bytes = append(unknown.Bytes, ByteDiff{
+			Offset: i,
+			Old:    oldBytes[i],
+			New:    newBytes[i],
+			Mask:   bits,
+		})
+	}
+
+	for _, unknown := range diff.Unknown {
+		start := unknown.Bytes[0].Offset - context
+		if start < 0 {
+			start = 0
+		}
+		end := unknown.Bytes[len(unknown.Bytes)-1].Offset + 1 + context
+		if end > len(oldBytes) {
+			end = len(oldBytes)
+		}
+
+		unknown.ContextOffset = start
+		unknown.OldContext = append([]byte(nil), oldBytes[start:end]...)
+		unknown.NewContext = append([]byte(nil), newBytes[start:end]...)
+	}
+
+	return diff, nil
+}
+
+// slotField returns a field holding the value found in cp.bytes at the
+// given record and field slots, whether or not they are in use.
+func (cp *Codeplug) slotField(ri *recordInfo, rIndex int, fi *fieldInfo, fIndex int) *Field {
+	r := cp.newRecord(ri.rType, rIndex)
+	fd := &fDesc{fieldInfo: fi, record: r}
+	(*r.fDesc)[fi.fType] = fd
+
+	f := &Field{fDesc: fd, fIndex: fIndex}
+	f.value = newValue(fi.valueType)
+	f.load()
+	fd.fields = []*Field{f}
+
+	return f
+}
+
+// slotRecordName returns the name of the codeplug's record loaded from
+// the given record slot, or "" if the slot is not in use.
+func (cp *Codeplug) slotRecordName(ri *recordInfo, rIndex int) string {
+	for _, r := range cp.records(ri.rType) {
+		if r.rIndex == rIndex {
+			return r.Name()
+		}
+	}
+
+	return ""
+}
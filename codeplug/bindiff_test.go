@@ -0,0 +1,162 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"testing"
+)
+
+// readTestCodeplugs returns two codeplugs read from the rdt form of a
+// new codeplug of the given type.
+func readTestCodeplugs(t *testing.T, typ string) (*Codeplug, *Codeplug) {
+	t.Helper()
+
+	cp := newTestCodeplug(t, typ)
+	var buf bytes.Buffer
+	err := cp.WriteRdt(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	freqRange := AllFrequencyRanges()[typ][0]
+	var cps []*Codeplug
+	for i := 0; i < 2; i++ {
+		ncp, err := ReadCodeplug(FileTypeRdt, typ, freqRange, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cps = append(cps, ncp)
+	}
+
+	return cps[0], cps[1]
+}
+
+func TestBinaryDiffNone(t *testing.T) {
+	oldCp, newCp := readTestCodeplugs(t, "MD-380")
+
+	diff, err := BinaryDiff(oldCp, newCp, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Fields) != 0 || len(diff.Unknown) != 0 {
+		t.Errorf("got %d field and %d unknown diffs of identical codeplugs",
+			len(diff.Fields), len(diff.Unknown))
+	}
+}
+
+func TestBinaryDiffField(t *testing.T) {
+	oldCp, newCp := readTestCodeplugs(t, "MD-380")
+	r := newCp.Records(RtChannels_md380)[0]
+	f := r.Field(FtCiTot)
+	offset := f.fieldOffset(r, 0)
+
+	// The TOT is stored in units of 15 seconds.
+	newCp.bytes[offset] = 90 / 15
+
+	diff, err := BinaryDiff(oldCp, newCp, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Unknown) != 0 {
+		t.Errorf("got %d unknown diffs, want 0", len(diff.Unknown))
+	}
+	if len(diff.Fields) != 1 {
+		t.Fatalf("got %d field diffs, want 1", len(diff.Fields))
+	}
+
+	fd := diff.Fields[0]
+	if fd.RecordType != RtChannels_md380 || fd.RecordIndex != 0 || fd.RecordName != r.Name() {
+		t.Errorf("got record %s %d %s, want %s 0 %s",
+			fd.RecordType, fd.RecordIndex, fd.RecordName, RtChannels_md380, r.Name())
+	}
+	if fd.FieldType != FtCiTot || fd.FieldIndex != 0 {
+		t.Errorf("got field %s %d, want %s 0", fd.FieldType, fd.FieldIndex, FtCiTot)
+	}
+	if fd.OldValue != f.String() || fd.NewValue != "90" {
+		t.Errorf("got values %s to %s, want %s to 90", fd.OldValue, fd.NewValue, f.String())
+	}
+	if len(fd.Bytes) != 1 || fd.Bytes[0].Offset != offset || fd.Bytes[0].New != 90/15 {
+		t.Errorf("got byte diffs %+v", fd.Bytes)
+	}
+}
+
+func TestBinaryDiffUnknown(t *testing.T) {
+	oldCp, newCp := readTestCodeplugs(t, "MD-380")
+	offset, _ := unusedByte(t, newCp)
+	newCp.bytes[offset] ^= 0x81
+
+	context := 4
+	diff, err := BinaryDiff(oldCp, newCp, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Fields) != 0 {
+		t.Errorf("got %d field diffs, want 0", len(diff.Fields))
+	}
+	if len(diff.Unknown) != 1 {
+		t.Fatalf("got %d unknown diffs, want 1", len(diff.Unknown))
+	}
+
+	ud := diff.Unknown[0]
+	if len(ud.Bytes) != 1 || ud.Bytes[0].Offset != offset || ud.Bytes[0].Mask != 0x81 {
+		t.Errorf("got byte diffs %+v", ud.Bytes)
+	}
+
+	start := offset - context
+	if start < 0 {
+		start = 0
+	}
+	if ud.ContextOffset != start {
+		t.Errorf("got context offset %d, want %d", ud.ContextOffset, start)
+	}
+	end := offset + 1 + context
+	if !bytes.Equal(ud.OldContext, oldCp.bytes[start:end]) ||
+		!bytes.Equal(ud.NewContext, newCp.bytes[start:end]) {
+		t.Errorf("context doesn't match the bytes at %d-%d", start, end-1)
+	}
+}
+
+func TestBinaryDiffErrors(t *testing.T) {
+	oldCp, _ := readTestCodeplugs(t, "MD-380")
+	otherCp, _ := readTestCodeplugs(t, "MD-UV380")
+
+	_, err := BinaryDiff(oldCp, otherCp, 4)
+	if err == nil {
+		t.Error("diff of different codeplug types succeeded")
+	}
+
+	unloaded, err := NewCodeplug(FileTypeNew, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = BinaryDiff(oldCp, unloaded, 4)
+	if err == nil {
+		t.Error("diff with an unloaded codeplug succeeded")
+	}
+}
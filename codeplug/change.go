@@ -87,6 +87,7 @@ const (
 	InsertFieldsChange  ChangeType = "InsertFieldsChange"
 	RemoveFieldsChange  ChangeType = "RemoveFieldsChange"
 	RecordsFieldChange  ChangeType = "RecordsFieldChange"
+	RawChange           ChangeType = "RawChange"
 )
 
 func fieldChange(f *Field, previousValue string) *Change {
@@ -160,6 +161,17 @@ func recordsFieldChange(recs []*Record) *Change {
 	return &change
 }
 
+// rawChange returns a change of the raw values of a record.  A change
+// of the codeplug's raw values refers to its BasicInformation record.
+func rawChange(r *Record) *Change {
+	change := Change{
+		cType:   RawChange,
+		records: []*Record{r},
+	}
+
+	return &change
+}
+
 func recordsChange(t ChangeType, records []*Record) *Change {
 	change := Change{
 		cType:   t,
@@ -174,6 +186,10 @@ func (f *Field) Change(previousValue string) *Change {
 	return fieldChange(f, previousValue)
 }
 
+func (r *Record) RawChange() *Change {
+	return rawChange(r)
+}
+
 func (r *Record) MoveFieldsChange(fields []*Field) *Change {
 	return fieldsChange(MoveFieldsChange, r, fields)
 }
@@ -200,6 +216,23 @@ func (cp *Codeplug) InsertRecordsChange(records []*Record) *Change {
 	return change
 }
 
+// completeInsertRecordsChanges completes an InsertRecordsChange for
+// each type of record among the given records.
+func (cp *Codeplug) completeInsertRecordsChanges(records []*Record) {
+	var rTypes []RecordType
+	typeRecords := make(map[RecordType][]*Record)
+	for _, r := range records {
+		if typeRecords[r.rType] == nil {
+			rTypes = append(rTypes, r.rType)
+		}
+		typeRecords[r.rType] = append(typeRecords[r.rType], r)
+	}
+
+	for _, rType := range rTypes {
+		cp.InsertRecordsChange(typeRecords[rType]).Complete()
+	}
+}
+
 func (cp *Codeplug) RemoveRecordsChange(records []*Record) *Change {
 	change := recordsChange(RemoveRecordsChange, records)
 
@@ -237,3 +270,98 @@ func (change *Change) Changes() []*Change {
 func (change *Change) AddChange(newChange *Change) {
 	change.changes = append(change.changes, newChange)
 }
+
+// A ChangeFilter selects the changes passed to a subscriber.  A change
+// matches if its type, record type and field type are each found in
+// the corresponding slice.  An empty slice matches any value.
+type ChangeFilter struct {
+	ChangeTypes []ChangeType
+	RecordTypes []RecordType
+	FieldTypes  []FieldType
+}
+
+// matches returns true if the change is selected by the filter.
+func (filter ChangeFilter) matches(change *Change) bool {
+	if len(filter.ChangeTypes) > 0 {
+		found := false
+		for _, cType := range filter.ChangeTypes {
+			if cType == change.cType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(filter.RecordTypes) > 0 {
+		if len(change.records) == 0 {
+			return false
+		}
+		found := false
+		for _, rType := range filter.RecordTypes {
+			if rType == change.RecordType() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(filter.FieldTypes) > 0 {
+		found := false
+		for _, fType := range filter.FieldTypes {
+			if fType == change.FieldType() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// A Subscription is a function registered to receive a codeplug's
+// changes.
+type Subscription struct {
+	cp     *Codeplug
+	filter ChangeFilter
+	fn     func(*Change)
+}
+
+// SubscribeChanges causes fn to be called with each completed change
+// of the codeplug that matches filter.  Subscribers are called in the
+// order in which they subscribed.
+func (cp *Codeplug) SubscribeChanges(filter ChangeFilter, fn func(*Change)) *Subscription {
+	s := &Subscription{
+		cp:     cp,
+		filter: filter,
+		fn:     fn,
+	}
+	cp.subscriptions = append(cp.subscriptions, s)
+
+	return s
+}
+
+// Unsubscribe stops the delivery of changes to the subscription's
+// function.  It may be called from within that function.
+func (s *Subscription) Unsubscribe() {
+	cp := s.cp
+	if cp == nil {
+		return
+	}
+	s.cp = nil
+
+	for i, sub := range cp.subscriptions {
+		if sub == s {
+			cp.subscriptions = append(cp.subscriptions[:i], cp.subscriptions[i+1:]...)
+			break
+		}
+	}
+}
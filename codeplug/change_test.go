@@ -0,0 +1,152 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"reflect"
+	"testing"
+)
+
+// changeField sets a field of the record to value, publishing the
+// change.
+func changeField(t *testing.T, r *Record, fType FieldType, value string) {
+	t.Helper()
+
+	err := r.Field(fType).SetString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubscribeChangesOrder(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	r := cp.Records(RtChannels_md380)[0]
+
+	var got []int
+	for i := 0; i < 3; i++ {
+		i := i
+		cp.SubscribeChanges(ChangeFilter{}, func(*Change) {
+			got = append(got, i)
+		})
+	}
+
+	changeField(t, r, FtCiName, "A")
+	changeField(t, r, FtCiName, "B")
+
+	want := []int{0, 1, 2, 0, 1, 2}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got delivery order %v, want %v", got, want)
+	}
+}
+
+func TestSubscribeChangesFilter(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	channel := cp.Records(RtChannels_md380)[0]
+	contact := cp.Records(RtContacts)[0]
+
+	tests := []struct {
+		name   string
+		filter ChangeFilter
+		want   []FieldType
+	}{
+		{"none", ChangeFilter{},
+			[]FieldType{FtCiName, FtCiTot, FtDcCallID, ""}},
+		{"change type", ChangeFilter{ChangeTypes: []ChangeType{FieldChange}},
+			[]FieldType{FtCiName, FtCiTot, FtDcCallID}},
+		{"record type", ChangeFilter{RecordTypes: []RecordType{RtContacts}},
+			[]FieldType{FtDcCallID, ""}},
+		{"field type", ChangeFilter{FieldTypes: []FieldType{FtCiTot, FtDcCallID}},
+			[]FieldType{FtCiTot, FtDcCallID}},
+		{"all", ChangeFilter{
+			ChangeTypes: []ChangeType{FieldChange},
+			RecordTypes: []RecordType{RtChannels_md380},
+			FieldTypes:  []FieldType{FtCiName, FtDcCallID},
+		}, []FieldType{FtCiName}},
+	}
+
+	var subs []*Subscription
+	got := make([][]FieldType, len(tests))
+	for i, test := range tests {
+		i := i
+		subs = append(subs, cp.SubscribeChanges(test.filter, func(change *Change) {
+			got[i] = append(got[i], change.FieldType())
+		}))
+	}
+
+	changeField(t, channel, FtCiName, "A")
+	changeField(t, channel, FtCiTot, "90")
+	changeField(t, contact, FtDcCallID, "1234")
+	contact.RawChange().Complete()
+
+	for i, test := range tests {
+		if !reflect.DeepEqual(got[i], test.want) {
+			t.Errorf("%s: got changes of %v, want %v", test.name, got[i], test.want)
+		}
+		subs[i].Unsubscribe()
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	r := cp.Records(RtChannels_md380)[0]
+
+	var got []string
+	var second *Subscription
+	first := cp.SubscribeChanges(ChangeFilter{}, func(*Change) {
+		got = append(got, "first")
+		// Unsubscribing a later subscriber prevents its delivery
+		// of the current change.
+		second.Unsubscribe()
+	})
+	second = cp.SubscribeChanges(ChangeFilter{}, func(*Change) {
+		got = append(got, "second")
+	})
+	var third *Subscription
+	third = cp.SubscribeChanges(ChangeFilter{}, func(*Change) {
+		got = append(got, "third")
+		third.Unsubscribe()
+	})
+
+	changeField(t, r, FtCiName, "A")
+	want := []string{"first", "third"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got deliveries %v, want %v", got, want)
+	}
+
+	first.Unsubscribe()
+	first.Unsubscribe()
+
+	got = nil
+	changeField(t, r, FtCiName, "B")
+	if len(got) != 0 {
+		t.Errorf("unsubscribed functions got deliveries %v", got)
+	}
+	if len(cp.subscriptions) != 0 {
+		t.Errorf("%d subscriptions remain", len(cp.subscriptions))
+	}
+}
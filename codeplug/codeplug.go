@@ -40,10 +40,11 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
-	l "github.com/dalefarnsworth/codeplug/debug"
 	"github.com/dalefarnsworth/codeplug/dfu"
 	"github.com/tealeg/xlsx"
 )
@@ -59,6 +60,7 @@ const (
 	FileTypeText
 	FileTypeJSON
 	FileTypeXLSX
+	FileTypeCSV
 )
 
 const (
@@ -83,13 +85,16 @@ type Codeplug struct {
 	highFrequency      float64
 	lowFrequencyB      float64
 	highFrequencyB     float64
-	connectChange      func(*Change)
+	subscriptions      []*Subscription
 	codeplugInfo       *CodeplugInfo
 	loaded             bool
 	cachedNameToRt     map[string]RecordType
 	cachedNameToFt     map[RecordType]map[string]FieldType
 	gpsEnabled         bool
 	uniqueContactNames bool
+	source             []byte
+	raws               []*rawValue
+	cachedFieldBits    []byte
 
 	warnings []string
 }
@@ -106,56 +111,105 @@ type CodeplugInfo struct {
 }
 
 // NewCodeplug returns a Codeplug, given a filename and codeplug type.
+// If the type is FileTypeNone, it is determined from the file's contents.
 func NewCodeplug(fType FileType, filename string) (*Codeplug, error) {
 	cp := new(Codeplug)
 	cp.fileType = fType
 
 	switch fType {
-	case FileTypeNone:
+	case FileTypeNone, FileTypeRdt, FileTypeBin:
 		err := cp.findFileType(filename)
 		if err != nil {
 			return nil, err
 		}
-		if cp.fileType == FileTypeRdt {
-			err = cp.read(filename)
-			if err != nil {
-				cp.fileType = FileTypeNone
-				return nil, err
+		if fType != FileTypeNone && cp.fileType != fType {
+			return nil, fmt.Errorf("%s: %s", filename, errWrongFileSize)
+		}
+	}
+
+	return cp.init(filename)
+}
+
+var errWrongFileSize = fmt.Errorf("wrong size for the codeplug file type")
+
+// NewCodeplugFromReader returns a Codeplug whose contents are read from
+// reader rather than from a file.  If the type is FileTypeNone, it is
+// determined from the contents.  The name is used as the codeplug's
+// filename by Save.  CSV codeplugs are directories and can't be read
+// from a reader.
+func NewCodeplugFromReader(fType FileType, name string, reader io.Reader) (*Codeplug, error) {
+	if fType == FileTypeCSV {
+		return nil, fmt.Errorf("a CSV codeplug must be read from a directory")
+	}
+
+	source, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := new(Codeplug)
+	cp.fileType = fType
+	cp.source = source
+
+	switch fType {
+	case FileTypeNone, FileTypeRdt, FileTypeBin:
+		err := cp.detectFileType(source)
+		if err == nil && fType != FileTypeNone && cp.fileType != fType {
+			err = errWrongFileSize
+		}
+		if err != nil {
+			if name != "" {
+				err = fmt.Errorf("%s %s", name, err.Error())
 			}
+			return nil, err
+		}
+	}
+
+	return cp.init(name)
+}
+
+// ReadCodeplug returns a loaded Codeplug of the given codeplug type and
+// frequency range whose contents are read from reader.
+func ReadCodeplug(fType FileType, typ string, freqRange string, reader io.Reader) (*Codeplug, error) {
+	cp, err := NewCodeplugFromReader(fType, "", reader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cp.Load(typ, freqRange)
+	if err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// init completes the initialization of a new codeplug, after its
+// file type is known.
+func (cp *Codeplug) init(filename string) (*Codeplug, error) {
+	switch cp.fileType {
+	case FileTypeRdt:
+		err := cp.read(filename)
+		if err != nil {
+			cp.fileType = FileTypeNone
+			return nil, err
 		}
 
-	case FileTypeText, FileTypeJSON, FileTypeXLSX:
+	case FileTypeBin:
+
+	case FileTypeText, FileTypeJSON, FileTypeXLSX, FileTypeCSV:
 		cp.importFilename = filename
 		fallthrough
 
 	case FileTypeNew:
-		baseName := "codeplug"
-		for i := 1; ; i++ {
-			filename = fmt.Sprintf("%s%d", baseName, i)
-
-			found := false
-			for _, cp := range codeplugs {
-				if strings.HasPrefix(cp.filename, filename) {
-					found = true
-					break
-				}
-			}
-			if !found {
-				matches, err := filepath.Glob(filename + "*")
-				if err != nil {
-					l.Fatal(err.Error())
-				}
-				if len(matches) != 0 {
-					found = true
-					break
-				}
-			}
-			if !found {
-				break
-			}
+		var err error
+		filename, err = newFilename()
+		if err != nil {
+			return nil, err
 		}
+
 	default:
-		l.Fatal("unknown file type")
+		return nil, fmt.Errorf("unknown file type: %d", cp.fileType)
 	}
 
 	cp.filename = filename
@@ -166,13 +220,47 @@ func NewCodeplug(fType FileType, filename string) (*Codeplug, error) {
 	return cp, nil
 }
 
+// newFilename returns a filename for a new codeplug that is not used
+// by any open codeplug or existing file.
+func newFilename() (string, error) {
+	var filename string
+
+	baseName := "codeplug"
+	for i := 1; ; i++ {
+		filename = fmt.Sprintf("%s%d", baseName, i)
+
+		found := false
+		for _, cp := range Codeplugs() {
+			if strings.HasPrefix(cp.filename, filename) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			matches, err := filepath.Glob(filename + "*")
+			if err != nil {
+				return "", err
+			}
+			if len(matches) != 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+
+	return filename, nil
+}
+
 type Warning struct {
 	error
 }
 
 func (cp *Codeplug) Load(typ string, freqRange string) error {
 	cp.codeplugInfo = nil
-	for _, cpi := range codeplugInfos {
+	for _, cpi := range allCodeplugInfos() {
 		if typ == cpi.Type {
 			cp.codeplugInfo = cpi
 		}
@@ -181,9 +269,10 @@ func (cp *Codeplug) Load(typ string, freqRange string) error {
 	if cp.codeplugInfo == nil {
 		return fmt.Errorf("codeplug type not found: %s", typ)
 	}
+	cp.cachedFieldBits = nil
 
 	switch cp.fileType {
-	case FileTypeNew, FileTypeBin, FileTypeText, FileTypeJSON, FileTypeXLSX:
+	case FileTypeNew, FileTypeBin, FileTypeText, FileTypeJSON, FileTypeXLSX, FileTypeCSV:
 		freqRange = strings.Replace(freqRange, " ", "_", -1)
 		filename := cp.Type() + "_" + freqRange + "." + cp.Ext()
 		err := cp.readNew(filename)
@@ -194,6 +283,7 @@ func (cp *Codeplug) Load(typ string, freqRange string) error {
 
 	switch cp.fileType {
 	case FileTypeRdt, FileTypeBin:
+		cp.setFileSize()
 		err := cp.read(cp.filename)
 		if err != nil {
 			return err
@@ -206,21 +296,29 @@ func (cp *Codeplug) Load(typ string, freqRange string) error {
 	}
 
 	switch cp.fileType {
-	case FileTypeText, FileTypeJSON, FileTypeXLSX:
+	case FileTypeText, FileTypeJSON, FileTypeXLSX, FileTypeCSV:
 		cp.RemoveAllRecords()
 
 		var err error
 		switch cp.fileType {
-		case FileTypeText:
-			file, err := os.Open(cp.importFilename)
-			if err == nil {
-				defer file.Close()
+		case FileTypeText, FileTypeJSON, FileTypeXLSX:
+			var file io.ReadCloser
+			file, err = cp.open(cp.importFilename)
+			if err != nil {
+				break
+			}
+			defer file.Close()
+
+			switch cp.fileType {
+			case FileTypeText:
 				err = cp.importText(file)
+			case FileTypeJSON:
+				err = cp.importJSON(file)
+			case FileTypeXLSX:
+				err = cp.importXLSX(file)
 			}
-		case FileTypeJSON:
-			err = cp.importJSON(cp.importFilename)
-		case FileTypeXLSX:
-			err = cp.importXLSX(cp.importFilename)
+		case FileTypeCSV:
+			err = cp.importCSV(cp.importFilename)
 		}
 
 		cp.AddMissingFields()
@@ -234,8 +332,8 @@ func (cp *Codeplug) Load(typ string, freqRange string) error {
 		}
 	}
 
-	codeplugs = append(codeplugs, cp)
 	cp.loaded = true
+	cp.source = nil
 
 	return nil
 }
@@ -246,7 +344,7 @@ func (cp *Codeplug) Loaded() bool {
 
 func (cp *Codeplug) AllExts() []string {
 	extMap := make(map[string]bool)
-	for _, cpi := range codeplugInfos {
+	for _, cpi := range allCodeplugInfos() {
 		extMap[cpi.Ext] = true
 	}
 	exts := make([]string, 0, len(extMap))
@@ -267,7 +365,7 @@ func (cp *Codeplug) CodeplugInfo() *CodeplugInfo {
 
 func ModelTypes(model string) string {
 	types := make([]string, 0)
-	for _, cpi := range codeplugInfos {
+	for _, cpi := range allCodeplugInfos() {
 		for _, cpModel := range cpi.Models {
 			if cpModel == model {
 				types = append(types, cpi.Type)
@@ -347,7 +445,7 @@ func (cp *Codeplug) TextLines() []string {
 func AllFrequencyRanges() map[string][]string {
 	freqRanges := make(map[string][]string)
 
-	for _, cpi := range codeplugInfos {
+	for _, cpi := range allCodeplugInfos() {
 		model := cpi.Type
 		for _, rInfo := range cpi.RecordInfos {
 			if rInfo.rType == RtBasicInformation_md380 {
@@ -363,7 +461,7 @@ func AllFrequencyRanges() map[string][]string {
 		}
 	}
 
-	for _, cpi := range codeplugInfos {
+	for _, cpi := range allCodeplugInfos() {
 		model := cpi.Type
 		for _, rInfo := range cpi.RecordInfos {
 			if rInfo.rType == RtBasicInformation_md380 {
@@ -396,14 +494,14 @@ func (cp *Codeplug) TypesFrequencyRanges() (types []string, freqRanges map[strin
 	switch cp.fileType {
 	case FileTypeRdt:
 
-	case FileTypeText, FileTypeJSON, FileTypeXLSX:
+	case FileTypeText, FileTypeJSON, FileTypeXLSX, FileTypeCSV:
 		model, freqRange = cp.parseModelFrequencyRange()
 		fallthrough
 	default:
-		cp.bytes = make([]byte, codeplugInfos[0].RdtSize)
+		cp.bytes = make([]byte, maxRdtSize())
 	}
 
-	for _, cpi := range codeplugInfos {
+	for _, cpi := range allCodeplugInfos() {
 		cp.codeplugInfo = cpi
 		cp.loadHeader()
 		typ := cp.Type()
@@ -521,25 +619,53 @@ func (cp *Codeplug) Warnings() []string {
 	return cp.warnings
 }
 
-// Codeplugs returns a slice containing all currently open codeplugs.
+// Register adds the codeplug to the registry of open codeplugs
+// returned by Codeplugs.  Codeplugs are independent of each other, so
+// only an application that shares codeplugs among its windows, like
+// editcp, needs to register them.
+func (cp *Codeplug) Register() {
+	codeplugsMutex.Lock()
+	defer codeplugsMutex.Unlock()
+
+	for _, codeplug := range codeplugs {
+		if cp == codeplug {
+			return
+		}
+	}
+	codeplugs = append(codeplugs, cp)
+}
+
+// Codeplugs returns a slice containing all currently registered codeplugs.
 func Codeplugs() []*Codeplug {
-	return codeplugs
+	codeplugsMutex.Lock()
+	defer codeplugsMutex.Unlock()
+
+	return append([]*Codeplug(nil), codeplugs...)
 }
 
-// Free frees a codeplug
+// Free frees a codeplug, removing it from the registry if it
+// was registered.
 func (cp *Codeplug) Free() {
+	codeplugsMutex.Lock()
 	for i, codeplug := range codeplugs {
 		if cp == codeplug {
 			codeplugs = append(codeplugs[:i], codeplugs[i+1:]...)
-			for _, rd := range cp.rDesc {
-				rd.codeplug = nil
-			}
 			break
 		}
 	}
+	codeplugsMutex.Unlock()
+
+	for _, rd := range cp.rDesc {
+		rd.codeplug = nil
+	}
 }
 
 func (cp *Codeplug) readNew(filename string) error {
+	if template := definedTemplate(filename); template != nil {
+		cp.bytes = append([]byte(nil), template...)
+		return nil
+	}
+
 	archive := bzip2.NewReader(bytes.NewReader(new_tar_bz2))
 	tarfile := tar.NewReader(archive)
 
@@ -550,14 +676,14 @@ func (cp *Codeplug) readNew(filename string) error {
 			if err == io.EOF {
 				break
 			}
-			l.Fatal(err)
+			return fmt.Errorf("codeplug templates: %s", err.Error())
 		}
 		if hdr.Name != filename {
 			continue
 		}
 		bytes, err = ioutil.ReadAll(tarfile)
 		if err != nil {
-			l.Fatal(err)
+			return fmt.Errorf("codeplug template %s: %s", filename, err.Error())
 		}
 		break
 	}
@@ -571,9 +697,19 @@ func (cp *Codeplug) readNew(filename string) error {
 	return nil
 }
 
+// open returns a reader of the named file or, for a codeplug created
+// by NewCodeplugFromReader, of the contents read from its reader.
+func (cp *Codeplug) open(filename string) (io.ReadCloser, error) {
+	if cp.source != nil {
+		return ioutil.NopCloser(bytes.NewReader(cp.source)), nil
+	}
+
+	return os.Open(filename)
+}
+
 // read opens a file and reads its contents into cp.bytes.
 func (cp *Codeplug) read(filename string) error {
-	file, err := os.Open(filename)
+	file, err := cp.open(filename)
 	if err != nil {
 		return err
 	}
@@ -584,8 +720,8 @@ func (cp *Codeplug) read(filename string) error {
 	}
 	bytes := make([]byte, cp.fileSize)
 
-	bytesRead, err := file.Read(bytes)
-	if err != nil {
+	bytesRead, err := io.ReadFull(file, bytes)
+	if err != nil && err != io.ErrUnexpectedEOF {
 		return err
 	}
 
@@ -631,7 +767,10 @@ func (cp *Codeplug) Revert() error {
 		r.Field(FtCiTalkaround).SetString("Off")
 	}
 
-	cp.store()
+	err := cp.store()
+	if err != nil {
+		return err
+	}
 
 	cp.Valid()
 
@@ -668,12 +807,6 @@ func (cp *Codeplug) SaveAs(filename string) error {
 // The state of the codeplug is not changed, so this
 // is useful for use by an autosave function.
 func (cp *Codeplug) SaveToFile(filename string) (err error) {
-	cp.Valid()
-
-	cp.setLastProgrammedTime(time.Now())
-
-	cp.store()
-
 	dir, base := filepath.Split(filename)
 	tmpFile, err := ioutil.TempFile(dir, base)
 	if err != nil {
@@ -695,23 +828,60 @@ func (cp *Codeplug) SaveToFile(filename string) (err error) {
 		err = os.Rename(tmpFilename, filename)
 	}()
 
+	return cp.WriteRdt(tmpFile)
+}
+
+// WriteRdt writes the state of the Codeplug to w as an rdt file.
+// Like SaveToFile, it doesn't change the state of the codeplug.
+func (cp *Codeplug) WriteRdt(w io.Writer) error {
+	cp.Valid()
+
+	cp.setLastProgrammedTime(time.Now())
+
+	err := cp.store()
+	if err != nil {
+		return err
+	}
+
 	cpi := cp.codeplugInfo
 	fileSize := cpi.RdtSize
 	fileOffset := 0
 
-	bytes := cp.bytes[fileOffset : fileOffset+fileSize]
-	bytesWritten, err := tmpFile.Write(bytes)
+	_, err = w.Write(cp.bytes[fileOffset : fileOffset+fileSize])
+	return err
+}
+
+// WriteBin writes the state of the Codeplug to w as a bin file,
+// the form in which it is written to the radio.
+func (cp *Codeplug) WriteBin(w io.Writer) error {
+	cp.Valid()
+
+	cp.setLastProgrammedTime(time.Now())
+
+	err := cp.store()
 	if err != nil {
 		return err
 	}
 
-	if bytesWritten != fileSize {
-		return fmt.Errorf("write to %s failed", cp.filename)
-	}
-
+	_, err = w.Write(cp.binBytes())
 	return err
 }
 
+// binBytes returns a copy of the codeplug's bytes without the rdt
+// file's header and trailer.
+func (cp *Codeplug) binBytes() []byte {
+	cpi := cp.codeplugInfo
+
+	binBytes := make([]byte, 0, cpi.RdtSize-cpi.HeaderSize-cpi.TrailerSize)
+	binBytes = append(binBytes, cp.bytes[cpi.HeaderSize:cpi.TrailerOffset]...)
+
+	begin := cpi.TrailerOffset + cpi.TrailerSize
+	end := len(cp.bytes)
+	binBytes = append(binBytes, cp.bytes[begin:end]...)
+
+	return binBytes
+}
+
 func (cp *Codeplug) setLastProgrammedTime(t time.Time) {
 	r := cp.rDesc[RtBasicInformation_md380].records[0]
 	f := r.Field(FtBiLastProgrammedTime)
@@ -736,12 +906,9 @@ func (cp *Codeplug) CurrentHash() [sha256.Size]byte {
 		return cp.hash
 	}
 
-	bytes := make([]byte, len(cp.bytes))
-	copy(bytes, cp.bytes)
-	saveBytes := cp.bytes
-	cp.bytes = bytes
-	cp.store()
-	cp.bytes = saveBytes
+	// A field that can't be stored leaves its bytes unchanged.  The
+	// error is reported when the codeplug is written.
+	bytes, _ := cp.currentBytes()
 
 	return sha256.Sum256(bytes)
 }
@@ -812,7 +979,7 @@ func (cp *Codeplug) RecordTypes() []RecordType {
 	indexes := make([]int, 0, len(cp.rDesc))
 
 	for rType, rDesc := range cp.rDesc {
-		index := rDesc.recordInfo.index
+		index := rDesc.index
 		indexes = append(indexes, index)
 		indexedStrs[index] = string(rType)
 	}
@@ -849,14 +1016,17 @@ func (cp *Codeplug) ID() string {
 }
 
 // MoveRecord moves a record from its current slice index to the given index.
-func (cp *Codeplug) MoveRecord(dIndex int, r *Record) {
+func (cp *Codeplug) MoveRecord(dIndex int, r *Record) error {
 	sIndex := r.rIndex
-	cp.RemoveRecord(r)
+	err := cp.RemoveRecord(r)
+	if err != nil {
+		return err
+	}
 	if sIndex < dIndex {
 		dIndex--
 	}
 	r.rIndex = dIndex
-	cp.InsertRecord(r)
+	return cp.InsertRecord(r)
 }
 
 // InsertRecord inserts the given record into the codeplug.
@@ -896,8 +1066,9 @@ func (cp *Codeplug) AppendRecord(r *Record) error {
 	return cp.InsertRecord(r)
 }
 
-// RemoveRecord removes the given record from the codeplug.
-func (cp *Codeplug) RemoveRecord(r *Record) {
+// RemoveRecord removes the given record from the codeplug.  An error is
+// returned if the record isn't in the codeplug.
+func (cp *Codeplug) RemoveRecord(r *Record) error {
 	rType := r.rType
 	index := -1
 	records := cp.records(rType)
@@ -908,7 +1079,7 @@ func (cp *Codeplug) RemoveRecord(r *Record) {
 		}
 	}
 	if index < 0 || index >= len(records) {
-		l.Fatal("removeRecord: bad record")
+		return fmt.Errorf("%s %s: record not in codeplug", rType, r.Name())
 	}
 
 	deleteRecord(&records, index)
@@ -919,6 +1090,8 @@ func (cp *Codeplug) RemoveRecord(r *Record) {
 
 	cp.rDesc[rType].records = records
 	cp.rDesc[rType].cachedListNames = nil
+
+	return nil
 }
 
 func (cp *Codeplug) RemoveAllRecords() {
@@ -926,10 +1099,8 @@ func (cp *Codeplug) RemoveAllRecords() {
 		if cp.MaxRecords(rType) == 1 {
 			continue
 		}
-		records := cp.records(rType)
-		for i := len(records) - 1; i >= 0; i-- {
-			cp.RemoveRecord(records[i])
-		}
+		cp.rDesc[rType].records = []*Record{}
+		cp.rDesc[rType].cachedListNames = nil
 	}
 }
 
@@ -953,17 +1124,10 @@ func (cp *Codeplug) AddMissingFields() {
 	}
 }
 
-// ConnectChange will cause the given function to be called passing
-// the given change.
-func (cp *Codeplug) ConnectChange(fn func(*Change)) {
-	cp.connectChange = fn
-}
-
 // loadHeader loads the rdt header into the codeplug from its file.
 func (cp *Codeplug) loadHeader() {
 	cp.clearCachedListNames()
 	ri := cp.codeplugInfo.RecordInfos[0]
-	ri.max = 1
 
 	rd := &rDesc{recordInfo: ri}
 	cp.rDesc[ri.rType] = rd
@@ -975,16 +1139,15 @@ func (cp *Codeplug) loadHeader() {
 func (cp *Codeplug) load() {
 	cp.clearCachedListNames()
 	for i, ri := range cp.codeplugInfo.RecordInfos {
-		ri.index = i
-		if ri.max == 0 {
-			ri.max = 1
-		}
-
-		rd := &rDesc{recordInfo: ri}
+		rd := &rDesc{recordInfo: ri, index: i}
 		cp.rDesc[ri.rType] = rd
 		rd.codeplug = cp
 		rd.loadRecords()
 	}
+
+	for _, rv := range cp.raws {
+		rv.load(cp.bytes[rv.offset:])
+	}
 }
 
 // newRecord creates and returns the address of a new record of the given type.
@@ -1019,7 +1182,8 @@ func (cp *Codeplug) Valid() bool {
 	return true
 }
 
-// findFileType sets the codeplug type based on file size
+// findFileType sets the codeplug type based on the file's contents.
+// A directory is taken to be a CSV codeplug.
 func (cp *Codeplug) findFileType(filename string) error {
 	fileInfo, err := os.Stat(filename)
 	if err != nil {
@@ -1030,16 +1194,40 @@ func (cp *Codeplug) findFileType(filename string) error {
 		return err
 	}
 
-	for _, cpi := range codeplugInfos {
+	if fileInfo.IsDir() {
+		cp.fileType = FileTypeCSV
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		cp.fileType = FileTypeNone
+		return err
+	}
+
+	err = cp.detectFileType(data)
+	if err != nil {
+		return fmt.Errorf("%s %s", filename, err.Error())
+	}
+
+	return nil
+}
+
+// detectFileType sets the codeplug type based on the contents of its
+// file.  Rdt and bin files are recognized by their size, spreadsheets by
+// their zip signature, JSON files by their leading brace and text files
+// by a leading record name.
+func (cp *Codeplug) detectFileType(data []byte) error {
+	for _, cpi := range allCodeplugInfos() {
 		cp.rdtSize = cpi.RdtSize
-		switch fileInfo.Size() {
-		case int64(cpi.RdtSize):
+		switch len(data) {
+		case cpi.RdtSize:
 			cp.fileType = FileTypeRdt
 			cp.fileSize = cpi.RdtSize
 			cp.fileOffset = 0
 			return nil
 
-		case int64(cpi.RdtSize - cpi.HeaderSize - cpi.TrailerSize):
+		case cpi.RdtSize - cpi.HeaderSize - cpi.TrailerSize:
 			cp.fileType = FileTypeBin
 			cp.fileSize = cpi.RdtSize - cpi.HeaderSize - cpi.TrailerSize
 			cp.fileOffset = cpi.HeaderSize
@@ -1047,25 +1235,70 @@ func (cp *Codeplug) findFileType(filename string) error {
 		}
 	}
 
+	text := bytes.TrimLeftFunc(data, unicode.IsSpace)
+	r, _ := utf8.DecodeRune(text)
+
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		cp.fileType = FileTypeXLSX
+		return nil
+
+	case !utf8.Valid(text):
+
+	case r == '{':
+		cp.fileType = FileTypeJSON
+		return nil
+
+	case unicode.IsLetter(r):
+		cp.fileType = FileTypeText
+		return nil
+	}
+
 	cp.fileType = FileTypeNone
-	err = fmt.Errorf("%s is not a known codeplug file type", filename)
-	return err
+	return fmt.Errorf("is not a known codeplug file type")
+}
+
+// setFileSize sets the size and offset of the codeplug's rdt or bin
+// file contents from the codeplug's type.
+func (cp *Codeplug) setFileSize() {
+	cpi := cp.codeplugInfo
+
+	cp.rdtSize = cpi.RdtSize
+	cp.fileSize = cpi.RdtSize
+	cp.fileOffset = 0
+
+	if cp.fileType == FileTypeBin {
+		cp.fileSize = cpi.RdtSize - cpi.HeaderSize - cpi.TrailerSize
+		cp.fileOffset = cpi.HeaderSize
+	}
 }
 
 // store stores all all fields of the codeplug into its byte slice.
-func (cp *Codeplug) store() {
+func (cp *Codeplug) store() error {
 	for _, rd := range cp.rDesc {
 		for rIndex := 0; rIndex < rd.max; rIndex++ {
 			if rIndex < len(rd.records) {
-				rd.records[rIndex].store()
+				err := rd.records[rIndex].store()
+				if err != nil {
+					return err
+				}
 			} else if rd.delDesc != nil {
-				rd.deleteRecord(cp, rIndex)
+				err := rd.deleteRecord(cp, rIndex)
+				if err != nil {
+					return err
+				}
 			} else {
 				r := cp.newRecord(rd.rType, rIndex)
 				cp.InsertRecord(r)
 			}
 		}
 	}
+
+	for _, rv := range cp.raws {
+		rv.store(cp.bytes[rv.offset:])
+	}
+
+	return nil
 }
 
 func (cp *Codeplug) FrequencyValidA(freq float64) bool {
@@ -1128,15 +1361,58 @@ func (cp *Codeplug) frequencyValid(freq float64) error {
 }
 
 // publishChange passes the given change (with any additional generated
-// changes resulting from that change) to a registered function.
+// changes resulting from that change) to each subscriber whose filter
+// matches it, in the order of subscription.
 func (cp *Codeplug) publishChange(change *Change) {
-	if cp.connectChange != nil {
-		cp.connectChange(change)
+	subscriptions := append([]*Subscription(nil), cp.subscriptions...)
+	for _, s := range subscriptions {
+		if s.cp != nil && s.filter.matches(change) {
+			s.fn(change)
+		}
 	}
 }
 
-// codeplugs contains the list of open codeplugs.
+// codeplugs is the registry of codeplugs added by Register.
 var codeplugs []*Codeplug
+var codeplugsMutex sync.Mutex
+
+// init fills in the defaults of the static record and field
+// information, so that loading a codeplug doesn't modify state
+// shared with other codeplugs.
+func init() {
+	for _, cpi := range codeplugInfos {
+		normalizeCodeplugInfo(cpi)
+	}
+}
+
+// normalizeCodeplugInfo fills in the defaults of the codeplug's record
+// and field information.
+func normalizeCodeplugInfo(cpi *CodeplugInfo) {
+	for _, ri := range cpi.RecordInfos {
+		if ri.max == 0 {
+			ri.max = 1
+		}
+
+		for _, fi := range ri.fieldInfos {
+			if fi.max == 0 {
+				fi.max = 1
+			}
+			if fi.valueType == VtName || fi.valueType == VtContactName {
+				ri.nameFieldType = fi.fType
+			}
+
+			span := fi.span
+			if span != nil {
+				if span.scale == 0 {
+					span.scale = 1
+				}
+				if span.interval == 0 {
+					span.interval = 1
+				}
+			}
+		}
+	}
+}
 
 func PrintRecord(w io.Writer, r *Record) {
 	rType := r.Type()
@@ -1445,7 +1721,7 @@ func (e *PositionError) Column() int {
 
 func (cp *Codeplug) parseModelFrequencyRange() (model string, freqRange string) {
 	var freqRangeB string
-	file, err := os.Open(cp.importFilename)
+	file, err := cp.open(cp.importFilename)
 	if err != nil {
 		return model, freqRange
 	}
@@ -1462,6 +1738,9 @@ func (cp *Codeplug) parseModelFrequencyRange() (model string, freqRange string)
 
 	case FileTypeXLSX:
 		pRecs = cp.parseXLSXFile(file)
+
+	case FileTypeCSV:
+		pRecs = cp.parseCSVDir(cp.importFilename)
 	}
 
 	for _, pr := range pRecs {
@@ -1819,7 +2098,8 @@ func (cp *Codeplug) rNameToRecord(name string, index int) (*Record, error) {
 	return nil, fmt.Errorf("codeplug has no record: %s", string(rType))
 }
 
-func (cp *Codeplug) exportText(filename string, pr func(io.Writer, *Record)) (err error) {
+// createFile creates the named file and calls write to write its contents.
+func createFile(filename string, write func(io.Writer) error) (err error) {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -1832,7 +2112,11 @@ func (cp *Codeplug) exportText(filename string, pr func(io.Writer, *Record)) (er
 		return
 	}()
 
-	w := bufio.NewWriter(file)
+	return write(file)
+}
+
+func (cp *Codeplug) writeText(iw io.Writer, pr func(io.Writer, *Record), prRaw func(io.Writer, *rawValue)) error {
+	w := bufio.NewWriter(iw)
 	for i, rType := range cp.RecordTypes() {
 		for j, r := range cp.records(rType) {
 			if i != 0 || j != 0 {
@@ -1841,22 +2125,50 @@ func (cp *Codeplug) exportText(filename string, pr func(io.Writer, *Record)) (er
 			pr(w, r)
 		}
 	}
-	w.Flush()
 
-	return nil
+	for _, rv := range cp.allRaws() {
+		fmt.Fprintln(w)
+		prRaw(w, rv)
+	}
+
+	return w.Flush()
+}
+
+// WriteText writes the codeplug's records to w in text form.
+// The codeplug's raw values follow as Raw pseudo-records.
+func (cp *Codeplug) WriteText(w io.Writer) error {
+	return cp.writeText(w, PrintRecord, printRaw)
+}
+
+// WriteTextOneLineRecords writes the codeplug's records to w in text
+// form, one record per line.
+func (cp *Codeplug) WriteTextOneLineRecords(w io.Writer) error {
+	return cp.writeText(w, PrintOneLineRecord, printOneLineRaw)
 }
 
 func (cp *Codeplug) ExportText(filename string) (err error) {
-	return cp.exportText(filename, PrintRecord)
+	return createFile(filename, cp.WriteText)
 }
 
 func (cp *Codeplug) ExportTextOneLineRecords(filename string) (err error) {
-	return cp.exportText(filename, PrintOneLineRecord)
+	return createFile(filename, cp.WriteTextOneLineRecords)
 }
 
 func (cp *Codeplug) importText(reader io.Reader) error {
+	pRecs, raws, err := cp.parseRawRecords(cp.parseTextFile(reader))
+	if err != nil {
+		return err
+	}
+
 	deferValues := false
-	records, _, err := cp.ParseRecords(reader, deferValues)
+	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
+	if err != nil {
+		return err
+	}
+
+	for _, rv := range raws {
+		setRawValue(&cp.raws, rv)
+	}
 	err = cp.storeParsedRecords(records)
 	if err != nil {
 		return err
@@ -1872,18 +2184,11 @@ func (cp *Codeplug) ImportText(reader io.Reader) error {
 }
 
 func (cp *Codeplug) ExportJSON(filename string) error {
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		fErr := file.Close()
-		if err == nil {
-			err = fErr
-		}
-		return
-	}()
+	return createFile(filename, cp.WriteJSON)
+}
 
+// WriteJSON writes the codeplug's records to w as a JSON object.
+func (cp *Codeplug) WriteJSON(w io.Writer) error {
 	recordTypes := cp.RecordTypes()
 	recordMap := make(map[string]interface{})
 	for _, rType := range recordTypes {
@@ -1915,32 +2220,41 @@ func (cp *Codeplug) ExportJSON(filename string) error {
 		}
 	}
 
-	writer := bufio.NewWriter(file)
+	raws := cp.allRaws()
+	if len(raws) > 0 {
+		rawSlice := make([]map[string]interface{}, len(raws))
+		for i, rv := range raws {
+			rawSlice[i] = rawFieldMap(rv)
+		}
+		recordMap[rawRecordName] = rawSlice
+	}
+
+	writer := bufio.NewWriter(w)
 	encoder := json.NewEncoder(writer)
 	encoder.SetIndent("", "\t")
-	err = encoder.Encode(recordMap)
+	err := encoder.Encode(recordMap)
 	if err != nil {
 		return err
 	}
-	writer.Flush()
 
-	return nil
+	return writer.Flush()
 }
 
-func (cp *Codeplug) importJSON(filename string) error {
-	file, err := os.Open(filename)
+func (cp *Codeplug) importJSON(reader io.Reader) error {
+	pRecs, raws, err := cp.parseRawRecords(cp.parseJSONFile(reader))
 	if err != nil {
 		return err
 	}
-	defer file.Close()
 
-	pRecs := cp.parseJSONFile(file)
 	deferValues := false
 	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
 	if err != nil {
 		return err
 	}
 
+	for _, rv := range raws {
+		setRawValue(&cp.raws, rv)
+	}
 	err = cp.storeParsedRecords(records)
 	if err != nil {
 		return err
@@ -2052,6 +2366,12 @@ func (cp *Codeplug) parseJSONFields(fMap map[string]interface{}) []*parsedField
 }
 
 func (cp *Codeplug) ExportXLSX(filename string) error {
+	return createFile(filename, cp.WriteXLSX)
+}
+
+// WriteXLSX writes the codeplug's records to w as a spreadsheet, with
+// a sheet for each record type.
+func (cp *Codeplug) WriteXLSX(w io.Writer) error {
 	var file *xlsx.File
 	var sheet *xlsx.Sheet
 	var row *xlsx.Row
@@ -2059,6 +2379,7 @@ func (cp *Codeplug) ExportXLSX(filename string) error {
 	var err error
 
 	file = xlsx.NewFile()
+	lookups := &xlsxLookups{columns: make(map[string]int)}
 
 	recordTypes := cp.RecordTypes()
 	for _, rType := range recordTypes {
@@ -2086,23 +2407,23 @@ func (cp *Codeplug) ExportXLSX(filename string) error {
 				}
 			}
 		}
+
+		err = lookups.addValidations(sheet, records)
+		if err != nil {
+			return err
+		}
 	}
-	err = file.Save(filename)
+
+	err = lookups.addSheets(file)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return writeXLSXFile(w, file)
 }
 
-func (cp *Codeplug) importXLSX(filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	pRecs := cp.parseXLSXFile(file)
+func (cp *Codeplug) importXLSX(reader io.Reader) error {
+	pRecs := cp.parseXLSXFile(reader)
 	deferValues := false
 	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
 	if err != nil {
@@ -2140,6 +2461,9 @@ func (cp *Codeplug) parseXLSXFile(iRdr io.Reader) []*parsedRecord {
 	var pRecords []*parsedRecord
 
 	for _, sheet := range file.Sheets {
+		if sheet.Hidden {
+			continue
+		}
 		rTypeName := sheet.Name
 
 		headerCells := sheet.Rows[0].Cells
@@ -2190,7 +2514,10 @@ func (cp *Codeplug) storeParsedRecords(records []*Record) error {
 		}
 	}
 	cp.Valid()
-	cp.store()
+	err := cp.store()
+	if err != nil {
+		return err
+	}
 	cp.changed = true
 
 	return nil
@@ -2285,17 +2612,14 @@ func (cp *Codeplug) WriteRadio(progress func(cur int) error) error {
 		r.Field(FtCiTalkaround).SetString("Off")
 	}
 
-	cp.store()
-
-	cpi := cp.codeplugInfo
-	binBytes := cp.bytes[cpi.HeaderSize:cpi.TrailerOffset]
-
-	begin := cpi.TrailerOffset + cpi.TrailerSize
-	end := len(cp.bytes)
-	binBytes = append(binBytes, cp.bytes[begin:end]...)
+	err = cp.store()
+	binBytes := cp.binBytes()
 
 	cp.bytes = savedBytes
 	cp.setLastProgrammedTime(savedTime)
+	if err != nil {
+		return err
+	}
 
 	dfu, err := dfu.New(func(cur int) error {
 		return progress(cur)
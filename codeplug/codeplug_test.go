@@ -0,0 +1,243 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newTestCodeplug returns a new, loaded codeplug of the given type,
+// with its first frequency range.
+func newTestCodeplug(t *testing.T, typ string) *Codeplug {
+	cp, err := NewCodeplug(FileTypeNew, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, freqRanges := cp.TypesFrequencyRanges()
+	if len(freqRanges[typ]) == 0 {
+		t.Fatalf("%s: no frequency ranges", typ)
+	}
+
+	err = cp.Load(typ, freqRanges[typ][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cp
+}
+
+// forEachType calls fn, as a subtest, with a new codeplug of each type.
+func forEachType(t *testing.T, fn func(t *testing.T, cp *Codeplug)) {
+	var types []string
+	for typ := range AllFrequencyRanges() {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	for _, typ := range types {
+		typ := typ
+		t.Run(typ, func(t *testing.T) {
+			fn(t, newTestCodeplug(t, typ))
+		})
+	}
+}
+
+// checkValid fails the test if the codeplug has invalid fields.
+func checkValid(t *testing.T, cp *Codeplug) {
+	t.Helper()
+
+	if !cp.Valid() {
+		for _, warning := range cp.Warnings() {
+			t.Error(warning)
+		}
+	}
+}
+
+// recordStrings returns the text form of each of the codeplug's
+// records of the given types.
+func recordStrings(cp *Codeplug, rTypes ...RecordType) []string {
+	var strs []string
+	for _, rType := range rTypes {
+		if !cp.HasRecordType(rType) {
+			continue
+		}
+		for _, r := range cp.Records(rType) {
+			var b strings.Builder
+			PrintRecord(&b, r)
+			strs = append(strs, b.String())
+		}
+	}
+
+	return strs
+}
+
+// tempDir returns a new temporary directory and a function removing it.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "codeplug")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+// checkFiles fails the test if any of the named files is missing from dir.
+func checkFiles(t *testing.T, dir string, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestNewCodeplugsValid(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		checkValid(t, cp)
+	})
+}
+
+// contactSuffixes matches the random suffixes given to contact names,
+// which are chosen anew when a binary codeplug is read.
+var contactSuffixes = regexp.MustCompile(fmt.Sprintf("_[%s]{%d}",
+	regexp.QuoteMeta(letterBytes), contactSuffixLength))
+
+// withoutSuffixes returns strs with any contact name suffixes removed.
+func withoutSuffixes(strs []string) []string {
+	var stripped []string
+	for _, str := range strs {
+		stripped = append(stripped, contactSuffixes.ReplaceAllString(str, ""))
+	}
+
+	return stripped
+}
+
+func TestReadWrite(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		freqRange := AllFrequencyRanges()[cp.Type()][0]
+
+		// Writing a codeplug sets its last programmed time.
+		var rTypes []RecordType
+		for _, rType := range cp.RecordTypes() {
+			if rType != RtBasicInformation_md380 {
+				rTypes = append(rTypes, rType)
+			}
+		}
+		before := sortedLines(withoutSuffixes(recordStrings(cp, rTypes...)))
+
+		for _, format := range []struct {
+			fType FileType
+			write func(io.Writer) error
+		}{
+			{FileTypeRdt, cp.WriteRdt},
+			{FileTypeBin, cp.WriteBin},
+			{FileTypeText, cp.WriteText},
+			{FileTypeText, cp.WriteTextOneLineRecords},
+			{FileTypeJSON, cp.WriteJSON},
+		} {
+			var buf bytes.Buffer
+			err := format.write(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ncp, err := NewCodeplugFromReader(FileTypeNone, "", bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ncp.fileType != format.fType {
+				t.Errorf("file type %d detected as %d", format.fType, ncp.fileType)
+			}
+
+			ncp, err = ReadCodeplug(format.fType, cp.Type(), freqRange, bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkValid(t, ncp)
+
+			after := sortedLines(withoutSuffixes(recordStrings(ncp, rTypes...)))
+			if !reflect.DeepEqual(before, after) {
+				t.Errorf("file type %d: records changed", format.fType)
+			}
+		}
+	})
+}
+
+func TestDetectFileType(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		fType FileType
+	}{
+		{"xlsx", "PK\x03\x04rest", FileTypeXLSX},
+		{"json", "\n  {}", FileTypeJSON},
+		{"text", "BasicInformation:\n", FileTypeText},
+		{"binary", "\x00\x01", FileTypeNone},
+		{"invalid utf-8", "\xff\xfe", FileTypeNone},
+		{"empty", "", FileTypeNone},
+	}
+
+	for _, test := range tests {
+		cp := new(Codeplug)
+		err := cp.detectFileType([]byte(test.data))
+		if cp.fileType != test.fType || (err == nil) != (test.fType != FileTypeNone) {
+			t.Errorf("%s: got file type %d, error %v", test.name, cp.fileType, err)
+		}
+	}
+
+	_, err := NewCodeplugFromReader(FileTypeCSV, "", bytes.NewReader(nil))
+	if err == nil {
+		t.Error("CSV codeplug read from a reader")
+	}
+}
+
+func TestReadWrongFileType(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+
+	var buf bytes.Buffer
+	err := cp.WriteBin(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewCodeplugFromReader(FileTypeRdt, "x.rdt", bytes.NewReader(buf.Bytes()))
+	if err == nil || !strings.HasPrefix(err.Error(), "x.rdt ") {
+		t.Errorf("bin read as rdt: got error %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"runtime/debug"
+	"testing"
+)
+
+// A corruption returns a corrupted copy of data, whose first
+// headerSize bytes are the file's header.
+type corruption struct {
+	name    string
+	corrupt func(rnd *rand.Rand, data []byte, headerSize int) []byte
+}
+
+// flipBytes returns a corruption that changes n random bytes within
+// the part of data selected by span.
+func flipBytes(name string, n int, span func(data []byte, headerSize int) (int, int)) corruption {
+	return corruption{name, func(rnd *rand.Rand, data []byte, headerSize int) []byte {
+		data = append([]byte(nil), data...)
+		start, end := span(data, headerSize)
+		for i := 0; i < n; i++ {
+			data[start+rnd.Intn(end-start)] ^= byte(1 + rnd.Intn(255))
+		}
+		return data
+	}}
+}
+
+var corruptions = []corruption{
+	flipBytes("header", 8, func(data []byte, headerSize int) (int, int) {
+		return 0, headerSize
+	}),
+	flipBytes("records", 64, func(data []byte, headerSize int) (int, int) {
+		return headerSize, len(data)
+	}),
+	{"truncated", func(rnd *rand.Rand, data []byte, headerSize int) []byte {
+		return append([]byte(nil), data[:headerSize+rnd.Intn(len(data)-headerSize)]...)
+	}},
+}
+
+// noPanic calls fn, failing the test if it panics.
+func noPanic(t *testing.T, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if err := recover(); err != nil {
+			t.Errorf("panic: %v\n%s", err, debug.Stack())
+		}
+	}()
+
+	fn()
+}
+
+// readCorrupted reads data as a codeplug and, if that succeeds, writes
+// it again.  Errors are expected; panics are not.
+func readCorrupted(t *testing.T, typ string, freqRange string, data []byte) {
+	t.Helper()
+
+	noPanic(t, func() {
+		cp, err := ReadCodeplug(FileTypeNone, typ, freqRange, bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		cp.Valid()
+		var buf bytes.Buffer
+		cp.WriteRdt(&buf)
+		cp.WriteText(&buf)
+	})
+}
+
+func TestCorruptRdt(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		var buf bytes.Buffer
+		err := cp.WriteRdt(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+
+		freqRange := AllFrequencyRanges()[cp.Type()][0]
+		headerSize := cp.codeplugInfo.HeaderSize
+
+		for _, c := range corruptions {
+			t.Run(c.name, func(t *testing.T) {
+				readCorrupted(t, cp.Type(), freqRange, c.corrupt(rnd, data, headerSize))
+			})
+		}
+	})
+}
+
+func TestCorruptImports(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	cp := newTestCodeplug(t, "MD-UV380")
+	freqRange := AllFrequencyRanges()[cp.Type()][0]
+
+	for _, format := range []struct {
+		name  string
+		write func(*Codeplug, io.Writer) error
+	}{
+		{"text", (*Codeplug).WriteText},
+		{"json", (*Codeplug).WriteJSON},
+	} {
+		var buf bytes.Buffer
+		err := format.write(cp, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data := buf.Bytes()
+
+		for _, c := range corruptions {
+			t.Run(format.name+"/"+c.name, func(t *testing.T) {
+				readCorrupted(t, cp.Type(), freqRange, c.corrupt(rnd, data, 64))
+			})
+		}
+	}
+}
+
+func TestCorruptTemplates(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	saved := new_tar_bz2
+	defer func() { new_tar_bz2 = saved }()
+
+	freqRange := AllFrequencyRanges()["MD-380"][0]
+
+	// The header holds the bzip2 signature and block size.
+	for _, c := range corruptions {
+		t.Run(c.name, func(t *testing.T) {
+			new_tar_bz2 = c.corrupt(rnd, saved, 4)
+			noPanic(t, func() {
+				cp, err := NewCodeplug(FileTypeNew, "")
+				if err != nil {
+					return
+				}
+				cp.Load("MD-380", freqRange)
+			})
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// A codeplug is exported to CSV as a directory holding one file per
+// record type, named by the record type, such as Channels.csv.  The
+// first line of each file names the columns and each following line
+// holds one record.  A column holds the values of one field type and
+// is named by it.  A field type that may have several values, such as
+// a zone's channels, has a column per value, named by the field type
+// and the value's position: Channel[1], Channel[2], and so on.  Empty
+// cells of those columns hold no value.  References to other records
+// are by their names.
+
+const csvExtension = ".csv"
+
+// csvColumnName returns the column name for the value at index of a
+// field type with up to max values.
+func csvColumnName(fType FieldType, index int, max int) string {
+	if max <= 1 {
+		return string(fType)
+	}
+
+	return fmt.Sprintf("%s[%d]", fType, index+1)
+}
+
+// parseCSVColumnName returns the field type name and value index of
+// a column name, and whether the field type may have several values.
+func parseCSVColumnName(name string) (fTypeName string, index int, multi bool) {
+	name = strings.TrimSpace(name)
+	i := strings.IndexByte(name, '[')
+	if i < 0 || !strings.HasSuffix(name, "]") {
+		return name, 0, false
+	}
+
+	n, err := strconv.Atoi(name[i+1 : len(name)-1])
+	if err != nil || n < 1 {
+		return name, 0, false
+	}
+
+	return name[:i], n - 1, true
+}
+
+// ExportCSV writes the codeplug into dir, creating it if necessary,
+// as a CSV file per record type.
+func (cp *Codeplug) ExportCSV(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, rType := range cp.RecordTypes() {
+		err := cp.exportCSVFile(filepath.Join(dir, string(rType)+csvExtension), rType)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cp *Codeplug) exportCSVFile(filename string, rType RecordType) (err error) {
+	records := cp.records(rType)
+	if len(records) == 0 {
+		return nil
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	// A field type with several values has as many columns as the
+	// record with the most values needs.
+	fTypes := records[0].FieldTypes()
+	columns := make(map[FieldType]int)
+	for _, fType := range fTypes {
+		columns[fType] = 1
+		for _, r := range records {
+			if n := len(r.Fields(fType)); n > columns[fType] {
+				columns[fType] = n
+			}
+		}
+	}
+
+	var header []string
+	for _, fType := range fTypes {
+		max := (*records[0].fDesc)[fType].max
+		for i := 0; i < columns[fType]; i++ {
+			header = append(header, csvColumnName(fType, i, max))
+		}
+	}
+
+	w := csv.NewWriter(file)
+	err = w.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		var row []string
+		for _, fType := range fTypes {
+			fields := r.Fields(fType)
+			for i := 0; i < columns[fType]; i++ {
+				value := ""
+				if i < len(fields) {
+					value = fields[i].String()
+				}
+				row = append(row, value)
+			}
+		}
+		err = w.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+func (cp *Codeplug) importCSV(dir string) error {
+	pRecs := cp.parseCSVDir(dir)
+	deferValues := false
+	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
+	if err != nil {
+		return err
+	}
+
+	err = cp.storeParsedRecords(records)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseCSVDir parses each of the CSV files in dir.
+func (cp *Codeplug) parseCSVDir(dir string) []*parsedRecord {
+	errorRecords := func(err error) []*parsedRecord {
+		return []*parsedRecord{&parsedRecord{err: err}}
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errorRecords(err)
+	}
+
+	var pRecords []*parsedRecord
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.EqualFold(filepath.Ext(name), csvExtension) {
+			continue
+		}
+
+		pRecs, err := parseCSVFile(filepath.Join(dir, name))
+		if err != nil {
+			return errorRecords(fmt.Errorf("%s: %s", name, err.Error()))
+		}
+		pRecords = append(pRecords, pRecs...)
+	}
+
+	if len(pRecords) == 0 {
+		return errorRecords(fmt.Errorf("%s: no CSV files found", dir))
+	}
+
+	return pRecords
+}
+
+// parseCSVFile parses a CSV file holding records of the type named by
+// the file's base name.
+func parseCSVFile(filename string) ([]*parsedRecord, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	base := filepath.Base(filename)
+	rTypeName := base[:len(base)-len(filepath.Ext(base))]
+
+	type column struct {
+		name  string
+		index int
+		multi bool
+	}
+	header := make([]column, len(lines[0]))
+	for i, name := range lines[0] {
+		fTypeName, index, multi := parseCSVColumnName(name)
+		header[i] = column{fTypeName, index, multi}
+	}
+
+	var pRecords []*parsedRecord
+	for index, line := range lines[1:] {
+		pos := &position{line: index + 1}
+		pRecord := &parsedRecord{
+			name:  rTypeName,
+			index: index,
+			pos:   pos,
+		}
+		for i, value := range line {
+			if i >= len(header) {
+				break
+			}
+			col := header[i]
+			if col.multi && value == "" {
+				continue
+			}
+			pRecord.pFields = append(pRecord.pFields, &parsedField{
+				name:  col.name,
+				index: col.index,
+				value: value,
+				pos:   &position{line: index + 1, column: i},
+			})
+		}
+		pRecords = append(pRecords, pRecord)
+	}
+
+	return pRecords, nil
+}
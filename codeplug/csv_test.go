@@ -0,0 +1,161 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestCSVColumnName(t *testing.T) {
+	tests := []struct {
+		fType FieldType
+		index int
+		max   int
+		name  string
+	}{
+		{FtCiName, 0, 1, "Name"},
+		{FtZiChannel_md380, 0, 16, "Channel[1]"},
+		{FtZiChannel_md380, 15, 16, "Channel[16]"},
+	}
+
+	for _, test := range tests {
+		name := csvColumnName(test.fType, test.index, test.max)
+		if name != test.name {
+			t.Errorf("csvColumnName(%s, %d, %d): got %s, want %s", test.fType, test.index, test.max, name, test.name)
+		}
+
+		fTypeName, index, multi := parseCSVColumnName(name)
+		if fTypeName != string(test.fType) || index != test.index || multi != (test.max > 1) {
+			t.Errorf("parseCSVColumnName(%s): got %s, %d, %v", name, fTypeName, index, multi)
+		}
+	}
+
+	for _, name := range []string{"Channel[0]", "Channel[x]", "Channel[1"} {
+		if _, _, multi := parseCSVColumnName(name); multi {
+			t.Errorf("parseCSVColumnName(%s): multi-valued", name)
+		}
+	}
+}
+
+// readCSVCodeplug returns a codeplug of the given type read from the
+// CSV files in dir.
+func readCSVCodeplug(t *testing.T, typ string, dir string) (*Codeplug, error) {
+	cp, err := NewCodeplug(FileTypeCSV, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cp, cp.Load(typ, AllFrequencyRanges()[typ][0])
+}
+
+// sortedLines returns each of strs with its lines sorted.  The fields
+// of a record listing a field type twice, as the rt84's general
+// settings do, may be reordered by an import.
+func sortedLines(strs []string) []string {
+	sorted := make([]string, len(strs))
+	for i, str := range strs {
+		lines := strings.Split(str, "\n")
+		sort.Strings(lines)
+		sorted[i] = strings.Join(lines, "\n")
+	}
+
+	return sorted
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		dir, removeDir := tempDir(t)
+		defer removeDir()
+
+		csvDir := filepath.Join(dir, "new", "csv")
+		err := cp.ExportCSV(csvDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ncp, err := readCSVCodeplug(t, cp.Type(), csvDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkValid(t, ncp)
+
+		rTypes := cp.RecordTypes()
+		before := sortedLines(recordStrings(cp, rTypes...))
+		after := sortedLines(recordStrings(ncp, rTypes...))
+		if !reflect.DeepEqual(before, after) {
+			t.Errorf("records changed:\n%s\n%s", strings.Join(before, ""), strings.Join(after, ""))
+		}
+	})
+}
+
+func TestCSVImportErrors(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+
+	tests := []struct {
+		name   string
+		export bool
+		files  map[string]string
+		err    string
+	}{
+		{"empty", false, nil, "no CSV files found"},
+		{"bad csv", true, map[string]string{
+			"Contacts.csv": "Name\n\"x\n",
+		}, "Contacts.csv"},
+		{"missing records", false, map[string]string{
+			"Contacts.csv": "Name,CallID,CallType\nx,1,Group\n",
+		}, " found"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir, removeDir := tempDir(t)
+			defer removeDir()
+
+			if test.export {
+				err := cp.ExportCSV(dir)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for name, text := range test.files {
+				err := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := readCSVCodeplug(t, cp.Type(), dir)
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("got error %v, want %q", err, test.err)
+			}
+		})
+	}
+}
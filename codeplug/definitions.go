@@ -0,0 +1,652 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dalefarnsworth/codeplug/codeplug/layout"
+)
+
+// Codeplug definitions may be loaded at runtime from a JSON file using
+// the same layout as codeplugs.json, from which the compiled-in
+// definitions are generated.  In addition, each codeplug may provide
+// blank template images, keyed by frequency range, that are used when
+// creating a new codeplug of that type:
+//
+//	"codeplugs": [{
+//		"type": "MD-9600", ...
+//		"templates": { "136-174_400-480": "<base64 rdt image>" }
+//	}]
+
+// codeplugInfosMutex guards codeplugInfos, rTypeFieldRefs and
+// definedTemplates, which LoadDefinitions may extend.
+var codeplugInfosMutex sync.RWMutex
+
+// definedTemplates holds the template images of loaded definitions,
+// indexed by their new file names.
+var definedTemplates = make(map[string][]byte)
+
+type defTop struct {
+	Codeplugs []*defCodeplug `json:"codeplugs"`
+	Records   []*defRecord   `json:"records"`
+	Fields    []*defField    `json:"fields"`
+}
+
+type defCodeplug struct {
+	Models        []string          `json:"models"`
+	Type          string            `json:"type"`
+	Ext           string            `json:"ext"`
+	RdtSize       int               `json:"rdtSize"`
+	HeaderSize    int               `json:"headerSize"`
+	TrailerOffset int               `json:"trailerOffset"`
+	TrailerSize   int               `json:"trailerSize"`
+	RecordTypes   []string          `json:"recordTypes"`
+	Templates     map[string][]byte `json:"templates"`
+}
+
+type defRecord struct {
+	TypeName string `json:"typeName"`
+	Type     string `json:"type"`
+	Offset   int    `json:"offset"`
+	Size     int    `json:"size"`
+	Max      int    `json:"max"`
+	DelDesc  *struct {
+		Offset int `json:"offset"`
+		Size   int `json:"size"`
+		Value  int `json:"value"`
+	} `json:"delDesc"`
+	FieldTypes []string `json:"fieldTypes"`
+	NamePrefix string   `json:"namePrefix"`
+	Names      []string `json:"names"`
+}
+
+type defField struct {
+	TypeName     string    `json:"typeName"`
+	Type         string    `json:"type"`
+	BitOffset    int       `json:"bitOffset"`
+	BitSize      int       `json:"bitSize"`
+	Max          int       `json:"max"`
+	ValueType    string    `json:"valueType"`
+	DefaultValue string    `json:"defaultValue"`
+	Strings      *[]string `json:"strings"`
+	Span         *struct {
+		Min       int    `json:"min"`
+		Max       int    `json:"max"`
+		Scale     int    `json:"scale"`
+		Interval  int    `json:"interval"`
+		MinString string `json:"minString"`
+	} `json:"span"`
+	IndexedStrings *[]struct {
+		Index  uint16 `json:"index"`
+		String string `json:"string"`
+	} `json:"indexedStrings"`
+	ExtOffset    int          `json:"extOffset"`
+	ExtSize      int          `json:"extSize"`
+	ExtIndex     int          `json:"extIndex"`
+	ExtBitOffset int          `json:"extBitOffset"`
+	ListType     *string      `json:"listType"`
+	EnablesIn    []*defEnable `json:"enables"`
+	EnableIn     *defEnable   `json:"enable"`
+}
+
+type defEnable struct {
+	Value    string   `json:"value"`
+	Enables  []string `json:"enables"`
+	Disables []string `json:"disables"`
+}
+
+// definitionErrors accumulates the problems found in a definitions file.
+type definitionErrors []string
+
+func (errs *definitionErrors) add(format string, args ...interface{}) {
+	*errs = append(*errs, fmt.Sprintf(format, args...))
+}
+
+// typeString removes the variant suffix from a codeplugs.json type name.
+func typeString(s string) string {
+	index := strings.LastIndex(s, "_")
+	if index > 0 {
+		s = s[:index]
+	}
+	return s
+}
+
+// LoadDefinitionsFile loads the codeplug definitions in the named JSON
+// file.  See LoadDefinitions.
+func LoadDefinitionsFile(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	err = LoadDefinitions(file)
+	if err != nil {
+		return fmt.Errorf("%s: %s", filename, err.Error())
+	}
+
+	return nil
+}
+
+// LoadDefinitions reads codeplug definitions in the codeplugs.json
+// format from reader and adds them to the compiled-in definitions.
+// If the definitions are malformed, an error describing each problem
+// is returned and no definitions are added.
+func LoadDefinitions(reader io.Reader) error {
+	var top defTop
+
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&top)
+	if err != nil {
+		return fmt.Errorf("bad definitions: %s", err.Error())
+	}
+
+	codeplugInfosMutex.Lock()
+	defer codeplugInfosMutex.Unlock()
+
+	var errs definitionErrors
+
+	fieldInfos := makeFieldInfos(top.Fields, &errs)
+	recordInfos := makeRecordInfos(top.Records, fieldInfos, &errs)
+	setEnables(top.Records, top.Fields, fieldInfos, &errs)
+	cpis, templates := makeCodeplugInfos(top.Codeplugs, recordInfos, &errs)
+	errs = append(errs, top.layout().Check()...)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("bad definitions:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+
+	for _, cpi := range cpis {
+		normalizeCodeplugInfo(cpi)
+		codeplugInfos = append(codeplugInfos, cpi)
+		addFieldRefs(cpi)
+	}
+
+	for filename, bytes := range templates {
+		definedTemplates[filename] = bytes
+	}
+
+	return nil
+}
+
+// layout returns the layout of the definitions, for checking by the
+// same rules as the compiled-in definitions.
+func (top *defTop) layout() *layout.Layout {
+	l := &layout.Layout{}
+
+	for _, c := range top.Codeplugs {
+		l.Codeplugs = append(l.Codeplugs, &layout.Codeplug{
+			Type:        c.Type,
+			RdtSize:     c.RdtSize,
+			RecordTypes: c.RecordTypes,
+		})
+	}
+
+	for _, r := range top.Records {
+		lr := &layout.Record{
+			Type:       r.Type,
+			Offset:     r.Offset,
+			Size:       r.Size,
+			Max:        r.Max,
+			FieldTypes: r.FieldTypes,
+		}
+		if r.DelDesc != nil {
+			lr.DelDesc = &layout.DelDesc{
+				Offset: r.DelDesc.Offset,
+				Size:   r.DelDesc.Size,
+			}
+		}
+		l.Records = append(l.Records, lr)
+	}
+
+	for _, f := range top.Fields {
+		lf := &layout.Field{
+			Type:         f.Type,
+			ValueType:    f.ValueType,
+			BitOffset:    f.BitOffset,
+			BitSize:      f.BitSize,
+			Max:          f.Max,
+			ExtOffset:    f.ExtOffset,
+			ExtSize:      f.ExtSize,
+			ExtIndex:     f.ExtIndex,
+			ExtBitOffset: f.ExtBitOffset,
+		}
+		if f.ListType != nil {
+			lf.ListType = *f.ListType
+		}
+		enables := f.EnablesIn
+		if f.EnableIn != nil {
+			enables = append(enables, f.EnableIn)
+		}
+		for _, enable := range enables {
+			lf.Enables = append(lf.Enables, layout.Enable{
+				Value:   enable.Value,
+				Enables: enable.Enables,
+			})
+		}
+		l.Fields = append(l.Fields, lf)
+	}
+
+	return l
+}
+
+// makeFieldInfos returns the fieldInfos of the given field definitions,
+// indexed by their JSON type names.
+func makeFieldInfos(fields []*defField, errs *definitionErrors) map[string]*fieldInfo {
+	fieldInfos := make(map[string]*fieldInfo)
+
+	for _, f := range fields {
+		name := f.Type
+		if len(name) < 3 {
+			errs.add("field %q: type must have a two letter prefix", name)
+			continue
+		}
+		if fieldInfos[name] != nil {
+			errs.add("field %s: duplicate field type", name)
+			continue
+		}
+
+		fi := &fieldInfo{
+			fType:        FieldType(typeString(name)[2:]),
+			typeName:     f.TypeName,
+			max:          f.Max,
+			bitOffset:    f.BitOffset,
+			bitSize:      f.BitSize,
+			valueType:    ValueType(f.ValueType),
+			defaultValue: f.DefaultValue,
+			strings:      f.Strings,
+			extOffset:    f.ExtOffset,
+			extSize:      f.ExtSize,
+			extIndex:     f.ExtIndex,
+			extBitOffset: f.ExtBitOffset,
+		}
+		fieldInfos[name] = fi
+
+		if fi.max == 0 {
+			fi.max = 1
+		}
+
+		if f.TypeName == "" {
+			errs.add("field %s: missing typeName", name)
+		}
+
+		if newValue(fi.valueType) == nil {
+			errs.add("field %s: unknown value type %q", name, f.ValueType)
+		}
+
+		if fi.bitSize <= 0 || fi.bitOffset < 0 || fi.max < 0 {
+			errs.add("field %s: bad bitOffset, bitSize or max", name)
+		}
+
+		if f.Span != nil {
+			sp := f.Span
+			fi.span = &Span{
+				min:       sp.Min,
+				max:       sp.Max,
+				scale:     sp.Scale,
+				interval:  sp.Interval,
+				minString: sp.MinString,
+			}
+			if sp.MinString != "" {
+				fi.span.min = 0
+			}
+			if fi.span.min > fi.span.max || fi.span.scale < 0 || fi.span.interval < 0 {
+				errs.add("field %s: bad span", name)
+			}
+		}
+
+		if f.IndexedStrings != nil {
+			iStrs := make([]IndexedString, len(*f.IndexedStrings))
+			for i, is := range *f.IndexedStrings {
+				iStrs[i] = IndexedString{is.Index, is.String}
+			}
+			fi.indexedStrings = &iStrs
+		}
+
+		if f.ListType != nil {
+			fi.listRecordType = RecordType(typeString(*f.ListType))
+		}
+
+		switch fi.valueType {
+		case VtIStrings, VtBandwidth, VtPrivacyNumber:
+			if fi.strings == nil || len(*fi.strings) == 0 {
+				errs.add("field %s: %s requires strings", name, fi.valueType)
+			}
+
+		case VtIndexedStrings, VtRadioButton, VtCallType:
+			if fi.indexedStrings == nil || len(*fi.indexedStrings) == 0 {
+				errs.add("field %s: %s requires indexedStrings", name, fi.valueType)
+			}
+
+		case VtSpan, VtSpanList, VtGpsReportInterval:
+			if fi.span == nil {
+				errs.add("field %s: %s requires a span", name, fi.valueType)
+			}
+
+		case VtListIndex, VtMemberListIndex, VtContactListIndex,
+			VtNkContactListIndex, VtGpsListIndex, VtDerefListIndex:
+			if fi.listRecordType == "" {
+				errs.add("field %s: %s requires a listType", name, fi.valueType)
+			}
+		}
+	}
+
+	return fieldInfos
+}
+
+// makeRecordInfos returns the recordInfos of the given record
+// definitions, indexed by their JSON type names.
+func makeRecordInfos(records []*defRecord, fieldInfos map[string]*fieldInfo, errs *definitionErrors) map[string]*recordInfo {
+	recordInfos := make(map[string]*recordInfo)
+
+	for _, r := range records {
+		name := r.Type
+		if name == "" {
+			errs.add("record with no type")
+			continue
+		}
+		if recordInfos[name] != nil {
+			errs.add("record %s: duplicate record type", name)
+			continue
+		}
+
+		ri := &recordInfo{
+			rType:      RecordType(typeString(name)),
+			typeName:   r.TypeName,
+			max:        r.Max,
+			offset:     r.Offset,
+			size:       r.Size,
+			namePrefix: r.NamePrefix,
+			names:      r.Names,
+		}
+		recordInfos[name] = ri
+
+		if ri.max == 0 {
+			ri.max = 1
+		}
+
+		if r.TypeName == "" {
+			errs.add("record %s: missing typeName", name)
+		}
+
+		if ri.size <= 0 || ri.offset < 0 || ri.max < 0 {
+			errs.add("record %s: bad offset, size or max", name)
+		}
+
+		if dd := r.DelDesc; dd != nil {
+			if dd.Offset < 0 || dd.Size <= 0 || dd.Offset+dd.Size > 255 || dd.Value < 0 || dd.Value > 255 {
+				errs.add("record %s: bad delDesc", name)
+			} else {
+				ri.delDesc = &delDesc{
+					offset: uint8(dd.Offset),
+					size:   uint8(dd.Size),
+					value:  byte(dd.Value),
+				}
+			}
+		}
+
+		if len(r.FieldTypes) == 0 {
+			errs.add("record %s: no fieldTypes", name)
+		}
+
+		for _, fType := range r.FieldTypes {
+			fi := fieldInfos[fType]
+			if fi == nil {
+				errs.add("record %s: unknown field type %s", name, fType)
+				continue
+			}
+			ri.fieldInfos = append(ri.fieldInfos, fi)
+		}
+	}
+
+	return recordInfos
+}
+
+// setEnables fills in the fields' enables, enablerType and enablers
+// from the fields' "enable" and "enables" descriptions.
+func setEnables(records []*defRecord, fields []*defField, fieldInfos map[string]*fieldInfo, errs *definitionErrors) {
+	seen := make(map[string]bool)
+
+	for _, f := range fields {
+		fi := fieldInfos[f.Type]
+		if fi == nil {
+			continue
+		}
+
+		enables := f.EnablesIn
+		if f.EnableIn != nil {
+			if len(enables) != 0 {
+				errs.add("field %s: both enable and enables found", f.Type)
+				continue
+			}
+			enables = []*defEnable{f.EnableIn}
+		}
+
+		enablesMap := make(map[FieldType]bool)
+		for _, enable := range enables {
+			fTypes := append(append([]string{}, enable.Enables...), enable.Disables...)
+			for i, fTypeEn := range fTypes {
+				efi := fieldInfos[fTypeEn]
+				if efi == nil {
+					errs.add("field %s: unknown enabled field type %s", f.Type, fTypeEn)
+					continue
+				}
+
+				enablesMap[efi.fType] = true
+				efi.enablerType = fi.fType
+
+				seenKey := fTypeEn + ":" + enable.Value
+				if seen[seenKey] {
+					continue
+				}
+				seen[seenKey] = true
+
+				efi.enablers = append(efi.enablers, enabler{
+					value:  enable.Value,
+					enable: i < len(enable.Enables),
+				})
+			}
+		}
+
+		for fType := range enablesMap {
+			fi.enables = append(fi.enables, fType)
+		}
+		sort.Slice(fi.enables, func(i, j int) bool {
+			return fi.enables[i] < fi.enables[j]
+		})
+	}
+
+	for _, fi := range fieldInfos {
+		sort.SliceStable(fi.enablers, func(i, j int) bool {
+			return fi.enablers[i].value < fi.enablers[j].value
+		})
+	}
+}
+
+// makeCodeplugInfos returns the CodeplugInfos of the given codeplug
+// definitions and their templates, indexed by new file name.
+func makeCodeplugInfos(codeplugs []*defCodeplug, recordInfos map[string]*recordInfo, errs *definitionErrors) ([]*CodeplugInfo, map[string][]byte) {
+	var cpis []*CodeplugInfo
+	templates := make(map[string][]byte)
+
+	if len(codeplugs) == 0 {
+		errs.add("no codeplugs defined")
+	}
+
+	for _, c := range codeplugs {
+		name := c.Type
+		if name == "" {
+			errs.add("codeplug with no type")
+			continue
+		}
+
+		for _, cpi := range codeplugInfos {
+			if cpi.Type == name {
+				errs.add("codeplug %s: already defined", name)
+			}
+		}
+		for _, cpi := range cpis {
+			if cpi.Type == name {
+				errs.add("codeplug %s: duplicate codeplug type", name)
+			}
+		}
+
+		cpi := &CodeplugInfo{
+			Type:          name,
+			Models:        c.Models,
+			Ext:           c.Ext,
+			RdtSize:       c.RdtSize,
+			HeaderSize:    c.HeaderSize,
+			TrailerOffset: c.TrailerOffset,
+			TrailerSize:   c.TrailerSize,
+		}
+		cpis = append(cpis, cpi)
+
+		if len(c.Models) == 0 {
+			errs.add("codeplug %s: no models", name)
+		}
+
+		if c.Ext == "" {
+			errs.add("codeplug %s: missing ext", name)
+		}
+
+		if c.RdtSize <= c.HeaderSize+c.TrailerSize || c.HeaderSize < 0 || c.TrailerSize < 0 ||
+			c.TrailerOffset < 0 || c.TrailerOffset+c.TrailerSize > c.RdtSize {
+			errs.add("codeplug %s: bad rdtSize, headerSize or trailer", name)
+		}
+
+		for _, rType := range c.RecordTypes {
+			ri := recordInfos[rType]
+			if ri == nil {
+				errs.add("codeplug %s: unknown record type %s", name, rType)
+				continue
+			}
+			cpi.RecordInfos = append(cpi.RecordInfos, ri)
+		}
+
+		if len(cpi.RecordInfos) == 0 || cpi.RecordInfos[0].rType != RtBasicInformation_md380 {
+			errs.add("codeplug %s: the first record type must be a %s record", name, RtBasicInformation_md380)
+		} else {
+			// The first record is read from files of every codeplug
+			// type while determining a file's type.
+			ri := cpi.RecordInfos[0]
+			minRdtSize := c.RdtSize
+			for _, cpi := range codeplugInfos {
+				if cpi.RdtSize < minRdtSize {
+					minRdtSize = cpi.RdtSize
+				}
+			}
+			if ri.offset+ri.max*ri.size > minRdtSize {
+				errs.add("codeplug %s: record %s must lie within the first %d bytes", name, c.RecordTypes[0], minRdtSize)
+			}
+		}
+
+		if len(c.Templates) == 0 {
+			errs.add("codeplug %s: no templates", name)
+		}
+
+		for freqRange, bytes := range c.Templates {
+			if len(bytes) != c.RdtSize {
+				errs.add("codeplug %s: template %s size is %d, not %d", name, freqRange, len(bytes), c.RdtSize)
+			}
+			freqRange = strings.Replace(freqRange, " ", "_", -1)
+			templates[name+"_"+freqRange+"."+c.Ext] = bytes
+		}
+	}
+
+	return cpis, templates
+}
+
+// addFieldRefs adds the references of the codeplug's list fields to
+// rTypeFieldRefs.
+func addFieldRefs(cpi *CodeplugInfo) {
+	for _, ri := range cpi.RecordInfos {
+		for _, fi := range ri.fieldInfos {
+			if fi.listRecordType == "" {
+				continue
+			}
+
+			ref := fieldRef{rType: ri.rType, fType: fi.fType}
+			found := false
+			for _, fr := range rTypeFieldRefs[fi.listRecordType] {
+				if fr == ref {
+					found = true
+					break
+				}
+			}
+			if !found {
+				rTypeFieldRefs[fi.listRecordType] = append(rTypeFieldRefs[fi.listRecordType], ref)
+			}
+		}
+	}
+}
+
+// allCodeplugInfos returns the compiled-in and loaded codeplug
+// definitions.
+func allCodeplugInfos() []*CodeplugInfo {
+	codeplugInfosMutex.RLock()
+	defer codeplugInfosMutex.RUnlock()
+
+	return append([]*CodeplugInfo(nil), codeplugInfos...)
+}
+
+// maxRdtSize returns the size of the largest codeplug definition.
+func maxRdtSize() int {
+	size := 0
+	for _, cpi := range allCodeplugInfos() {
+		if cpi.RdtSize > size {
+			size = cpi.RdtSize
+		}
+	}
+
+	return size
+}
+
+// fieldRefsTo returns the record and field types of the fields that
+// may reference records of the given type.
+func fieldRefsTo(rType RecordType) []fieldRef {
+	codeplugInfosMutex.RLock()
+	defer codeplugInfosMutex.RUnlock()
+
+	return append([]fieldRef(nil), rTypeFieldRefs[rType]...)
+}
+
+// definedTemplate returns the template of a loaded definition with
+// the given new file name, if any.
+func definedTemplate(filename string) []byte {
+	codeplugInfosMutex.RLock()
+	defer codeplugInfosMutex.RUnlock()
+
+	return definedTemplates[filename]
+}
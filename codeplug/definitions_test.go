@@ -0,0 +1,138 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// testDefinitions returns definitions of a codeplug type with one
+// record holding the given fields.
+func testDefinitions(t *testing.T, typ string, fields []map[string]interface{}) string {
+	var fTypes []string
+	for _, f := range fields {
+		fTypes = append(fTypes, f["type"].(string))
+	}
+
+	rdtSize := 64
+	top := map[string]interface{}{
+		"codeplugs": []interface{}{map[string]interface{}{
+			"models":      []string{typ},
+			"type":        typ,
+			"ext":         "rdt",
+			"rdtSize":     rdtSize,
+			"headerSize":  8,
+			"recordTypes": []string{"BasicInformation_test"},
+			"templates":   map[string][]byte{"136-174": make([]byte, rdtSize)},
+		}},
+		"records": []interface{}{map[string]interface{}{
+			"typeName":   "Basic Information",
+			"type":       "BasicInformation_test",
+			"offset":     8,
+			"size":       16,
+			"fieldTypes": fTypes,
+		}},
+		"fields": fields,
+	}
+
+	data, err := json.Marshal(top)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+// testField returns the definition of a one byte field.
+func testField(typ string, bitOffset int) map[string]interface{} {
+	return map[string]interface{}{
+		"typeName":  typ,
+		"type":      typ,
+		"bitOffset": bitOffset,
+		"bitSize":   8,
+		"valueType": "ascii",
+	}
+}
+
+// definedType returns true if a codeplug of the given type is defined.
+func definedType(typ string) bool {
+	for _, cpi := range allCodeplugInfos() {
+		if cpi.Type == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestLoadDefinitions(t *testing.T) {
+	defs := testDefinitions(t, "TEST-1", []map[string]interface{}{
+		testField("BiModel", 0),
+		testField("BiTest", 8),
+	})
+
+	err := LoadDefinitions(strings.NewReader(defs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !definedType("TEST-1") {
+		t.Error("TEST-1 not defined")
+	}
+}
+
+func TestLoadDefinitionsLayout(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		fields  []map[string]interface{}
+		problem string
+	}{
+		{"overlap", []map[string]interface{}{
+			testField("BiModel", 0),
+			testField("BiTest", 0),
+		}, "field BiModel overlaps field BiTest"},
+		{"past end", []map[string]interface{}{
+			testField("BiModel", 128),
+		}, "extend past the record's 16 bytes"},
+		{"unaligned", []map[string]interface{}{
+			testField("BiModel", 4),
+		}, "not byte aligned"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			typ := "TEST-" + test.name
+			err := LoadDefinitions(strings.NewReader(testDefinitions(t, typ, test.fields)))
+			if err == nil || !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("got %v, want %q", err, test.problem)
+			}
+
+			if definedType(typ) {
+				t.Errorf("%s defined", typ)
+			}
+		})
+	}
+}
@@ -0,0 +1,832 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+// dmrconfig is the text format used by the dmrconfig utility.  It
+// consists of tables of digital and analog channels, zones, scan lists,
+// contacts and group lists, plus a few "key: value" settings.  Records
+// are referred to by number in dmrconfig, and by name in a codeplug.
+
+// dmrconfigFieldTypes lists the fields of each record type that are
+// represented in dmrconfig files.
+var dmrconfigFieldTypes = map[RecordType][]FieldType{
+	RtChannels_md380: []FieldType{
+		FtCiName,
+		FtCiRxFrequency,
+		FtCiTxFrequencyOffset,
+		FtCiChannelMode,
+		FtCiPower,
+		FtCiScanList_md380,
+		FtCiTot,
+		FtCiRxOnly,
+		FtCiAdmitCriteria,
+		FtCiColorCode,
+		FtCiRepeaterSlot,
+		FtCiGroupList,
+		FtCiContactName,
+		FtCiSquelch,
+		FtCiCtcssDecode,
+		FtCiCtcssEncode,
+		FtCiBandwidth,
+	},
+	RtZones_md380: []FieldType{
+		FtZiName,
+		FtZiChannel_md380,
+		FtZiChannelA_uv380,
+		FtZiChannelB_uv380,
+	},
+	RtScanLists_md380: []FieldType{
+		FtSlName,
+		FtSlPriorityChannel1_md380,
+		FtSlPriorityChannel2_md380,
+		FtSlTxDesignatedChannel_md380,
+		FtSlChannel_md380,
+	},
+	RtContacts: []FieldType{
+		FtDcName,
+		FtDcCallType,
+		FtDcCallID,
+		FtDcCallReceiveTone,
+	},
+	RtGroupLists: []FieldType{
+		FtGlName,
+		FtGlContact,
+	},
+}
+
+// dmrconfigRecordTypes lists the record types in dmrconfig files, in
+// the order in which they are written.
+var dmrconfigRecordTypes = []RecordType{
+	RtChannels_md380,
+	RtZones_md380,
+	RtScanLists_md380,
+	RtContacts,
+	RtGroupLists,
+}
+
+// dmrconfigSettings maps dmrconfig's settings to general settings fields.
+var dmrconfigSettings = []struct {
+	key   string
+	fType FieldType
+}{
+	{"ID", FtGsRadioID},
+	{"Name", FtGsRadioName},
+	{"Intro Line 1", FtGsIntroScreenLine1},
+	{"Intro Line 2", FtGsIntroScreenLine2},
+}
+
+// dmrconfigValues maps dmrconfig's values to codeplug field values,
+// for those fields where they differ.
+var dmrconfigValues = map[FieldType]map[string]string{
+	FtCiAdmitCriteria: map[string]string{
+		"-":     "Always",
+		"Free":  "Channel free",
+		"Tone":  "CTCSS/DCS",
+		"Color": "Color code",
+	},
+	FtCiPower: map[string]string{
+		"Mid": "Medium",
+	},
+	FtCiTot: map[string]string{
+		"-": "Infinite",
+	},
+	FtCiRxOnly: map[string]string{
+		"-": "Off",
+		"+": "On",
+	},
+	FtCiCtcssDecode: map[string]string{
+		"-": "None",
+	},
+	FtCiCtcssEncode: map[string]string{
+		"-": "None",
+	},
+	FtDcCallReceiveTone: map[string]string{
+		"-": "No",
+		"+": "Yes",
+	},
+	FtSlPriorityChannel1_md380: map[string]string{
+		"-":   "None",
+		"Sel": "Selected",
+	},
+	FtSlPriorityChannel2_md380: map[string]string{
+		"-":   "None",
+		"Sel": "Selected",
+	},
+	FtSlTxDesignatedChannel_md380: map[string]string{
+		"Last": "Last Active Channel",
+		"Sel":  "Selected",
+	},
+}
+
+// fromDmrconfig returns the codeplug value for a dmrconfig value.
+func fromDmrconfig(fType FieldType, value string) string {
+	if v, ok := dmrconfigValues[fType][value]; ok {
+		return v
+	}
+
+	return value
+}
+
+// toDmrconfig returns the dmrconfig value for a codeplug value.
+func toDmrconfig(fType FieldType, value string) string {
+	for k, v := range dmrconfigValues[fType] {
+		if v == value {
+			return k
+		}
+	}
+
+	return value
+}
+
+// dmrconfigName converts a dmrconfig name to a codeplug name.
+func dmrconfigName(s string) string {
+	if s == "-" {
+		return ""
+	}
+
+	return strings.Replace(s, "_", " ", -1)
+}
+
+// nameToDmrconfig converts a codeplug name to a dmrconfig name.
+func nameToDmrconfig(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return strings.Replace(s, " ", "_", -1)
+}
+
+// dmrconfigRadio returns dmrconfig's name for a codeplug type.
+func dmrconfigRadio(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "MD-"):
+		return "TYT " + typ
+	case strings.HasPrefix(typ, "RT"):
+		return "Retevis " + typ
+	case strings.HasPrefix(typ, "DJ-"):
+		return "Alinco " + typ
+	}
+
+	return typ
+}
+
+// numberList formats a list of record numbers as dmrconfig's
+// comma-separated numbers and ranges.
+func numberList(numbers []int) string {
+	if len(numbers) == 0 {
+		return "-"
+	}
+
+	var strs []string
+	for i := 0; i < len(numbers); {
+		j := i
+		for j+1 < len(numbers) && numbers[j+1] == numbers[j]+1 {
+			j++
+		}
+		str := strconv.Itoa(numbers[i])
+		if j > i {
+			str += "-" + strconv.Itoa(numbers[j])
+		}
+		strs = append(strs, str)
+		i = j + 1
+	}
+
+	return strings.Join(strs, ",")
+}
+
+// parseNumberList parses dmrconfig's comma-separated numbers and ranges.
+func parseNumberList(s string) ([]int, error) {
+	var numbers []int
+
+	if s == "-" {
+		return numbers, nil
+	}
+
+	for _, str := range strings.Split(s, ",") {
+		bounds := strings.SplitN(str, "-", 2)
+		low, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("bad number list: %s", s)
+		}
+		high := low
+		if len(bounds) == 2 {
+			high, err = strconv.Atoi(bounds[1])
+			if err != nil || high < low {
+				return nil, fmt.Errorf("bad number list: %s", s)
+			}
+		}
+		for n := low; n <= high; n++ {
+			numbers = append(numbers, n)
+		}
+	}
+
+	return numbers, nil
+}
+
+// ExportDmrconfig writes the codeplug's channels, zones, scan lists,
+// contacts and group lists to filename in dmrconfig's format.  It
+// returns a description of each of the codeplug's settings that
+// cannot be represented in that format.
+func (cp *Codeplug) ExportDmrconfig(filename string) (unsupported []string, err error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	w := bufio.NewWriter(file)
+	unsupported = cp.writeDmrconfig(w)
+	err = w.Flush()
+
+	return unsupported, err
+}
+
+func (cp *Codeplug) writeDmrconfig(iw io.Writer) (unsupported []string) {
+	numbers := make(map[RecordType]map[string]int)
+	for _, rType := range dmrconfigRecordTypes {
+		numbers[rType] = make(map[string]int)
+		for i, r := range cp.records(rType) {
+			numbers[rType][r.Name()] = i + 1
+		}
+	}
+
+	value := func(r *Record, fType FieldType) string {
+		f := r.Field(fType)
+		if f == nil {
+			return "-"
+		}
+		return toDmrconfig(fType, f.String())
+	}
+
+	ref := func(r *Record, fType FieldType) string {
+		f := r.Field(fType)
+		if f == nil {
+			return "-"
+		}
+		n, ok := numbers[f.listRecordType][f.String()]
+		if !ok {
+			str := toDmrconfig(fType, f.String())
+			if str == "None" {
+				str = "-"
+			}
+			return str
+		}
+		return strconv.Itoa(n)
+	}
+
+	refs := func(r *Record, fType FieldType) string {
+		var nums []int
+		for _, f := range r.Fields(fType) {
+			n, ok := numbers[f.listRecordType][f.String()]
+			if ok {
+				nums = append(nums, n)
+			}
+		}
+		return numberList(nums)
+	}
+
+	name := func(r *Record) string {
+		f := r.NameField()
+		if f == nil {
+			return "-"
+		}
+		return nameToDmrconfig(removeSuffix(f, f.String()))
+	}
+
+	w := tabwriter.NewWriter(iw, 0, 8, 1, ' ', 0)
+
+	fmt.Fprintf(w, "Radio: %s\n", dmrconfigRadio(cp.Type()))
+
+	var digital, analog []*Record
+	for _, r := range cp.records(RtChannels_md380) {
+		if r.Field(FtCiChannelMode).String() == "Digital" {
+			digital = append(digital, r)
+		} else {
+			analog = append(analog, r)
+		}
+
+		if r.Field(FtCiBandwidth).String() == "20" {
+			unsupported = append(unsupported, fmt.Sprintf("%s.%s: %s: 20 KHz bandwidth is not supported", RtChannels_md380, FtCiBandwidth, r.Name()))
+		}
+	}
+
+	if len(digital) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Table of digital channels.")
+		fmt.Fprintln(w, "Digital\tName\tReceive\tTransmit\tPower\tScan\tTOT\tRO\tAdmit\tColor\tSlot\tRxGL\tTxContact")
+		for _, r := range digital {
+			fmt.Fprintf(w, "%5d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				numbers[RtChannels_md380][r.Name()],
+				name(r),
+				value(r, FtCiRxFrequency),
+				dmrconfigTransmit(r),
+				value(r, FtCiPower),
+				ref(r, FtCiScanList_md380),
+				value(r, FtCiTot),
+				value(r, FtCiRxOnly),
+				value(r, FtCiAdmitCriteria),
+				value(r, FtCiColorCode),
+				value(r, FtCiRepeaterSlot),
+				ref(r, FtCiGroupList),
+				ref(r, FtCiContactName))
+		}
+	}
+
+	if len(analog) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Table of analog channels.")
+		fmt.Fprintln(w, "Analog\tName\tReceive\tTransmit\tPower\tScan\tTOT\tRO\tAdmit\tSquelch\tRxTone\tTxTone\tWidth")
+		for _, r := range analog {
+			fmt.Fprintf(w, "%5d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				numbers[RtChannels_md380][r.Name()],
+				name(r),
+				value(r, FtCiRxFrequency),
+				dmrconfigTransmit(r),
+				value(r, FtCiPower),
+				ref(r, FtCiScanList_md380),
+				value(r, FtCiTot),
+				value(r, FtCiRxOnly),
+				value(r, FtCiAdmitCriteria),
+				value(r, FtCiSquelch),
+				value(r, FtCiCtcssDecode),
+				value(r, FtCiCtcssEncode),
+				value(r, FtCiBandwidth))
+		}
+	}
+
+	if zones := cp.records(RtZones_md380); len(zones) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Table of channel zones.")
+		fmt.Fprintln(w, "Zone\tName\tChannels")
+		for i, r := range zones {
+			if !r.HasFieldType(FtZiChannelB_uv380) {
+				fmt.Fprintf(w, "%4d\t%s\t%s\n", i+1, name(r),
+					refs(r, FtZiChannel_md380))
+				continue
+			}
+			fmt.Fprintf(w, "%4da\t%s\t%s\n", i+1, name(r),
+				refs(r, FtZiChannelA_uv380))
+			if len(r.Fields(FtZiChannelB_uv380)) > 0 {
+				fmt.Fprintf(w, "%4db\t-\t%s\n", i+1,
+					refs(r, FtZiChannelB_uv380))
+			}
+		}
+	}
+
+	if scanLists := cp.records(RtScanLists_md380); len(scanLists) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Table of scan lists.")
+		fmt.Fprintln(w, "Scanlist\tName\tPCh1\tPCh2\tTxCh\tChannels")
+		for i, r := range scanLists {
+			fmt.Fprintf(w, "%4d\t%s\t%s\t%s\t%s\t%s\n", i+1, name(r),
+				ref(r, FtSlPriorityChannel1_md380),
+				ref(r, FtSlPriorityChannel2_md380),
+				ref(r, FtSlTxDesignatedChannel_md380),
+				refs(r, FtSlChannel_md380))
+		}
+	}
+
+	if contacts := cp.records(RtContacts); len(contacts) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Table of contacts.")
+		fmt.Fprintln(w, "Contact\tName\tType\tID\tRxTone")
+		for i, r := range contacts {
+			fmt.Fprintf(w, "%5d\t%s\t%s\t%s\t%s\n", i+1, name(r),
+				value(r, FtDcCallType),
+				value(r, FtDcCallID),
+				value(r, FtDcCallReceiveTone))
+		}
+	}
+
+	if groupLists := cp.records(RtGroupLists); len(groupLists) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Table of group lists.")
+		fmt.Fprintln(w, "Grouplist\tName\tContacts")
+		for i, r := range groupLists {
+			fmt.Fprintf(w, "%4d\t%s\t%s\n", i+1, name(r),
+				refs(r, FtGlContact))
+		}
+	}
+	w.Flush()
+
+	if gs := cp.record(RtGeneralSettings_md380); gs != nil {
+		fmt.Fprintln(iw)
+		for _, s := range dmrconfigSettings {
+			f := gs.Field(s.fType)
+			if f == nil {
+				continue
+			}
+			fmt.Fprintf(iw, "%s: %s\n", s.key, nameToDmrconfig(f.String()))
+		}
+	}
+
+	for _, rType := range dmrconfigRecordTypes {
+		unsupported = append(unsupported, cp.unsupportedFields(rType, dmrconfigFieldTypes[rType])...)
+	}
+
+	return unsupported
+}
+
+// dmrconfigTransmit returns a channel's transmit frequency in dmrconfig's
+// format, as an offset from the receive frequency.
+func dmrconfigTransmit(r *Record) string {
+	f := r.Field(FtCiTxFrequencyOffset)
+	if f == nil {
+		return "+0"
+	}
+
+	offset, err := strconv.ParseFloat(f.String(), 64)
+	if err != nil || offset == 0 {
+		return "+0"
+	}
+
+	str := strconv.FormatFloat(offset, 'f', -1, 64)
+	if offset > 0 {
+		str = "+" + str
+	}
+
+	return str
+}
+
+// dmrconfigRow is a row of a dmrconfig table.
+type dmrconfigRow struct {
+	number int
+	suffix string
+	fields []string
+	pos    *position
+}
+
+// ImportDmrconfig replaces the codeplug's channels, zones, scan lists,
+// contacts and group lists with those read from a dmrconfig file, and
+// sets the radio's ID, name and intro lines.  It returns a description
+// of each of the file's settings that have no codeplug equivalent.
+func (cp *Codeplug) ImportDmrconfig(reader io.Reader) (unsupported []string, err error) {
+	report := func(pos *position, format string, v ...interface{}) {
+		msg := fmt.Sprintf(format, v...)
+		if pos != nil {
+			msg = fmt.Sprintf("line %d: %s", pos.line+1, msg)
+		}
+		unsupported = append(unsupported, msg)
+	}
+
+	tables := make(map[string][]*dmrconfigRow)
+	settings := make(map[string]string)
+	settingPos := make(map[string]*position)
+	var settingKeys []string
+
+	table := ""
+	scanner := bufio.NewScanner(reader)
+	for lineNum := 0; scanner.Scan(); lineNum++ {
+		pos := &position{line: lineNum}
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		first := rune(line[0])
+		switch {
+		case unicode.IsSpace(first) || unicode.IsDigit(first):
+			if table == "" {
+				report(pos, "data outside of a table")
+				continue
+			}
+			fields := strings.Fields(line)
+			numStr := strings.TrimRight(fields[0], "ab")
+			number, err := strconv.Atoi(numStr)
+			if err != nil {
+				report(pos, "bad %s number: %s", table, fields[0])
+				continue
+			}
+			tables[table] = append(tables[table], &dmrconfigRow{
+				number: number,
+				suffix: fields[0][len(numStr):],
+				fields: fields[1:],
+				pos:    pos,
+			})
+
+		case strings.Contains(line, ":"):
+			table = ""
+			i := strings.IndexByte(line, ':')
+			key := strings.TrimSpace(line[:i])
+			settings[key] = strings.TrimSpace(line[i+1:])
+			settingPos[key] = pos
+			settingKeys = append(settingKeys, key)
+
+		default:
+			table = strings.Fields(line)[0]
+			switch table {
+			case "Digital", "Analog", "Zone", "Scanlist", "Contact", "Grouplist":
+			default:
+				report(pos, "%s table is not supported", table)
+			}
+		}
+	}
+	err = scanner.Err()
+	if err != nil {
+		return unsupported, err
+	}
+
+	for _, key := range settingKeys {
+		value := settings[key]
+		switch key {
+		case "Radio":
+			if !strings.HasSuffix(value, cp.Type()) {
+				report(settingPos[key], "radio %s does not match codeplug type %s", value, cp.Type())
+			}
+		case "ID", "Name", "Intro Line 1", "Intro Line 2":
+		default:
+			report(settingPos[key], "%s: not supported", key)
+		}
+	}
+
+	var channels []*dmrconfigRow
+	channels = append(channels, tables["Digital"]...)
+	channels = append(channels, tables["Analog"]...)
+
+	// Assign each record a unique name, by which it will be referenced.
+	names := make(map[RecordType]map[int]string)
+	nameRecords := func(rType RecordType, rows []*dmrconfigRow) {
+		sort.SliceStable(rows, func(i, j int) bool {
+			return rows[i].number < rows[j].number
+		})
+		names[rType] = make(map[int]string)
+		used := make(map[string]bool)
+		for _, row := range rows {
+			if row.suffix == "b" || len(row.fields) == 0 {
+				continue
+			}
+			name := dmrconfigName(row.fields[0])
+			var unique string
+			if rType == RtContacts && len(row.fields) > 2 {
+				unique = cp.importContactName(name, row.fields[2], used)
+			} else {
+				unique = cp.importName(rType, name, used)
+			}
+			renamed := rType != RtContacts || cp.uniqueContactNames
+			if renamed && unique != name {
+				report(row.pos, "duplicate name %s renamed to %s", name, unique)
+			}
+			names[rType][row.number] = unique
+		}
+	}
+	nameRecords(RtChannels_md380, channels)
+	nameRecords(RtZones_md380, tables["Zone"])
+	nameRecords(RtScanLists_md380, tables["Scanlist"])
+	nameRecords(RtContacts, tables["Contact"])
+	nameRecords(RtGroupLists, tables["Grouplist"])
+
+	refName := func(rType RecordType, fType FieldType, row *dmrconfigRow, str string) string {
+		if v, ok := dmrconfigValues[fType][str]; ok {
+			return v
+		}
+		if str == "-" {
+			return "None"
+		}
+		n, err := strconv.Atoi(str)
+		if err == nil {
+			if name, ok := names[rType][n]; ok {
+				return name
+			}
+		}
+		report(row.pos, "%s %s not found", rType, str)
+		return "None"
+	}
+
+	var pRecs []*parsedRecord
+	var pRec *parsedRecord
+	counts := make(map[RecordType]int)
+
+	addRecord := func(rType RecordType, row *dmrconfigRow) {
+		pRec = &parsedRecord{
+			name:  string(rType),
+			index: counts[rType],
+			pos:   row.pos,
+		}
+		counts[rType]++
+		pRecs = append(pRecs, pRec)
+		pRec.pFields = append(pRec.pFields, &parsedField{
+			name:  string(FtCiName),
+			value: names[rType][row.number],
+			pos:   row.pos,
+		})
+	}
+
+	addField := func(fType FieldType, index int, value string, row *dmrconfigRow) {
+		pRec.pFields = append(pRec.pFields, &parsedField{
+			name:  string(fType),
+			index: index,
+			value: value,
+			pos:   row.pos,
+		})
+	}
+
+	addRefs := func(rType RecordType, fType FieldType, row *dmrconfigRow, str string) {
+		numbers, err := parseNumberList(str)
+		if err != nil {
+			report(row.pos, "%s", err.Error())
+			return
+		}
+		for i, n := range numbers {
+			addField(fType, i, refName(rType, fType, row, strconv.Itoa(n)), row)
+		}
+	}
+
+	checkColumns := func(table string, row *dmrconfigRow, n int) bool {
+		if len(row.fields) == n {
+			return true
+		}
+		report(row.pos, "%s: expected %d columns, found %d", table, n+1, len(row.fields)+1)
+		return false
+	}
+
+	digital := make(map[*dmrconfigRow]bool)
+	for _, row := range tables["Digital"] {
+		digital[row] = true
+	}
+
+	for _, row := range channels {
+		if !checkColumns("channel", row, 12) {
+			continue
+		}
+		f := row.fields
+		addRecord(RtChannels_md380, row)
+		addField(FtCiRxFrequency, 0, f[1], row)
+		addField(FtCiTxFrequencyOffset, 0, dmrconfigOffset(f[1], f[2]), row)
+		addField(FtCiPower, 0, fromDmrconfig(FtCiPower, f[3]), row)
+		addField(FtCiScanList_md380, 0, refName(RtScanLists_md380, FtCiScanList_md380, row, f[4]), row)
+		addField(FtCiTot, 0, fromDmrconfig(FtCiTot, f[5]), row)
+		addField(FtCiRxOnly, 0, fromDmrconfig(FtCiRxOnly, f[6]), row)
+		addField(FtCiAdmitCriteria, 0, fromDmrconfig(FtCiAdmitCriteria, f[7]), row)
+		if digital[row] {
+			addField(FtCiChannelMode, 0, "Digital", row)
+			addField(FtCiColorCode, 0, f[8], row)
+			addField(FtCiRepeaterSlot, 0, f[9], row)
+			addField(FtCiGroupList, 0, refName(RtGroupLists, FtCiGroupList, row, f[10]), row)
+			addField(FtCiContactName, 0, refName(RtContacts, FtCiContactName, row, f[11]), row)
+		} else {
+			addField(FtCiChannelMode, 0, "Analog", row)
+			addField(FtCiSquelch, 0, f[8], row)
+			addField(FtCiCtcssDecode, 0, fromDmrconfig(FtCiCtcssDecode, f[9]), row)
+			addField(FtCiCtcssEncode, 0, fromDmrconfig(FtCiCtcssEncode, f[10]), row)
+			addField(FtCiBandwidth, 0, f[11], row)
+		}
+	}
+
+	zoneNumber := -1
+	for _, row := range tables["Zone"] {
+		if !checkColumns("zone", row, 2) {
+			continue
+		}
+		switch row.suffix {
+		case "b":
+			if pRec == nil || pRec.name != string(RtZones_md380) || zoneNumber != row.number {
+				report(row.pos, "zone %db without zone %da", row.number, row.number)
+				continue
+			}
+			addRefs(RtChannels_md380, FtZiChannelB_uv380, row, row.fields[1])
+		default:
+			addRecord(RtZones_md380, row)
+			addRefs(RtChannels_md380, FtZiChannel_md380, row, row.fields[1])
+			zoneNumber = row.number
+		}
+	}
+
+	for _, row := range tables["Scanlist"] {
+		if !checkColumns("scanlist", row, 5) {
+			continue
+		}
+		f := row.fields
+		addRecord(RtScanLists_md380, row)
+		addField(FtSlPriorityChannel1_md380, 0, refName(RtChannels_md380, FtSlPriorityChannel1_md380, row, f[1]), row)
+		addField(FtSlPriorityChannel2_md380, 0, refName(RtChannels_md380, FtSlPriorityChannel2_md380, row, f[2]), row)
+		addField(FtSlTxDesignatedChannel_md380, 0, refName(RtChannels_md380, FtSlTxDesignatedChannel_md380, row, f[3]), row)
+		addRefs(RtChannels_md380, FtSlChannel_md380, row, f[4])
+	}
+
+	for _, row := range tables["Contact"] {
+		if !checkColumns("contact", row, 4) {
+			continue
+		}
+		f := row.fields
+		addRecord(RtContacts, row)
+		addField(FtDcCallType, 0, f[1], row)
+		addField(FtDcCallID, 0, f[2], row)
+		addField(FtDcCallReceiveTone, 0, fromDmrconfig(FtDcCallReceiveTone, f[3]), row)
+	}
+
+	for _, row := range tables["Grouplist"] {
+		if !checkColumns("grouplist", row, 2) {
+			continue
+		}
+		addRecord(RtGroupLists, row)
+		addRefs(RtContacts, FtGlContact, row, row.fields[1])
+	}
+
+	deferValues := false
+	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
+	if _, warning := err.(Warning); warning {
+		for _, msg := range strings.Split(err.Error(), "\n") {
+			if msg != "" {
+				unsupported = append(unsupported, msg)
+			}
+		}
+		err = nil
+	}
+	if err != nil {
+		return unsupported, err
+	}
+
+	refs := cp.importRefs(records)
+
+	err = cp.storeParsedRecords(records)
+	if err != nil {
+		return unsupported, err
+	}
+	cp.AddMissingFields()
+
+	unsupported = append(unsupported, cp.restoreImportRefs(refs)...)
+
+	if gs := cp.record(RtGeneralSettings_md380); gs != nil {
+		for _, s := range dmrconfigSettings {
+			value, ok := settings[s.key]
+			if !ok {
+				continue
+			}
+			f := gs.Field(s.fType)
+			if f == nil {
+				report(settingPos[s.key], "%s: not supported", s.key)
+				continue
+			}
+			err := f.SetString(dmrconfigName(value))
+			if err != nil {
+				report(settingPos[s.key], "%s: %s", s.key, err.Error())
+			}
+		}
+	}
+
+	return unsupported, nil
+}
+
+// dmrconfigOffset returns the codeplug transmit offset for dmrconfig's
+// receive and transmit columns.  The transmit column holds either a
+// frequency or a signed offset from the receive frequency.
+func dmrconfigOffset(receive string, transmit string) string {
+	if strings.HasPrefix(transmit, "+") || strings.HasPrefix(transmit, "-") {
+		return transmit
+	}
+
+	rx, err := strconv.ParseFloat(receive, 64)
+	if err != nil {
+		return transmit
+	}
+	tx, err := strconv.ParseFloat(transmit, 64)
+	if err != nil {
+		return transmit
+	}
+
+	return frequencyToSignedString(tx - rx)
+}
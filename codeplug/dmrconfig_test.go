@@ -0,0 +1,123 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDmrconfigRoundTrip(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		var buf bytes.Buffer
+		unsupported := cp.writeDmrconfig(&buf)
+		for _, msg := range unsupported {
+			t.Log("export:", msg)
+		}
+
+		others := []RecordType{RtOneTouch, RtNumberKey, RtGeneralSettings_md380}
+		before := recordStrings(cp, others...)
+
+		unsupported, err := cp.ImportDmrconfig(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range unsupported {
+			t.Log("import:", msg)
+		}
+
+		checkValid(t, cp)
+
+		after := recordStrings(cp, others...)
+		if !reflect.DeepEqual(before, after) {
+			t.Errorf("records not imported were changed:\n%v\n%v", before, after)
+		}
+	})
+}
+
+func TestDmrconfigImport(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-UV380")
+
+	var changed []string
+	cp.SubscribeChanges(ChangeFilter{
+		FieldTypes: []FieldType{FtGsRadioName},
+	}, func(change *Change) {
+		changed = append(changed, change.Field().String())
+	})
+
+	text := "Name: Tester\n" +
+		"\n" +
+		"Zone\tName\tChannels\n" +
+		"   1a\tZone1\t-\n" +
+		"   2b\t-\t-\n"
+	unsupported, err := cp.ImportDmrconfig(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(changed, []string{"Tester"}) {
+		t.Errorf("radio name changes: got %q, want %q", changed, []string{"Tester"})
+	}
+
+	found := false
+	for _, msg := range unsupported {
+		if strings.Contains(msg, "zone 2b without zone 2a") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("zone 2b not reported: %q", unsupported)
+	}
+}
+
+func TestDmrconfigKeepsContactNames(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		var names []string
+		for _, r := range cp.records(RtContacts) {
+			names = append(names, r.Name())
+		}
+		if len(names) == 0 {
+			t.Skip("no contacts")
+		}
+
+		var buf bytes.Buffer
+		cp.writeDmrconfig(&buf)
+		_, err := cp.ImportDmrconfig(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var after []string
+		for _, r := range cp.records(RtContacts) {
+			after = append(after, r.Name())
+		}
+		if !reflect.DeepEqual(names, after) {
+			t.Errorf("contact names changed:\n%q\n%q", names, after)
+		}
+	})
+}
@@ -33,10 +33,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
-
-	l "github.com/dalefarnsworth/codeplug/debug"
 )
 
 const InvalidValueString = "=INVALID="
@@ -56,7 +55,7 @@ type value interface {
 	setString(*Field, string, bool) error
 	valid(*Field) error
 	load(*Field)
-	store(*Field)
+	store(*Field) error
 }
 
 // An fDesc contains a field type's dynamic information.
@@ -64,6 +63,7 @@ type fDesc struct {
 	*fieldInfo
 	record *Record
 	fields []*Field
+	index  int
 }
 
 // A fieldInfo contains a field type's static information.
@@ -82,12 +82,10 @@ type fieldInfo struct {
 	enablerType    FieldType
 	enablers       []enabler
 	listRecordType RecordType
-	recordInfo     *recordInfo
 	extOffset      int
 	extSize        int
 	extIndex       int
 	extBitOffset   int
-	index          int
 }
 
 type enabler struct {
@@ -263,11 +261,14 @@ func (f *Field) listNames() []string {
 	derefValues := make([]string, len(*pListNames))
 	for i, name := range *pListNames {
 		r := f.record.codeplug.FindRecordByName(f.listRecordType, name)
+		derefValues[i] = InvalidValueString
+		if r == nil {
+			continue
+		}
 		fields := r.AllFields()
-		if len(fields) != 1 {
-			l.Fatal("deref: more than one field")
+		if len(fields) == 1 {
+			derefValues[i] = fields[0].String()
 		}
-		derefValues[i] = fields[0].String()
 	}
 
 	return derefValues
@@ -348,9 +349,6 @@ func (f *Field) Strings() []string {
 
 	case VtSpanList:
 		strs = f.SpanStrings()
-
-	default:
-		l.Fatalf("f.Strings: unexpected f.valueType: %s", f.valueType)
 	}
 
 	if len(strs) == 0 {
@@ -475,20 +473,20 @@ func (f *Field) load() {
 }
 
 // store inserts the field's value into the field's part of cp.bytes.
-func (f *Field) store() {
+func (f *Field) store() error {
 	if f.noStore {
 		// some fields may overlap with other fields
-		return
+		return nil
 	}
 
 	if !f.IsEnabled() {
 		if f.IsInvalidValue() {
 			// Leave invalid value in the codeplug as we loaded it.
-			return
+			return nil
 		}
 	}
 
-	f.value.store(f)
+	return f.value.store(f)
 }
 
 func (f *Field) SetStore(store bool) {
@@ -501,8 +499,8 @@ func (f *Field) bytes() []byte {
 }
 
 // storeBytes stores the field's value into the field's part of cp.bytes.
-func (f *Field) storeBytes(bytes []byte) {
-	f.fDesc.storeBytes(bytes, f.record, f.fIndex)
+func (f *Field) storeBytes(bytes []byte) error {
+	return f.fDesc.storeBytes(bytes, f.record, f.fIndex)
 }
 
 // TypeName returns the field's type's name.
@@ -578,6 +576,224 @@ func (f *Field) EnablerType() FieldType {
 	return f.enablerType
 }
 
+// valueTypeError returns an error reporting that the field's value
+// cannot be accessed as the given kind of value.
+func (f *Field) valueTypeError(kind string) error {
+	return fmt.Errorf("%s: %s value is not %s", f.FullTypeName(), f.valueType, kind)
+}
+
+// Float64 returns the field's value as a floating point number.
+// Frequencies are in MHz and CTCSS tones in Hz.
+func (f *Field) Float64() (float64, error) {
+	s := f.String()
+
+	switch f.valueType {
+	case VtFrequency, VtBiFrequency, VtFrequencyOffset:
+		return stringToFrequency(s)
+
+	case VtCtcssDcs:
+		if ctcssDcsStringToBinary(s) < 0 || s == "None" || s[0] == 'D' {
+			return 0, fmt.Errorf("%s: '%s' is not a CTCSS tone", f.FullTypeName(), s)
+		}
+		return strconv.ParseFloat(s, 64)
+	}
+
+	return 0, f.valueTypeError("a float64")
+}
+
+// SetFloat64 sets the field's value from a floating point number.
+// Frequencies are in MHz and CTCSS tones in Hz.
+func (f *Field) SetFloat64(value float64) error {
+	var s string
+
+	switch f.valueType {
+	case VtFrequency, VtBiFrequency:
+		s = frequencyToString(value)
+
+	case VtFrequencyOffset:
+		s = frequencyToSignedString(value)
+
+	case VtCtcssDcs:
+		s = fmt.Sprintf("%.1f", value)
+
+	default:
+		return f.valueTypeError("a float64")
+	}
+
+	return f.SetString(s)
+}
+
+// Int returns the field's value as an integer.  A span's minimum
+// string, such as "None" or "Infinite", is returned as the span's
+// minimum value.
+func (f *Field) Int() (int, error) {
+	s := f.String()
+
+	switch f.valueType {
+	case VtSpan, VtSpanList, VtGpsReportInterval:
+		sp := f.span
+		if sp.minString != "" && s == sp.minString {
+			return sp.min * sp.scale, nil
+		}
+
+	case VtCallID, VtPrivacyNumber:
+
+	default:
+		return 0, f.valueTypeError("an int")
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s: bad integer '%s'", f.FullTypeName(), s)
+	}
+
+	return i, nil
+}
+
+// SetInt sets the field's value from an integer.
+func (f *Field) SetInt(value int) error {
+	switch f.valueType {
+	case VtSpan, VtSpanList, VtGpsReportInterval, VtCallID, VtPrivacyNumber:
+		return f.SetString(strconv.Itoa(value))
+	}
+
+	return f.valueTypeError("an int")
+}
+
+// Bool returns true if the field's value is "On".
+func (f *Field) Bool() (bool, error) {
+	switch f.valueType {
+	case VtOffOn, VtOnOff:
+		return f.String() == "On", nil
+	}
+
+	return false, f.valueTypeError("a bool")
+}
+
+// SetBool sets the field's value to "On" if value is true and to "Off"
+// if it is false.
+func (f *Field) SetBool(value bool) error {
+	switch f.valueType {
+	case VtOffOn, VtOnOff:
+		s := "Off"
+		if value {
+			s = "On"
+		}
+		return f.SetString(s)
+	}
+
+	return f.valueTypeError("a bool")
+}
+
+// isEnum returns true if the field's value is one of a fixed set of
+// strings.
+func (f *Field) isEnum() bool {
+	switch f.valueType {
+	case VtIStrings, VtBandwidth, VtPrivacyNumber,
+		VtIndexedStrings, VtRadioButton, VtCallType:
+		return true
+	}
+
+	return false
+}
+
+// EnumIndex returns the index of the field's value in f.Strings().
+func (f *Field) EnumIndex() (int, error) {
+	if !f.isEnum() {
+		return 0, f.valueTypeError("an enumeration")
+	}
+
+	s := f.String()
+	for i, str := range f.Strings() {
+		if str == s {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%s: invalid value '%s'", f.FullTypeName(), s)
+}
+
+// SetEnumIndex sets the field's value to f.Strings()[index].
+func (f *Field) SetEnumIndex(index int) error {
+	if !f.isEnum() {
+		return f.valueTypeError("an enumeration")
+	}
+
+	strs := f.Strings()
+	if index < 0 || index >= len(strs) {
+		return fmt.Errorf("%s: index %d out of range", f.FullTypeName(), index)
+	}
+
+	return f.SetString(strs[index])
+}
+
+// isRecordRef returns true if the field's value references a record.
+func (f *Field) isRecordRef() bool {
+	switch f.valueType {
+	case VtListIndex, VtMemberListIndex, VtContactListIndex,
+		VtNkContactListIndex, VtGpsListIndex, VtDerefListIndex:
+		return true
+	}
+
+	return false
+}
+
+// RecordRef returns the record referenced by the field.  It returns
+// nil if the field holds one of its indexed strings, such as "None".
+func (f *Field) RecordRef() (*Record, error) {
+	if !f.isRecordRef() {
+		return nil, f.valueTypeError("a record reference")
+	}
+
+	s := f.String()
+	for _, is := range f.IndexedStrings() {
+		if is.String == s {
+			return nil, nil
+		}
+	}
+
+	rd := f.record.codeplug.rDesc[f.listRecordType]
+	if rd != nil {
+		for i, name := range f.listNames() {
+			if name == s && i < len(rd.records) {
+				return rd.records[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("bad %s name: '%s'", f.listRecordType, s)
+}
+
+// SetRecordRef makes the field reference the given record.  A nil
+// record sets the field to its first indexed string, such as "None".
+func (f *Field) SetRecordRef(r *Record) error {
+	if !f.isRecordRef() {
+		return f.valueTypeError("a record reference")
+	}
+
+	if r == nil {
+		is := f.IndexedStrings()
+		if len(is) == 0 {
+			return fmt.Errorf("%s: requires a %s record", f.FullTypeName(), f.listRecordType)
+		}
+		return f.SetString(is[0].String)
+	}
+
+	if r.rType != f.listRecordType || r.codeplug != f.record.codeplug {
+		return fmt.Errorf("%s: not a %s record of this codeplug", f.FullTypeName(), f.listRecordType)
+	}
+
+	names := f.listNames()
+	rd := f.record.codeplug.rDesc[f.listRecordType]
+	for i, lr := range rd.records {
+		if lr == r && i < len(names) {
+			return f.SetString(names[i])
+		}
+	}
+
+	return fmt.Errorf("%s: record not found", f.FullTypeName())
+}
+
 // fieldDeleted returns true if the field at fIndex is deleted.
 func (fd *fDesc) fieldDeleted(r *Record, fIndex int) bool {
 	if fd.max == 1 {
@@ -595,24 +811,16 @@ func (fd *fDesc) fieldDeleted(r *Record, fIndex int) bool {
 }
 
 // deleteField marks the field at fIndex as deleted.
-func (fd *fDesc) deleteField(r *Record, fIndex int) {
+func (fd *fDesc) deleteField(r *Record, fIndex int) error {
 	bytes := fd.bytes(r, fIndex)
 	for i := range bytes {
 		bytes[i] = 0
 	}
-	fd.storeBytes(bytes, r, fIndex)
+	return fd.storeBytes(bytes, r, fIndex)
 }
 
 func (fd *fDesc) fieldOffset(r *Record, fIndex int) int {
-	var offset int
-	if fd.extSize == 0 || fIndex < fd.extIndex {
-		offset = r.offset + r.rIndex*r.size + fd.offset(fIndex)
-	} else {
-		fExtOffset := fd.extBitOffset/8 + (fIndex-fd.extIndex)*fd.size()
-		offset = fd.extOffset + r.rIndex*fd.extSize + fExtOffset
-	}
-
-	return offset
+	return fd.rdtOffset(r.recordInfo, r.rIndex, fIndex)
 }
 
 // bytes returns the bytes of the field from cp.bytes.
@@ -638,17 +846,17 @@ func (fd *fDesc) bytes(r *Record, fIndex int) []byte {
 }
 
 // storeBytes inserts bytes value into the field's bits in cp.bytes.
-func (fd *fDesc) storeBytes(bytes []byte, r *Record, fIndex int) {
+func (fd *fDesc) storeBytes(bytes []byte, r *Record, fIndex int) error {
 	if fd.size() != len(bytes) {
-		panic(fmt.Sprintf("%s: storeBytes(%v) size mismatch: %d vs %d",
-			fd.typeName, bytes, fd.size(), len(bytes)))
+		return fmt.Errorf("%s: %d bytes stored in a %d byte field",
+			fd.typeName, len(bytes), fd.size())
 	}
 
 	cp := r.codeplug
 	offset := fd.fieldOffset(r, fIndex)
 	if fd.bitSize >= 8 {
 		copy(cp.bytes[offset:offset+fd.size()], bytes)
-		return
+		return nil
 	}
 
 	value := int(bytes[0])
@@ -661,11 +869,14 @@ func (fd *fDesc) storeBytes(bytes []byte, r *Record, fIndex int) {
 	}
 	mask = ^mask
 	if (value & mask) != 0 {
-		panic("value wider than bitSize")
+		return fmt.Errorf("%s: value %d wider than %d bits",
+			fd.typeName, bytes[0], fd.bitSize)
 	}
 
 	cp.bytes[offset] &= byte(mask)
 	cp.bytes[offset] |= byte(value)
+
+	return nil
 }
 
 // offset returns the byte offset of the field at fIndex within the field's
@@ -674,6 +885,37 @@ func (fi *fieldInfo) offset(fIndex int) int {
 	return (fi.bitOffset + fIndex*fi.bitSize) / 8
 }
 
+// rdtOffset returns the offset within cp.bytes of the field at fIndex
+// of the record at rIndex.
+func (fi *fieldInfo) rdtOffset(ri *recordInfo, rIndex int, fIndex int) int {
+	var offset int
+	if fi.extSize == 0 || fIndex < fi.extIndex {
+		offset = ri.offset + rIndex*ri.size + fi.offset(fIndex)
+	} else {
+		fExtOffset := fi.extBitOffset/8 + (fIndex-fi.extIndex)*fi.size()
+		offset = fi.extOffset + rIndex*fi.extSize + fExtOffset
+	}
+
+	return offset
+}
+
+// byteMask returns the mask of the field's bits within a byte of the
+// field.  Fields of fewer than 8 bits occupy part of a single byte.
+func (fi *fieldInfo) byteMask() byte {
+	if fi.bitSize >= 8 {
+		return 0xff
+	}
+
+	mask := (1 << uint(fi.bitSize)) - 1
+
+	rightOffset := uint((fi.bitOffset + fi.bitSize) % 8)
+	if rightOffset != 0 {
+		mask <<= 8 - rightOffset
+	}
+
+	return byte(mask)
+}
+
 // size returns the field's size in bytes
 func (fi *fieldInfo) size() (fSize int) {
 	return (fi.bitSize + 7) / 8
@@ -718,8 +960,8 @@ func (v *frequency) load(f *Field) {
 }
 
 // store stores the frequency's value into its bits in cp.bytes.
-func (v *frequency) store(f *Field) {
-	f.storeBytes(frequencyToBytes(float64(*v)))
+func (v *frequency) store(f *Field) error {
+	return f.storeBytes(frequencyToBytes(float64(*v)))
 }
 
 type frequencyOffset float64
@@ -776,11 +1018,14 @@ func (v *frequencyOffset) load(f *Field) {
 }
 
 // store stores the frequencyOffset's value into its bits in cp.bytes.
-func (v *frequencyOffset) store(f *Field) {
+func (v *frequencyOffset) store(f *Field) error {
 	rxFreq, err := rxFrequency(f)
-	if err == nil {
-		f.storeBytes(frequencyToBytes(float64(*v) + rxFreq))
+	if err != nil {
+		// Without a receive frequency, there is no offset to store.
+		return nil
 	}
+
+	return f.storeBytes(frequencyToBytes(float64(*v) + rxFreq))
 }
 
 func rxFrequency(f *Field) (float64, error) {
@@ -835,12 +1080,12 @@ func (v *onOff) load(f *Field) {
 }
 
 // store stores the onOff's value into its bits in cp.bytes.
-func (v *onOff) store(f *Field) {
+func (v *onOff) store(f *Field) error {
 	b := 1
 	if *v {
 		b = 0
 	}
-	f.storeBytes([]byte{byte(b)})
+	return f.storeBytes([]byte{byte(b)})
 }
 
 // offOn is a field value representing a boolean value.
@@ -858,12 +1103,12 @@ func (v *offOn) load(f *Field) {
 }
 
 // store stores the offOn's value into its bits in cp.bytes.
-func (v *offOn) store(f *Field) {
+func (v *offOn) store(f *Field) error {
 	b := 0
 	if v.onOff {
 		b = 1
 	}
-	f.storeBytes([]byte{byte(b)})
+	return f.storeBytes([]byte{byte(b)})
 }
 
 // iStrings is a field value where an integer value is used to index
@@ -916,8 +1161,8 @@ func (v *iStrings) load(f *Field) {
 }
 
 // store stores the iStrings' value into its bits in cp.bytes.
-func (v *iStrings) store(f *Field) {
-	f.storeBytes([]byte{byte(*v)})
+func (v *iStrings) store(f *Field) error {
+	return f.storeBytes([]byte{byte(*v)})
 }
 
 type bandwidth struct {
@@ -1009,8 +1254,8 @@ func (v *span) load(f *Field) {
 }
 
 // store stores the span's value into its bits in cp.bytes.
-func (v *span) store(f *Field) {
-	f.storeBytes([]byte{byte(*v)})
+func (v *span) store(f *Field) error {
+	return f.storeBytes([]byte{byte(*v)})
 }
 
 type spanList struct {
@@ -1082,8 +1327,8 @@ func (v *indexedStrings) load(f *Field) {
 }
 
 // store stores the indexedStrings's value into its bits in cp.bytes.
-func (v *indexedStrings) store(f *Field) {
-	f.storeBytes([]byte{byte(*v)})
+func (v *indexedStrings) store(f *Field) error {
+	return f.storeBytes([]byte{byte(*v)})
 }
 
 type radioButton struct {
@@ -1152,9 +1397,9 @@ func (v *biFrequency) load(f *Field) {
 }
 
 // store stores the biFrequency's value into its bits in cp.bytes.
-func (v *biFrequency) store(f *Field) {
+func (v *biFrequency) store(f *Field) error {
 	i := int64ToBcd(int64(*v) * int64(10))
-	f.storeBytes(int64ToBytes(i, f.size()))
+	return f.storeBytes(int64ToBytes(i, f.size()))
 }
 
 // introLine is a field value representing a introductory line of text
@@ -1198,9 +1443,9 @@ func (v *introLine) load(f *Field) {
 }
 
 // store stores the introLine's value into its bits in cp.bytes.
-func (v *introLine) store(f *Field) {
+func (v *introLine) store(f *Field) error {
 	ucs2, _ := stringToUcs2Bytes(string(*v), f.size())
-	f.storeBytes(ucs2)
+	return f.storeBytes(ucs2)
 }
 
 type callType struct {
@@ -1274,8 +1519,8 @@ func (v *callID) load(f *Field) {
 }
 
 // store stores the callID's value into its bits in cp.bytes.
-func (v *callID) store(f *Field) {
-	f.storeBytes(int64ToBytes(int64(*v), f.size()))
+func (v *callID) store(f *Field) error {
+	return f.storeBytes(int64ToBytes(int64(*v), f.size()))
 }
 
 // radioPassword is a field value representing password for the radio.
@@ -1317,13 +1562,13 @@ func (v *radioPassword) load(f *Field) {
 }
 
 // store stores the radioPassword's value into its bits in cp.bytes.
-func (v *radioPassword) store(f *Field) {
+func (v *radioPassword) store(f *Field) error {
 	val, _ := strconv.ParseUint(string(*v), 10, 32)
 	bytes := int64ToBytes(int64ToRevBcd(int64(val)), f.size())
 	if val == 0 {
 		bytes = []byte{0xff, 0xff, 0xff, 0xff}
 	}
-	f.storeBytes(bytes)
+	return f.storeBytes(bytes)
 }
 
 // radioPassword is a field value representing password for the radio.
@@ -1365,13 +1610,13 @@ func (v *radioProgPassword) load(f *Field) {
 }
 
 // store stores the radioProgPassword's value into its bits in cp.bytes.
-func (v *radioProgPassword) store(f *Field) {
+func (v *radioProgPassword) store(f *Field) error {
 	val, _ := strconv.ParseUint(string(*v), 10, 32)
 	bytes := int64ToBytes(int64ToRevBcd(int64(val)), f.size())
 	if *v == "" {
 		bytes = []byte{0xff, 0xff, 0xff, 0xff}
 	}
-	f.storeBytes(bytes)
+	return f.storeBytes(bytes)
 }
 
 // pcPassword is a field value representing a password for the computer.
@@ -1422,14 +1667,13 @@ func (v *pcPassword) load(f *Field) {
 }
 
 // store stores the pcPassword's value into its bits in cp.bytes.
-func (v *pcPassword) store(f *Field) {
+func (v *pcPassword) store(f *Field) error {
 	if string(*v) == "" {
 		bytes := []byte("\xff\xff\xff\xff\xff\xff\xff\xff")
-		f.storeBytes(bytes)
-		return
+		return f.storeBytes(bytes)
 	}
 
-	f.storeBytes([]byte(*v))
+	return f.storeBytes([]byte(*v))
 }
 
 // radioName is a field value representing the name of the radio.
@@ -1476,9 +1720,9 @@ func (v *radioName) load(f *Field) {
 }
 
 // store stores the radioName's value into its bits in cp.bytes.
-func (v *radioName) store(f *Field) {
+func (v *radioName) store(f *Field) error {
 	ucs2, _ := stringToUcs2Bytes(string(*v), f.size())
-	f.storeBytes(ucs2)
+	return f.storeBytes(ucs2)
 }
 
 // textMessage is a field value representing a text message
@@ -1517,9 +1761,9 @@ func (v *textMessage) load(f *Field) {
 }
 
 // store stores the textMessage's value into its bits in cp.bytes.
-func (v *textMessage) store(f *Field) {
+func (v *textMessage) store(f *Field) error {
 	ucs2, _ := stringToUcs2Bytes(string(*v), f.size())
-	f.storeBytes(ucs2)
+	return f.storeBytes(ucs2)
 }
 
 type contactName struct {
@@ -1548,10 +1792,10 @@ func (v *contactName) load(f *Field) {
 }
 
 // store stores the name's value into its bits in cp.bytes.
-func (v *contactName) store(f *Field) {
+func (v *contactName) store(f *Field) error {
 	name := removeSuffix(f, string(v.name))
 	ucs2, _ := stringToUcs2Bytes(name, f.size())
-	f.storeBytes(ucs2)
+	return f.storeBytes(ucs2)
 }
 
 // name is a field value representing a utf8 name.
@@ -1592,9 +1836,9 @@ func (v *name) load(f *Field) {
 }
 
 // store stores the name's value into its bits in cp.bytes.
-func (v *name) store(f *Field) {
+func (v *name) store(f *Field) error {
 	ucs2, _ := stringToUcs2Bytes(string(*v), f.size())
-	f.storeBytes(ucs2)
+	return f.storeBytes(ucs2)
 }
 
 // privacyNumber is a field value representing a privacy number.
@@ -1683,8 +1927,8 @@ func (v *ctcssDcs) load(f *Field) {
 }
 
 // store stores the ctcssDcs's value into its bits in cp.bytes.
-func (v *ctcssDcs) store(f *Field) {
-	f.storeBytes(int64ToBytes(int64(*v), f.size()))
+func (v *ctcssDcs) store(f *Field) error {
+	return f.storeBytes(int64ToBytes(int64(*v), f.size()))
 }
 
 // memberListIndex is a field value representing an index into a slice
@@ -1856,7 +2100,7 @@ func (v *listIndex) init(f *Field) error {
 
 	index64, err := strconv.ParseUint(str, 10, 16)
 	if err != nil {
-		l.Fatalf("listIndex ParseInt failure: %s", err.Error())
+		return fmt.Errorf("bad %s index: %s", f.listRecordType, err.Error())
 	}
 	index := int(index64)
 
@@ -1888,7 +2132,7 @@ func (v *listIndex) load(f *Field) {
 }
 
 // store stores the listIndex's value into its bits in cp.bytes.
-func (v *listIndex) store(f *Field) {
+func (v *listIndex) store(f *Field) error {
 	value := string(*v)
 	fd := f.fDesc
 
@@ -1913,10 +2157,10 @@ func (v *listIndex) store(f *Field) {
 	}
 
 	if index == -1 {
-		return
+		return nil
 	}
 
-	f.storeBytes(int64ToBytes(int64(index), f.size()))
+	return f.storeBytes(int64ToBytes(int64(index), f.size()))
 }
 
 // listIndex is a field value representing an index into a slice of records
@@ -1959,11 +2203,11 @@ func (v *ascii) load(f *Field) {
 }
 
 // store stores the ascii's value into its bits in cp.bytes.
-func (v *ascii) store(f *Field) {
+func (v *ascii) store(f *Field) error {
 	bytes := bytes.Repeat([]byte{0xff}, f.size())
 	str := string(*v) + "\000"
 	copy(bytes, []byte(str))
-	f.storeBytes(bytes)
+	return f.storeBytes(bytes)
 }
 
 // timeStamp is a field value representing a BCD-encoded time string
@@ -2003,8 +2247,8 @@ func (v *timeStamp) load(f *Field) {
 }
 
 // store stores the timeStamp's value into its bits in cp.bytes.
-func (v *timeStamp) store(f *Field) {
-	f.storeBytes(stringToBcdBytes(string(*v)))
+func (v *timeStamp) store(f *Field) error {
+	return f.storeBytes(stringToBcdBytes(string(*v)))
 }
 
 // timeStamp is a field value representing a BCD-encoded time string
@@ -2049,7 +2293,7 @@ func (v *cpsVersion) load(f *Field) {
 }
 
 // store stores the cpsVersion's value into its bits in cp.bytes.
-func (v *cpsVersion) store(f *Field) {
+func (v *cpsVersion) store(f *Field) error {
 	bytes := make([]byte, len(*v))
 	for i, r := range *v {
 		if r < '0' || r > '9' {
@@ -2057,7 +2301,7 @@ func (v *cpsVersion) store(f *Field) {
 		}
 		bytes[i] = byte(int(r) - int('0'))
 	}
-	f.storeBytes(bytes)
+	return f.storeBytes(bytes)
 }
 
 func reverseBytes(bytes []byte) []byte {
@@ -2106,8 +2350,8 @@ func (v *hexadecimal4) load(f *Field) {
 }
 
 // store stores the hexadecimal4 value into its bits in cp.bytes.
-func (v *hexadecimal4) store(f *Field) {
-	f.storeBytes((*v)[:])
+func (v *hexadecimal4) store(f *Field) error {
+	return f.storeBytes((*v)[:])
 }
 
 // hexadecimal is a field containing an arbitrary value displayed as hexadecimal
@@ -2146,8 +2390,8 @@ func (v *hexadecimal32) load(f *Field) {
 }
 
 // store stores the hexadecimal32 value into its bits in cp.bytes.
-func (v *hexadecimal32) store(f *Field) {
-	f.storeBytes((*v)[:])
+func (v *hexadecimal32) store(f *Field) error {
+	return f.storeBytes((*v)[:])
 }
 
 type biFilename struct {
@@ -2169,12 +2413,15 @@ type invalidValue struct {
 }
 
 var cachedCtcssDcsStrings []string
+var ctcssDcsStringsOnce sync.Once
 
 func ctcssDcsStrings() []string {
-	if cachedCtcssDcsStrings != nil {
-		return cachedCtcssDcsStrings
-	}
+	ctcssDcsStringsOnce.Do(makeCtcssDcsStrings)
 
+	return cachedCtcssDcsStrings
+}
+
+func makeCtcssDcsStrings() {
 	count := len(ctcssFrequencies) + 2*len(dcsCodes) + 1
 	cachedCtcssDcsStrings = make([]string, count)
 
@@ -2197,8 +2444,6 @@ func ctcssDcsStrings() []string {
 		cachedCtcssDcsStrings[i] = fmt.Sprintf("D%03dI", c)
 		i++
 	}
-
-	return cachedCtcssDcsStrings
 }
 
 var dcsCodes = [...]int{
@@ -2380,13 +2625,12 @@ func (f *Field) isDeferredValue() bool {
 	return deferred
 }
 
+// mustDeferValue returns true if setting the field's value to str must
+// wait until the records it refers to exist.
 func (f *Field) mustDeferValue(str string) bool {
-	if f.IsInvalidValue() {
+	if f.IsInvalidValue() || f.isDeferredValue() {
 		return false
 	}
-	if f.isDeferredValue() {
-		l.Fatal("already deferred: ", f.FullTypeName())
-	}
 
 	switch f.valueType {
 	case VtMemberListIndex:
@@ -2410,21 +2654,30 @@ func (f *Field) mustDeferValue(str string) bool {
 	return false
 }
 
+// deferValue defers setting the field's value to str until the records
+// it refers to exist.  Deferring an already deferred value replaces
+// its string.
 func (f *Field) deferValue(str string) {
-	if f.isDeferredValue() {
-		l.Fatal("already deferred: ", f.FullTypeName())
+	if dValue, deferred := f.value.(deferredValue); deferred {
+		dValue.str = str
+		f.value = dValue
+		return
 	}
 
 	f.value = deferredValue{value: f.value, str: str}
 }
 
-func (f *Field) undeferValue() {
+// undeferValue restores the value the field had before it was
+// deferred.  An error is returned if the field's value isn't deferred.
+func (f *Field) undeferValue() error {
 	dValue, deferred := f.value.(deferredValue)
 	if !deferred {
-		l.Fatal("undeferValue: not deferred: ", f.FullTypeName())
+		return fmt.Errorf("%s: value not deferred", f.FullTypeName())
 	}
 
 	f.value = dValue.value
+
+	return nil
 }
 
 func (f *Field) DeferredString() string {
@@ -0,0 +1,261 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"testing"
+)
+
+// testChannel returns the first channel of a new MD-380 codeplug, set
+// to analog mode.
+func testChannel(t *testing.T) *Record {
+	t.Helper()
+
+	cp := newTestCodeplug(t, "MD-380")
+	r := cp.Records(RtChannels_md380)[0]
+	err := r.Field(FtCiChannelMode).SetString("Analog")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestFieldFloat64(t *testing.T) {
+	r := testChannel(t)
+	cp := r.Codeplug()
+
+	f := r.Field(FtCiRxFrequency)
+	freq := cp.lowFrequency + 1.5
+	err := f.SetFloat64(freq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := f.Float64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != freq {
+		t.Errorf("got frequency %g, want %g", value, freq)
+	}
+
+	err = f.SetFloat64(cp.highFrequency + 100)
+	if err == nil {
+		t.Error("setting an out of range frequency succeeded")
+	}
+
+	f = r.Field(FtCiCtcssDecode)
+	err = f.SetFloat64(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.String() != "100.0" {
+		t.Errorf("got tone string %s, want 100.0", f.String())
+	}
+	value, err = f.Float64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 100 {
+		t.Errorf("got tone %g, want 100", value)
+	}
+
+	err = f.SetFloat64(99)
+	if err == nil {
+		t.Error("setting an invalid tone succeeded")
+	}
+
+	for _, s := range []string{"None", "D023N"} {
+		err = f.SetString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = f.Float64()
+		if err == nil {
+			t.Errorf("%s read as a tone", s)
+		}
+	}
+}
+
+func TestFieldInt(t *testing.T) {
+	r := testChannel(t)
+
+	f := r.Field(FtCiTot)
+	err := f.SetInt(90)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i, err := f.Int()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i != 90 {
+		t.Errorf("got tot %d, want 90", i)
+	}
+
+	err = f.SetString("Infinite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i, err = f.Int()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i != 0 {
+		t.Errorf("got tot %d for Infinite, want 0", i)
+	}
+
+	err = f.SetInt(91)
+	if err == nil {
+		t.Error("setting a tot that isn't a multiple of 15 succeeded")
+	}
+}
+
+func TestFieldBool(t *testing.T) {
+	r := testChannel(t)
+
+	f := r.Field(FtCiRxOnly)
+	for _, on := range []bool{true, false} {
+		err := f.SetBool(on)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, err := f.Bool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != on {
+			t.Errorf("got %t, want %t", value, on)
+		}
+	}
+}
+
+func TestFieldEnumIndex(t *testing.T) {
+	r := testChannel(t)
+
+	f := r.Field(FtCiPower)
+	strs := f.Strings()
+	for i := range strs {
+		err := f.SetEnumIndex(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.String() != strs[i] {
+			t.Errorf("index %d set %s, want %s", i, f.String(), strs[i])
+		}
+		index, err := f.EnumIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if index != i {
+			t.Errorf("got index %d, want %d", index, i)
+		}
+	}
+
+	for _, index := range []int{-1, len(strs)} {
+		err := f.SetEnumIndex(index)
+		if err == nil {
+			t.Errorf("setting index %d succeeded", index)
+		}
+	}
+}
+
+func TestFieldRecordRef(t *testing.T) {
+	r := testChannel(t)
+	cp := r.Codeplug()
+
+	f := r.Field(FtCiContactName)
+	contact := cp.Records(RtContacts)[0]
+	err := f.SetRecordRef(contact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := f.RecordRef()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != contact {
+		t.Errorf("got reference to %v, want %s", ref, contact.Name())
+	}
+
+	err = f.SetRecordRef(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err = f.RecordRef()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref != nil {
+		t.Errorf("got reference to %s after clearing", ref.Name())
+	}
+
+	err = f.SetRecordRef(r)
+	if err == nil {
+		t.Error("referencing a channel from a contact field succeeded")
+	}
+
+	other := newTestCodeplug(t, "MD-380")
+	err = f.SetRecordRef(other.Records(RtContacts)[0])
+	if err == nil {
+		t.Error("referencing another codeplug's contact succeeded")
+	}
+}
+
+func TestFieldTypeMismatch(t *testing.T) {
+	r := testChannel(t)
+	name := r.Field(FtCiName)
+	freq := r.Field(FtCiRxFrequency)
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"Float64", func() error { _, err := name.Float64(); return err }},
+		{"SetFloat64", func() error { return name.SetFloat64(1) }},
+		{"Int", func() error { _, err := freq.Int(); return err }},
+		{"SetInt", func() error { return freq.SetInt(1) }},
+		{"Bool", func() error { _, err := freq.Bool(); return err }},
+		{"SetBool", func() error { return freq.SetBool(true) }},
+		{"EnumIndex", func() error { _, err := freq.EnumIndex(); return err }},
+		{"SetEnumIndex", func() error { return freq.SetEnumIndex(0) }},
+		{"RecordRef", func() error { _, err := freq.RecordRef(); return err }},
+		{"SetRecordRef", func() error { return freq.SetRecordRef(nil) }},
+	}
+
+	for _, test := range tests {
+		before := name.String() + freq.String()
+		err := test.fn()
+		if err == nil {
+			t.Errorf("%s: no error for a mismatched value type", test.name)
+		}
+		if after := name.String() + freq.String(); after != before {
+			t.Errorf("%s: mismatched value type changed a field", test.name)
+		}
+	}
+}
@@ -1098,7 +1098,7 @@ var riGeneralSettings_md2017 = recordInfo{
 	typeName: "General Settings",
 	max:      1,
 	offset:   8805,
-	size:     144,
+	size:     176,
 	fieldInfos: []*fieldInfo{
 		&fiGsRadioName,
 		&fiGsRadioID,
@@ -1235,7 +1235,7 @@ var riGeneralSettings_rt84 = recordInfo{
 	typeName: "General Settings",
 	max:      1,
 	offset:   8805,
-	size:     144,
+	size:     176,
 	fieldInfos: []*fieldInfo{
 		&fiGsRadioName,
 		&fiGsRadioID,
@@ -1282,7 +1282,7 @@ var riGeneralSettings_uv380 = recordInfo{
 	typeName: "General Settings",
 	max:      1,
 	offset:   8805,
-	size:     144,
+	size:     176,
 	fieldInfos: []*fieldInfo{
 		&fiGsRadioName,
 		&fiGsRadioID,
@@ -4641,4 +4641,4984 @@ var rTypeFieldRefs = map[RecordType][]fieldRef{
 	},
 }
 
+// CiAdmitCriteria enumerates the values of the Admit Criteria field.
+type CiAdmitCriteria string
+
+const (
+	CiAdmitCriteriaAlways      CiAdmitCriteria = "Always"
+	CiAdmitCriteriaChannelFree CiAdmitCriteria = "Channel free"
+	CiAdmitCriteriaCTCSS_DCS   CiAdmitCriteria = "CTCSS/DCS"
+	CiAdmitCriteriaColorCode   CiAdmitCriteria = "Color code"
+)
+
+// CiBandwidth enumerates the values of the Bandwidth (KHz) field.
+type CiBandwidth string
+
+const (
+	CiBandwidth12_5 CiBandwidth = "12.5"
+	CiBandwidth20   CiBandwidth = "20"
+	CiBandwidth25   CiBandwidth = "25"
+)
+
+// CiChannelMode enumerates the values of the Channel Mode field.
+type CiChannelMode string
+
+const (
+	CiChannelModeAnalog  CiChannelMode = "Analog"
+	CiChannelModeDigital CiChannelMode = "Digital"
+)
+
+// CiDQTTurnoffFreq enumerates the values of the Non-QT/DQT Turn-off Freq field.
+type CiDQTTurnoffFreq string
+
+const (
+	CiDQTTurnoffFreq259_2Hz CiDQTTurnoffFreq = "259.2 Hz"
+	CiDQTTurnoffFreq55_2Hz  CiDQTTurnoffFreq = "55.2 Hz"
+	CiDQTTurnoffFreqNone    CiDQTTurnoffFreq = "None"
+)
+
+// CiInCallCriteria enumerates the values of the In Call Criteria field.
+type CiInCallCriteria string
+
+const (
+	CiInCallCriteriaAlways              CiInCallCriteria = "Always"
+	CiInCallCriteriaFollowAdmitCriteria CiInCallCriteria = "Follow Admit Criteria"
+)
+
+// CiPower enumerates the values of the Power field.
+type CiPower string
+
+const (
+	CiPowerLow  CiPower = "Low"
+	CiPowerHigh CiPower = "High"
+)
+
+// CiPower_uv380 enumerates the values of the Power field.
+type CiPower_uv380 string
+
+const (
+	CiPower_uv380Low    CiPower_uv380 = "Low"
+	CiPower_uv380Medium CiPower_uv380 = "Medium"
+	CiPower_uv380High   CiPower_uv380 = "High"
+)
+
+// CiPrivacy enumerates the values of the Privacy field.
+type CiPrivacy string
+
+const (
+	CiPrivacyNone     CiPrivacy = "None"
+	CiPrivacyBasic    CiPrivacy = "Basic"
+	CiPrivacyEnhanced CiPrivacy = "Enhanced"
+)
+
+// CiQtReverse enumerates the values of the QT Reverse field.
+type CiQtReverse string
+
+const (
+	CiQtReverse180 CiQtReverse = "180"
+	CiQtReverse120 CiQtReverse = "120"
+)
+
+// CiRepeaterSlot enumerates the values of the Repeater Slot field.
+type CiRepeaterSlot string
+
+const (
+	CiRepeaterSlot1 CiRepeaterSlot = "1"
+	CiRepeaterSlot2 CiRepeaterSlot = "2"
+)
+
+// CiRxRefFrequency enumerates the values of the Rx Ref Frequency field.
+type CiRxRefFrequency string
+
+const (
+	CiRxRefFrequencyLow    CiRxRefFrequency = "Low"
+	CiRxRefFrequencyMedium CiRxRefFrequency = "Medium"
+	CiRxRefFrequencyHigh   CiRxRefFrequency = "High"
+)
+
+// CiRxSignallingSystem enumerates the values of the Rx Signaling System field.
+type CiRxSignallingSystem string
+
+const (
+	CiRxSignallingSystemOff    CiRxSignallingSystem = "Off"
+	CiRxSignallingSystemDTMF_1 CiRxSignallingSystem = "DTMF-1"
+	CiRxSignallingSystemDTMF_2 CiRxSignallingSystem = "DTMF-2"
+	CiRxSignallingSystemDTMF_3 CiRxSignallingSystem = "DTMF-3"
+	CiRxSignallingSystemDTMF_4 CiRxSignallingSystem = "DTMF-4"
+)
+
+// CiSquelch enumerates the values of the Squelch field.
+type CiSquelch string
+
+const (
+	CiSquelchTight  CiSquelch = "Tight"
+	CiSquelchNormal CiSquelch = "Normal"
+)
+
+// CiTxRefFrequency enumerates the values of the Tx Ref Frequency field.
+type CiTxRefFrequency string
+
+const (
+	CiTxRefFrequencyLow    CiTxRefFrequency = "Low"
+	CiTxRefFrequencyMedium CiTxRefFrequency = "Medium"
+	CiTxRefFrequencyHigh   CiTxRefFrequency = "High"
+)
+
+// CiTxSignallingSystem enumerates the values of the Tx Signaling System field.
+type CiTxSignallingSystem string
+
+const (
+	CiTxSignallingSystemOff    CiTxSignallingSystem = "Off"
+	CiTxSignallingSystemDTMF_1 CiTxSignallingSystem = "DTMF-1"
+	CiTxSignallingSystemDTMF_2 CiTxSignallingSystem = "DTMF-2"
+	CiTxSignallingSystemDTMF_3 CiTxSignallingSystem = "DTMF-3"
+	CiTxSignallingSystemDTMF_4 CiTxSignallingSystem = "DTMF-4"
+)
+
+// DcCallReceiveTone enumerates the values of the Call Receive Tone field.
+type DcCallReceiveTone string
+
+const (
+	DcCallReceiveToneNo  DcCallReceiveTone = "No"
+	DcCallReceiveToneYes DcCallReceiveTone = "Yes"
+)
+
+// DcCallType enumerates the values of the Call Type field.
+type DcCallType string
+
+const (
+	DcCallTypeGroup   DcCallType = "Group"
+	DcCallTypePrivate DcCallType = "Private"
+	DcCallTypeAll     DcCallType = "All"
+)
+
+// GsBacklightColor enumerates the values of the Backlight Color field.
+type GsBacklightColor string
+
+const (
+	GsBacklightColorOff    GsBacklightColor = "Off"
+	GsBacklightColorOrange GsBacklightColor = "Orange"
+	GsBacklightColorWhite  GsBacklightColor = "White"
+	GsBacklightColorSakura GsBacklightColor = "Sakura"
+)
+
+// GsBacklightTime enumerates the values of the Backlight Time (S) field.
+type GsBacklightTime string
+
+const (
+	GsBacklightTimeAlways GsBacklightTime = "Always"
+	GsBacklightTime5      GsBacklightTime = "5"
+	GsBacklightTime10     GsBacklightTime = "10"
+	GsBacklightTime15     GsBacklightTime = "15"
+)
+
+// GsFreqChannelMode enumerates the values of the Freq/Channel Mode field.
+type GsFreqChannelMode string
+
+const (
+	GsFreqChannelModeFrequency GsFreqChannelMode = "Frequency"
+	GsFreqChannelModeChannel   GsFreqChannelMode = "Channel"
+)
+
+// GsFreqChannelMode_uv380 enumerates the values of the Freq/Channel Mode field.
+type GsFreqChannelMode_uv380 string
+
+const (
+	GsFreqChannelMode_uv380Frequency GsFreqChannelMode_uv380 = "Frequency"
+	GsFreqChannelMode_uv380Channel   GsFreqChannelMode_uv380 = "Channel"
+)
+
+// GsIntroScreen enumerates the values of the Intro Screen field.
+type GsIntroScreen string
+
+const (
+	GsIntroScreenCharacterString GsIntroScreen = "Character String"
+	GsIntroScreenPicture         GsIntroScreen = "Picture"
+)
+
+// GsLockUnlock enumerates the values of the Lock/Unlock field.
+type GsLockUnlock string
+
+const (
+	GsLockUnlockUnlock GsLockUnlock = "Unlock"
+	GsLockUnlockLock   GsLockUnlock = "Lock"
+)
+
+// GsMicLevel enumerates the values of the MIC Level field.
+type GsMicLevel string
+
+const (
+	GsMicLevel1 GsMicLevel = "1"
+	GsMicLevel2 GsMicLevel = "2"
+	GsMicLevel3 GsMicLevel = "3"
+	GsMicLevel4 GsMicLevel = "4"
+	GsMicLevel5 GsMicLevel = "5"
+	GsMicLevel6 GsMicLevel = "6"
+)
+
+// GsModeSelect enumerates the values of the Mode Select field.
+type GsModeSelect string
+
+const (
+	GsModeSelectVFO    GsModeSelect = "VFO"
+	GsModeSelectMemory GsModeSelect = "Memory"
+)
+
+// GsModeSelectA enumerates the values of the Mode Select A field.
+type GsModeSelectA string
+
+const (
+	GsModeSelectAVFO    GsModeSelectA = "VFO"
+	GsModeSelectAMemory GsModeSelectA = "Memory"
+)
+
+// GsModeSelectB enumerates the values of the Mode Select B field.
+type GsModeSelectB string
+
+const (
+	GsModeSelectBVFO    GsModeSelectB = "VFO"
+	GsModeSelectBMemory GsModeSelectB = "Memory"
+)
+
+// GsMonitorType enumerates the values of the Monitor Type field.
+type GsMonitorType string
+
+const (
+	GsMonitorTypeSilent      GsMonitorType = "Silent"
+	GsMonitorTypeOpenSquelch GsMonitorType = "Open Squelch"
+)
+
+// GsSetKeypadLockTime enumerates the values of the Set Keypad Lock Time (S) field.
+type GsSetKeypadLockTime string
+
+const (
+	GsSetKeypadLockTime5      GsSetKeypadLockTime = "5"
+	GsSetKeypadLockTime10     GsSetKeypadLockTime = "10"
+	GsSetKeypadLockTime15     GsSetKeypadLockTime = "15"
+	GsSetKeypadLockTimeManual GsSetKeypadLockTime = "Manual"
+)
+
+// GsTalkPermitTone enumerates the values of the Talk Permit Tone field.
+type GsTalkPermitTone string
+
+const (
+	GsTalkPermitToneNone             GsTalkPermitTone = "None"
+	GsTalkPermitToneDigital          GsTalkPermitTone = "Digital"
+	GsTalkPermitToneAnalog           GsTalkPermitTone = "Analog"
+	GsTalkPermitToneDigitalAndAnalog GsTalkPermitTone = "Digital and Analog"
+)
+
+// GsTimeZone enumerates the values of the Time Zone field.
+type GsTimeZone string
+
+const (
+	GsTimeZoneUTCMinus12_00 GsTimeZone = "UTC-12:00"
+	GsTimeZoneUTCMinus11_00 GsTimeZone = "UTC-11:00"
+	GsTimeZoneUTCMinus10_00 GsTimeZone = "UTC-10:00"
+	GsTimeZoneUTCMinus9_00  GsTimeZone = "UTC-9:00"
+	GsTimeZoneUTCMinus8_00  GsTimeZone = "UTC-8:00"
+	GsTimeZoneUTCMinus7_00  GsTimeZone = "UTC-7:00"
+	GsTimeZoneUTCMinus6_00  GsTimeZone = "UTC-6:00"
+	GsTimeZoneUTCMinus5_00  GsTimeZone = "UTC-5:00"
+	GsTimeZoneUTCMinus4_00  GsTimeZone = "UTC-4:00"
+	GsTimeZoneUTCMinus3_00  GsTimeZone = "UTC-3:00"
+	GsTimeZoneUTCMinus2_00  GsTimeZone = "UTC-2:00"
+	GsTimeZoneUTCMinus1_00  GsTimeZone = "UTC-1:00"
+	GsTimeZoneUTCPlus0_00   GsTimeZone = "UTC+0:00"
+	GsTimeZoneUTCPlus1_00   GsTimeZone = "UTC+1:00"
+	GsTimeZoneUTCPlus2_00   GsTimeZone = "UTC+2:00"
+	GsTimeZoneUTCPlus3_00   GsTimeZone = "UTC+3:00"
+	GsTimeZoneUTCPlus4_00   GsTimeZone = "UTC+4:00"
+	GsTimeZoneUTCPlus5_00   GsTimeZone = "UTC+5:00"
+	GsTimeZoneUTCPlus6_00   GsTimeZone = "UTC+6:00"
+	GsTimeZoneUTCPlus7_00   GsTimeZone = "UTC+7:00"
+	GsTimeZoneUTCPlus8_00   GsTimeZone = "UTC+8:00"
+	GsTimeZoneUTCPlus9_00   GsTimeZone = "UTC+9:00"
+	GsTimeZoneUTCPlus10_00  GsTimeZone = "UTC+10:00"
+	GsTimeZoneUTCPlus11_00  GsTimeZone = "UTC+11:00"
+	GsTimeZoneUTCPlus12_00  GsTimeZone = "UTC+12:00"
+)
+
+// GsTxMode enumerates the values of the Tx Mode field.
+type GsTxMode string
+
+const (
+	GsTxModeLastCallCH             GsTxMode = "Last Call CH"
+	GsTxModeLastCallPlusHandCH     GsTxMode = "Last Call + Hand CH"
+	GsTxModeDesignatedCH           GsTxMode = "Designated CH"
+	GsTxModeDesignatedCHPlusHandCH GsTxMode = "Designated CH + Hand CH"
+)
+
+// Channel_md380 is a typed view of a Channels_md380 record.
+type Channel_md380 struct {
+	record *Record
+}
+
+// NewChannel_md380 returns a typed view of r, a Channels_md380 record.
+func NewChannel_md380(r *Record) *Channel_md380 {
+	if r == nil {
+		return nil
+	}
+	return &Channel_md380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Channel_md380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Channel Name field's value.
+func (v *Channel_md380) Name() string {
+	return viewString(v.record, FtCiName)
+}
+
+// SetName sets the Channel Name field's value.
+func (v *Channel_md380) SetName(s string) error {
+	return viewSetString(v.record, FtCiName, s)
+}
+
+// RxFrequency returns the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_md380) RxFrequency() float64 {
+	return viewFloat(v.record, FtCiRxFrequency)
+}
+
+// SetRxFrequency sets the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_md380) SetRxFrequency(mhz float64) error {
+	return viewSetFloat(v.record, FtCiRxFrequency, mhz)
+}
+
+// TxFrequencyOffset returns the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_md380) TxFrequencyOffset() float64 {
+	return viewFloat(v.record, FtCiTxFrequencyOffset)
+}
+
+// SetTxFrequencyOffset sets the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_md380) SetTxFrequencyOffset(mhz float64) error {
+	return viewSetFloat(v.record, FtCiTxFrequencyOffset, mhz)
+}
+
+// ChannelMode returns the Channel Mode field's value.
+func (v *Channel_md380) ChannelMode() CiChannelMode {
+	return CiChannelMode(viewString(v.record, FtCiChannelMode))
+}
+
+// SetChannelMode sets the Channel Mode field's value.
+func (v *Channel_md380) SetChannelMode(s CiChannelMode) error {
+	return viewSetString(v.record, FtCiChannelMode, string(s))
+}
+
+// Bandwidth returns the Bandwidth (KHz) field's value.
+func (v *Channel_md380) Bandwidth() CiBandwidth {
+	return CiBandwidth(viewString(v.record, FtCiBandwidth))
+}
+
+// SetBandwidth sets the Bandwidth (KHz) field's value.
+func (v *Channel_md380) SetBandwidth(s CiBandwidth) error {
+	return viewSetString(v.record, FtCiBandwidth, string(s))
+}
+
+// ScanList returns the record referenced by the Scan List field,
+// or nil if it references no record.
+func (v *Channel_md380) ScanList() *ScanList_md380 {
+	return NewScanList_md380(viewRecord(v.record, FtCiScanList_md380))
+}
+
+// SetScanList makes the Scan List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_md380) SetScanList(ref *ScanList_md380) error {
+	return viewSetRecord(v.record, FtCiScanList_md380, ref.Record())
+}
+
+// Squelch returns the Squelch field's value.
+func (v *Channel_md380) Squelch() CiSquelch {
+	return CiSquelch(viewString(v.record, FtCiSquelch))
+}
+
+// SetSquelch sets the Squelch field's value.
+func (v *Channel_md380) SetSquelch(s CiSquelch) error {
+	return viewSetString(v.record, FtCiSquelch, string(s))
+}
+
+// RxRefFrequency returns the Rx Ref Frequency field's value.
+func (v *Channel_md380) RxRefFrequency() CiRxRefFrequency {
+	return CiRxRefFrequency(viewString(v.record, FtCiRxRefFrequency))
+}
+
+// SetRxRefFrequency sets the Rx Ref Frequency field's value.
+func (v *Channel_md380) SetRxRefFrequency(s CiRxRefFrequency) error {
+	return viewSetString(v.record, FtCiRxRefFrequency, string(s))
+}
+
+// TxRefFrequency returns the Tx Ref Frequency field's value.
+func (v *Channel_md380) TxRefFrequency() CiTxRefFrequency {
+	return CiTxRefFrequency(viewString(v.record, FtCiTxRefFrequency))
+}
+
+// SetTxRefFrequency sets the Tx Ref Frequency field's value.
+func (v *Channel_md380) SetTxRefFrequency(s CiTxRefFrequency) error {
+	return viewSetString(v.record, FtCiTxRefFrequency, string(s))
+}
+
+// Tot returns the TOT (S) field's value.
+func (v *Channel_md380) Tot() int {
+	return viewInt(v.record, FtCiTot)
+}
+
+// SetTot sets the TOT (S) field's value.
+func (v *Channel_md380) SetTot(i int) error {
+	return viewSetInt(v.record, FtCiTot, i)
+}
+
+// TotRekeyDelay returns the TOT Rekey Delay (S) field's value.
+func (v *Channel_md380) TotRekeyDelay() int {
+	return viewInt(v.record, FtCiTotRekeyDelay)
+}
+
+// SetTotRekeyDelay sets the TOT Rekey Delay (S) field's value.
+func (v *Channel_md380) SetTotRekeyDelay(i int) error {
+	return viewSetInt(v.record, FtCiTotRekeyDelay, i)
+}
+
+// Power returns the Power field's value.
+func (v *Channel_md380) Power() CiPower {
+	return CiPower(viewString(v.record, FtCiPower))
+}
+
+// SetPower sets the Power field's value.
+func (v *Channel_md380) SetPower(s CiPower) error {
+	return viewSetString(v.record, FtCiPower, string(s))
+}
+
+// AdmitCriteria returns the Admit Criteria field's value.
+func (v *Channel_md380) AdmitCriteria() CiAdmitCriteria {
+	return CiAdmitCriteria(viewString(v.record, FtCiAdmitCriteria))
+}
+
+// SetAdmitCriteria sets the Admit Criteria field's value.
+func (v *Channel_md380) SetAdmitCriteria(s CiAdmitCriteria) error {
+	return viewSetString(v.record, FtCiAdmitCriteria, string(s))
+}
+
+// Autoscan reports whether the Autoscan field is on.
+func (v *Channel_md380) Autoscan() bool {
+	return viewBool(v.record, FtCiAutoscan)
+}
+
+// SetAutoscan turns the Autoscan field on or off.
+func (v *Channel_md380) SetAutoscan(on bool) error {
+	return viewSetBool(v.record, FtCiAutoscan, on)
+}
+
+// RxOnly reports whether the Rx Only field is on.
+func (v *Channel_md380) RxOnly() bool {
+	return viewBool(v.record, FtCiRxOnly)
+}
+
+// SetRxOnly turns the Rx Only field on or off.
+func (v *Channel_md380) SetRxOnly(on bool) error {
+	return viewSetBool(v.record, FtCiRxOnly, on)
+}
+
+// LoneWorker reports whether the Lone Worker field is on.
+func (v *Channel_md380) LoneWorker() bool {
+	return viewBool(v.record, FtCiLoneWorker)
+}
+
+// SetLoneWorker turns the Lone Worker field on or off.
+func (v *Channel_md380) SetLoneWorker(on bool) error {
+	return viewSetBool(v.record, FtCiLoneWorker, on)
+}
+
+// Vox reports whether the VOX field is on.
+func (v *Channel_md380) Vox() bool {
+	return viewBool(v.record, FtCiVox)
+}
+
+// SetVox turns the VOX field on or off.
+func (v *Channel_md380) SetVox(on bool) error {
+	return viewSetBool(v.record, FtCiVox, on)
+}
+
+// AllowTalkaround reports whether the Allow Talkaround field is on.
+func (v *Channel_md380) AllowTalkaround() bool {
+	return viewBool(v.record, FtCiAllowTalkaround)
+}
+
+// SetAllowTalkaround turns the Allow Talkaround field on or off.
+func (v *Channel_md380) SetAllowTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiAllowTalkaround, on)
+}
+
+// PrivateCallConfirmed reports whether the Private Call Confimed field is on.
+func (v *Channel_md380) PrivateCallConfirmed() bool {
+	return viewBool(v.record, FtCiPrivateCallConfirmed)
+}
+
+// SetPrivateCallConfirmed turns the Private Call Confimed field on or off.
+func (v *Channel_md380) SetPrivateCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiPrivateCallConfirmed, on)
+}
+
+// EmergencyAlarmAck reports whether the Emergency Alarm Ack field is on.
+func (v *Channel_md380) EmergencyAlarmAck() bool {
+	return viewBool(v.record, FtCiEmergencyAlarmAck)
+}
+
+// SetEmergencyAlarmAck turns the Emergency Alarm Ack field on or off.
+func (v *Channel_md380) SetEmergencyAlarmAck(on bool) error {
+	return viewSetBool(v.record, FtCiEmergencyAlarmAck, on)
+}
+
+// DataCallConfirmed reports whether the Data Call Confirmed field is on.
+func (v *Channel_md380) DataCallConfirmed() bool {
+	return viewBool(v.record, FtCiDataCallConfirmed)
+}
+
+// SetDataCallConfirmed turns the Data Call Confirmed field on or off.
+func (v *Channel_md380) SetDataCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiDataCallConfirmed, on)
+}
+
+// CompressedUdpDataHeader reports whether the Compressed UDP Data Header field is on.
+func (v *Channel_md380) CompressedUdpDataHeader() bool {
+	return viewBool(v.record, FtCiCompressedUdpDataHeader)
+}
+
+// SetCompressedUdpDataHeader turns the Compressed UDP Data Header field on or off.
+func (v *Channel_md380) SetCompressedUdpDataHeader(on bool) error {
+	return viewSetBool(v.record, FtCiCompressedUdpDataHeader, on)
+}
+
+// Talkaround reports whether the Talkaround field is on.
+func (v *Channel_md380) Talkaround() bool {
+	return viewBool(v.record, FtCiTalkaround)
+}
+
+// SetTalkaround turns the Talkaround field on or off.
+func (v *Channel_md380) SetTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiTalkaround, on)
+}
+
+// EmergencySystem returns the Emergency System field's value.
+func (v *Channel_md380) EmergencySystem() int {
+	return viewInt(v.record, FtCiEmergencySystem)
+}
+
+// SetEmergencySystem sets the Emergency System field's value.
+func (v *Channel_md380) SetEmergencySystem(i int) error {
+	return viewSetInt(v.record, FtCiEmergencySystem, i)
+}
+
+// ContactName returns the record referenced by the Contact Name field,
+// or nil if it references no record.
+func (v *Channel_md380) ContactName() *Contact {
+	return NewContact(viewRecord(v.record, FtCiContactName))
+}
+
+// SetContactName makes the Contact Name field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_md380) SetContactName(ref *Contact) error {
+	return viewSetRecord(v.record, FtCiContactName, ref.Record())
+}
+
+// GroupList returns the record referenced by the RX Group List field,
+// or nil if it references no record.
+func (v *Channel_md380) GroupList() *GroupList {
+	return NewGroupList(viewRecord(v.record, FtCiGroupList))
+}
+
+// SetGroupList makes the RX Group List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_md380) SetGroupList(ref *GroupList) error {
+	return viewSetRecord(v.record, FtCiGroupList, ref.Record())
+}
+
+// ColorCode returns the Color Code field's value.
+func (v *Channel_md380) ColorCode() int {
+	return viewInt(v.record, FtCiColorCode)
+}
+
+// SetColorCode sets the Color Code field's value.
+func (v *Channel_md380) SetColorCode(i int) error {
+	return viewSetInt(v.record, FtCiColorCode, i)
+}
+
+// RepeaterSlot returns the Repeater Slot field's value.
+func (v *Channel_md380) RepeaterSlot() CiRepeaterSlot {
+	return CiRepeaterSlot(viewString(v.record, FtCiRepeaterSlot))
+}
+
+// SetRepeaterSlot sets the Repeater Slot field's value.
+func (v *Channel_md380) SetRepeaterSlot(s CiRepeaterSlot) error {
+	return viewSetString(v.record, FtCiRepeaterSlot, string(s))
+}
+
+// Privacy returns the Privacy field's value.
+func (v *Channel_md380) Privacy() CiPrivacy {
+	return CiPrivacy(viewString(v.record, FtCiPrivacy))
+}
+
+// SetPrivacy sets the Privacy field's value.
+func (v *Channel_md380) SetPrivacy(s CiPrivacy) error {
+	return viewSetString(v.record, FtCiPrivacy, string(s))
+}
+
+// PrivacyNumber returns the Privacy Number field's value.
+func (v *Channel_md380) PrivacyNumber() int {
+	return viewInt(v.record, FtCiPrivacyNumber)
+}
+
+// SetPrivacyNumber sets the Privacy Number field's value.
+func (v *Channel_md380) SetPrivacyNumber(i int) error {
+	return viewSetInt(v.record, FtCiPrivacyNumber, i)
+}
+
+// DisplayPTTID reports whether the Display PTT ID field is on.
+func (v *Channel_md380) DisplayPTTID() bool {
+	return viewBool(v.record, FtCiDisplayPTTID)
+}
+
+// SetDisplayPTTID turns the Display PTT ID field on or off.
+func (v *Channel_md380) SetDisplayPTTID(on bool) error {
+	return viewSetBool(v.record, FtCiDisplayPTTID, on)
+}
+
+// CtcssEncode returns the CTCSS/DCS Encode field's value.
+func (v *Channel_md380) CtcssEncode() string {
+	return viewString(v.record, FtCiCtcssEncode)
+}
+
+// SetCtcssEncode sets the CTCSS/DCS Encode field's value.
+func (v *Channel_md380) SetCtcssEncode(s string) error {
+	return viewSetString(v.record, FtCiCtcssEncode, s)
+}
+
+// TxSignallingSystem returns the Tx Signaling System field's value.
+func (v *Channel_md380) TxSignallingSystem() CiTxSignallingSystem {
+	return CiTxSignallingSystem(viewString(v.record, FtCiTxSignallingSystem))
+}
+
+// SetTxSignallingSystem sets the Tx Signaling System field's value.
+func (v *Channel_md380) SetTxSignallingSystem(s CiTxSignallingSystem) error {
+	return viewSetString(v.record, FtCiTxSignallingSystem, string(s))
+}
+
+// QtReverse returns the QT Reverse field's value.
+func (v *Channel_md380) QtReverse() CiQtReverse {
+	return CiQtReverse(viewString(v.record, FtCiQtReverse))
+}
+
+// SetQtReverse sets the QT Reverse field's value.
+func (v *Channel_md380) SetQtReverse(s CiQtReverse) error {
+	return viewSetString(v.record, FtCiQtReverse, string(s))
+}
+
+// ReverseBurst reports whether the Reverse Burst/Turn Off Code field is on.
+func (v *Channel_md380) ReverseBurst() bool {
+	return viewBool(v.record, FtCiReverseBurst)
+}
+
+// SetReverseBurst turns the Reverse Burst/Turn Off Code field on or off.
+func (v *Channel_md380) SetReverseBurst(on bool) error {
+	return viewSetBool(v.record, FtCiReverseBurst, on)
+}
+
+// CtcssDecode returns the CTCSS/DCS Decode field's value.
+func (v *Channel_md380) CtcssDecode() string {
+	return viewString(v.record, FtCiCtcssDecode)
+}
+
+// SetCtcssDecode sets the CTCSS/DCS Decode field's value.
+func (v *Channel_md380) SetCtcssDecode(s string) error {
+	return viewSetString(v.record, FtCiCtcssDecode, s)
+}
+
+// RxSignallingSystem returns the Rx Signaling System field's value.
+func (v *Channel_md380) RxSignallingSystem() CiRxSignallingSystem {
+	return CiRxSignallingSystem(viewString(v.record, FtCiRxSignallingSystem))
+}
+
+// SetRxSignallingSystem sets the Rx Signaling System field's value.
+func (v *Channel_md380) SetRxSignallingSystem(s CiRxSignallingSystem) error {
+	return viewSetString(v.record, FtCiRxSignallingSystem, string(s))
+}
+
+// Decode1 reports whether the Decode 1 field is on.
+func (v *Channel_md380) Decode1() bool {
+	return viewBool(v.record, FtCiDecode1)
+}
+
+// SetDecode1 turns the Decode 1 field on or off.
+func (v *Channel_md380) SetDecode1(on bool) error {
+	return viewSetBool(v.record, FtCiDecode1, on)
+}
+
+// Decode2 reports whether the Decode 2 field is on.
+func (v *Channel_md380) Decode2() bool {
+	return viewBool(v.record, FtCiDecode2)
+}
+
+// SetDecode2 turns the Decode 2 field on or off.
+func (v *Channel_md380) SetDecode2(on bool) error {
+	return viewSetBool(v.record, FtCiDecode2, on)
+}
+
+// Decode3 reports whether the Decode 3 field is on.
+func (v *Channel_md380) Decode3() bool {
+	return viewBool(v.record, FtCiDecode3)
+}
+
+// SetDecode3 turns the Decode 3 field on or off.
+func (v *Channel_md380) SetDecode3(on bool) error {
+	return viewSetBool(v.record, FtCiDecode3, on)
+}
+
+// Decode4 reports whether the Decode 4 field is on.
+func (v *Channel_md380) Decode4() bool {
+	return viewBool(v.record, FtCiDecode4)
+}
+
+// SetDecode4 turns the Decode 4 field on or off.
+func (v *Channel_md380) SetDecode4(on bool) error {
+	return viewSetBool(v.record, FtCiDecode4, on)
+}
+
+// Decode5 reports whether the Decode 5 field is on.
+func (v *Channel_md380) Decode5() bool {
+	return viewBool(v.record, FtCiDecode5)
+}
+
+// SetDecode5 turns the Decode 5 field on or off.
+func (v *Channel_md380) SetDecode5(on bool) error {
+	return viewSetBool(v.record, FtCiDecode5, on)
+}
+
+// Decode6 reports whether the Decode 6 field is on.
+func (v *Channel_md380) Decode6() bool {
+	return viewBool(v.record, FtCiDecode6)
+}
+
+// SetDecode6 turns the Decode 6 field on or off.
+func (v *Channel_md380) SetDecode6(on bool) error {
+	return viewSetBool(v.record, FtCiDecode6, on)
+}
+
+// Decode7 reports whether the Decode 7 field is on.
+func (v *Channel_md380) Decode7() bool {
+	return viewBool(v.record, FtCiDecode7)
+}
+
+// SetDecode7 turns the Decode 7 field on or off.
+func (v *Channel_md380) SetDecode7(on bool) error {
+	return viewSetBool(v.record, FtCiDecode7, on)
+}
+
+// Decode8 reports whether the Decode 8 field is on.
+func (v *Channel_md380) Decode8() bool {
+	return viewBool(v.record, FtCiDecode8)
+}
+
+// SetDecode8 turns the Decode 8 field on or off.
+func (v *Channel_md380) SetDecode8(on bool) error {
+	return viewSetBool(v.record, FtCiDecode8, on)
+}
+
+// ReceiveGPSInfo reports whether the Receive GPS Info field is on.
+func (v *Channel_md380) ReceiveGPSInfo() bool {
+	return viewBool(v.record, FtCiReceiveGPSInfo)
+}
+
+// SetReceiveGPSInfo turns the Receive GPS Info field on or off.
+func (v *Channel_md380) SetReceiveGPSInfo(on bool) error {
+	return viewSetBool(v.record, FtCiReceiveGPSInfo, on)
+}
+
+// SendGPSInfo reports whether the Send GPS Info field is on.
+func (v *Channel_md380) SendGPSInfo() bool {
+	return viewBool(v.record, FtCiSendGPSInfo)
+}
+
+// SetSendGPSInfo turns the Send GPS Info field on or off.
+func (v *Channel_md380) SetSendGPSInfo(on bool) error {
+	return viewSetBool(v.record, FtCiSendGPSInfo, on)
+}
+
+// GPSSystem returns the GPS System field's value.
+func (v *Channel_md380) GPSSystem() int {
+	return viewInt(v.record, FtCiGPSSystem)
+}
+
+// SetGPSSystem sets the GPS System field's value.
+func (v *Channel_md380) SetGPSSystem(i int) error {
+	return viewSetInt(v.record, FtCiGPSSystem, i)
+}
+
+// InCallCriteria returns the In Call Criteria field's value.
+func (v *Channel_md380) InCallCriteria() CiInCallCriteria {
+	return CiInCallCriteria(viewString(v.record, FtCiInCallCriteria))
+}
+
+// SetInCallCriteria sets the In Call Criteria field's value.
+func (v *Channel_md380) SetInCallCriteria(s CiInCallCriteria) error {
+	return viewSetString(v.record, FtCiInCallCriteria, string(s))
+}
+
+// Channel_md40 is a typed view of a Channels_md40 record.
+type Channel_md40 struct {
+	record *Record
+}
+
+// NewChannel_md40 returns a typed view of r, a Channels_md40 record.
+func NewChannel_md40(r *Record) *Channel_md40 {
+	if r == nil {
+		return nil
+	}
+	return &Channel_md40{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Channel_md40) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Channel Name field's value.
+func (v *Channel_md40) Name() string {
+	return viewString(v.record, FtCiName)
+}
+
+// SetName sets the Channel Name field's value.
+func (v *Channel_md40) SetName(s string) error {
+	return viewSetString(v.record, FtCiName, s)
+}
+
+// RxFrequency returns the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_md40) RxFrequency() float64 {
+	return viewFloat(v.record, FtCiRxFrequency)
+}
+
+// SetRxFrequency sets the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_md40) SetRxFrequency(mhz float64) error {
+	return viewSetFloat(v.record, FtCiRxFrequency, mhz)
+}
+
+// TxFrequencyOffset returns the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_md40) TxFrequencyOffset() float64 {
+	return viewFloat(v.record, FtCiTxFrequencyOffset)
+}
+
+// SetTxFrequencyOffset sets the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_md40) SetTxFrequencyOffset(mhz float64) error {
+	return viewSetFloat(v.record, FtCiTxFrequencyOffset, mhz)
+}
+
+// ChannelMode returns the Channel Mode field's value.
+func (v *Channel_md40) ChannelMode() CiChannelMode {
+	return CiChannelMode(viewString(v.record, FtCiChannelMode))
+}
+
+// SetChannelMode sets the Channel Mode field's value.
+func (v *Channel_md40) SetChannelMode(s CiChannelMode) error {
+	return viewSetString(v.record, FtCiChannelMode, string(s))
+}
+
+// Bandwidth returns the Bandwidth (KHz) field's value.
+func (v *Channel_md40) Bandwidth() CiBandwidth {
+	return CiBandwidth(viewString(v.record, FtCiBandwidth))
+}
+
+// SetBandwidth sets the Bandwidth (KHz) field's value.
+func (v *Channel_md40) SetBandwidth(s CiBandwidth) error {
+	return viewSetString(v.record, FtCiBandwidth, string(s))
+}
+
+// ScanList returns the record referenced by the Scan List field,
+// or nil if it references no record.
+func (v *Channel_md40) ScanList() *ScanList_md40 {
+	return NewScanList_md40(viewRecord(v.record, FtCiScanList_md40))
+}
+
+// SetScanList makes the Scan List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_md40) SetScanList(ref *ScanList_md40) error {
+	return viewSetRecord(v.record, FtCiScanList_md40, ref.Record())
+}
+
+// Squelch returns the Squelch field's value.
+func (v *Channel_md40) Squelch() CiSquelch {
+	return CiSquelch(viewString(v.record, FtCiSquelch))
+}
+
+// SetSquelch sets the Squelch field's value.
+func (v *Channel_md40) SetSquelch(s CiSquelch) error {
+	return viewSetString(v.record, FtCiSquelch, string(s))
+}
+
+// RxRefFrequency returns the Rx Ref Frequency field's value.
+func (v *Channel_md40) RxRefFrequency() CiRxRefFrequency {
+	return CiRxRefFrequency(viewString(v.record, FtCiRxRefFrequency))
+}
+
+// SetRxRefFrequency sets the Rx Ref Frequency field's value.
+func (v *Channel_md40) SetRxRefFrequency(s CiRxRefFrequency) error {
+	return viewSetString(v.record, FtCiRxRefFrequency, string(s))
+}
+
+// TxRefFrequency returns the Tx Ref Frequency field's value.
+func (v *Channel_md40) TxRefFrequency() CiTxRefFrequency {
+	return CiTxRefFrequency(viewString(v.record, FtCiTxRefFrequency))
+}
+
+// SetTxRefFrequency sets the Tx Ref Frequency field's value.
+func (v *Channel_md40) SetTxRefFrequency(s CiTxRefFrequency) error {
+	return viewSetString(v.record, FtCiTxRefFrequency, string(s))
+}
+
+// Tot returns the TOT (S) field's value.
+func (v *Channel_md40) Tot() int {
+	return viewInt(v.record, FtCiTot)
+}
+
+// SetTot sets the TOT (S) field's value.
+func (v *Channel_md40) SetTot(i int) error {
+	return viewSetInt(v.record, FtCiTot, i)
+}
+
+// TotRekeyDelay returns the TOT Rekey Delay (S) field's value.
+func (v *Channel_md40) TotRekeyDelay() int {
+	return viewInt(v.record, FtCiTotRekeyDelay)
+}
+
+// SetTotRekeyDelay sets the TOT Rekey Delay (S) field's value.
+func (v *Channel_md40) SetTotRekeyDelay(i int) error {
+	return viewSetInt(v.record, FtCiTotRekeyDelay, i)
+}
+
+// Power returns the Power field's value.
+func (v *Channel_md40) Power() CiPower {
+	return CiPower(viewString(v.record, FtCiPower))
+}
+
+// SetPower sets the Power field's value.
+func (v *Channel_md40) SetPower(s CiPower) error {
+	return viewSetString(v.record, FtCiPower, string(s))
+}
+
+// AdmitCriteria returns the Admit Criteria field's value.
+func (v *Channel_md40) AdmitCriteria() CiAdmitCriteria {
+	return CiAdmitCriteria(viewString(v.record, FtCiAdmitCriteria))
+}
+
+// SetAdmitCriteria sets the Admit Criteria field's value.
+func (v *Channel_md40) SetAdmitCriteria(s CiAdmitCriteria) error {
+	return viewSetString(v.record, FtCiAdmitCriteria, string(s))
+}
+
+// Autoscan reports whether the Autoscan field is on.
+func (v *Channel_md40) Autoscan() bool {
+	return viewBool(v.record, FtCiAutoscan)
+}
+
+// SetAutoscan turns the Autoscan field on or off.
+func (v *Channel_md40) SetAutoscan(on bool) error {
+	return viewSetBool(v.record, FtCiAutoscan, on)
+}
+
+// RxOnly reports whether the Rx Only field is on.
+func (v *Channel_md40) RxOnly() bool {
+	return viewBool(v.record, FtCiRxOnly)
+}
+
+// SetRxOnly turns the Rx Only field on or off.
+func (v *Channel_md40) SetRxOnly(on bool) error {
+	return viewSetBool(v.record, FtCiRxOnly, on)
+}
+
+// LoneWorker reports whether the Lone Worker field is on.
+func (v *Channel_md40) LoneWorker() bool {
+	return viewBool(v.record, FtCiLoneWorker)
+}
+
+// SetLoneWorker turns the Lone Worker field on or off.
+func (v *Channel_md40) SetLoneWorker(on bool) error {
+	return viewSetBool(v.record, FtCiLoneWorker, on)
+}
+
+// Vox reports whether the VOX field is on.
+func (v *Channel_md40) Vox() bool {
+	return viewBool(v.record, FtCiVox)
+}
+
+// SetVox turns the VOX field on or off.
+func (v *Channel_md40) SetVox(on bool) error {
+	return viewSetBool(v.record, FtCiVox, on)
+}
+
+// AllowTalkaround reports whether the Allow Talkaround field is on.
+func (v *Channel_md40) AllowTalkaround() bool {
+	return viewBool(v.record, FtCiAllowTalkaround)
+}
+
+// SetAllowTalkaround turns the Allow Talkaround field on or off.
+func (v *Channel_md40) SetAllowTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiAllowTalkaround, on)
+}
+
+// PrivateCallConfirmed reports whether the Private Call Confimed field is on.
+func (v *Channel_md40) PrivateCallConfirmed() bool {
+	return viewBool(v.record, FtCiPrivateCallConfirmed)
+}
+
+// SetPrivateCallConfirmed turns the Private Call Confimed field on or off.
+func (v *Channel_md40) SetPrivateCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiPrivateCallConfirmed, on)
+}
+
+// EmergencyAlarmAck reports whether the Emergency Alarm Ack field is on.
+func (v *Channel_md40) EmergencyAlarmAck() bool {
+	return viewBool(v.record, FtCiEmergencyAlarmAck)
+}
+
+// SetEmergencyAlarmAck turns the Emergency Alarm Ack field on or off.
+func (v *Channel_md40) SetEmergencyAlarmAck(on bool) error {
+	return viewSetBool(v.record, FtCiEmergencyAlarmAck, on)
+}
+
+// DataCallConfirmed reports whether the Data Call Confirmed field is on.
+func (v *Channel_md40) DataCallConfirmed() bool {
+	return viewBool(v.record, FtCiDataCallConfirmed)
+}
+
+// SetDataCallConfirmed turns the Data Call Confirmed field on or off.
+func (v *Channel_md40) SetDataCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiDataCallConfirmed, on)
+}
+
+// CompressedUdpDataHeader reports whether the Compressed UDP Data Header field is on.
+func (v *Channel_md40) CompressedUdpDataHeader() bool {
+	return viewBool(v.record, FtCiCompressedUdpDataHeader)
+}
+
+// SetCompressedUdpDataHeader turns the Compressed UDP Data Header field on or off.
+func (v *Channel_md40) SetCompressedUdpDataHeader(on bool) error {
+	return viewSetBool(v.record, FtCiCompressedUdpDataHeader, on)
+}
+
+// Talkaround reports whether the Talkaround field is on.
+func (v *Channel_md40) Talkaround() bool {
+	return viewBool(v.record, FtCiTalkaround)
+}
+
+// SetTalkaround turns the Talkaround field on or off.
+func (v *Channel_md40) SetTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiTalkaround, on)
+}
+
+// EmergencySystem returns the Emergency System field's value.
+func (v *Channel_md40) EmergencySystem() int {
+	return viewInt(v.record, FtCiEmergencySystem)
+}
+
+// SetEmergencySystem sets the Emergency System field's value.
+func (v *Channel_md40) SetEmergencySystem(i int) error {
+	return viewSetInt(v.record, FtCiEmergencySystem, i)
+}
+
+// ContactName returns the record referenced by the Contact Name field,
+// or nil if it references no record.
+func (v *Channel_md40) ContactName() *Contact {
+	return NewContact(viewRecord(v.record, FtCiContactName))
+}
+
+// SetContactName makes the Contact Name field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_md40) SetContactName(ref *Contact) error {
+	return viewSetRecord(v.record, FtCiContactName, ref.Record())
+}
+
+// GroupList returns the record referenced by the RX Group List field,
+// or nil if it references no record.
+func (v *Channel_md40) GroupList() *GroupList {
+	return NewGroupList(viewRecord(v.record, FtCiGroupList))
+}
+
+// SetGroupList makes the RX Group List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_md40) SetGroupList(ref *GroupList) error {
+	return viewSetRecord(v.record, FtCiGroupList, ref.Record())
+}
+
+// ColorCode returns the Color Code field's value.
+func (v *Channel_md40) ColorCode() int {
+	return viewInt(v.record, FtCiColorCode)
+}
+
+// SetColorCode sets the Color Code field's value.
+func (v *Channel_md40) SetColorCode(i int) error {
+	return viewSetInt(v.record, FtCiColorCode, i)
+}
+
+// RepeaterSlot returns the Repeater Slot field's value.
+func (v *Channel_md40) RepeaterSlot() CiRepeaterSlot {
+	return CiRepeaterSlot(viewString(v.record, FtCiRepeaterSlot))
+}
+
+// SetRepeaterSlot sets the Repeater Slot field's value.
+func (v *Channel_md40) SetRepeaterSlot(s CiRepeaterSlot) error {
+	return viewSetString(v.record, FtCiRepeaterSlot, string(s))
+}
+
+// Privacy returns the Privacy field's value.
+func (v *Channel_md40) Privacy() CiPrivacy {
+	return CiPrivacy(viewString(v.record, FtCiPrivacy))
+}
+
+// SetPrivacy sets the Privacy field's value.
+func (v *Channel_md40) SetPrivacy(s CiPrivacy) error {
+	return viewSetString(v.record, FtCiPrivacy, string(s))
+}
+
+// PrivacyNumber returns the Privacy Number field's value.
+func (v *Channel_md40) PrivacyNumber() int {
+	return viewInt(v.record, FtCiPrivacyNumber)
+}
+
+// SetPrivacyNumber sets the Privacy Number field's value.
+func (v *Channel_md40) SetPrivacyNumber(i int) error {
+	return viewSetInt(v.record, FtCiPrivacyNumber, i)
+}
+
+// DisplayPTTID reports whether the Display PTT ID field is on.
+func (v *Channel_md40) DisplayPTTID() bool {
+	return viewBool(v.record, FtCiDisplayPTTID)
+}
+
+// SetDisplayPTTID turns the Display PTT ID field on or off.
+func (v *Channel_md40) SetDisplayPTTID(on bool) error {
+	return viewSetBool(v.record, FtCiDisplayPTTID, on)
+}
+
+// CtcssEncode returns the CTCSS/DCS Encode field's value.
+func (v *Channel_md40) CtcssEncode() string {
+	return viewString(v.record, FtCiCtcssEncode)
+}
+
+// SetCtcssEncode sets the CTCSS/DCS Encode field's value.
+func (v *Channel_md40) SetCtcssEncode(s string) error {
+	return viewSetString(v.record, FtCiCtcssEncode, s)
+}
+
+// TxSignallingSystem returns the Tx Signaling System field's value.
+func (v *Channel_md40) TxSignallingSystem() CiTxSignallingSystem {
+	return CiTxSignallingSystem(viewString(v.record, FtCiTxSignallingSystem))
+}
+
+// SetTxSignallingSystem sets the Tx Signaling System field's value.
+func (v *Channel_md40) SetTxSignallingSystem(s CiTxSignallingSystem) error {
+	return viewSetString(v.record, FtCiTxSignallingSystem, string(s))
+}
+
+// QtReverse returns the QT Reverse field's value.
+func (v *Channel_md40) QtReverse() CiQtReverse {
+	return CiQtReverse(viewString(v.record, FtCiQtReverse))
+}
+
+// SetQtReverse sets the QT Reverse field's value.
+func (v *Channel_md40) SetQtReverse(s CiQtReverse) error {
+	return viewSetString(v.record, FtCiQtReverse, string(s))
+}
+
+// ReverseBurst reports whether the Reverse Burst/Turn Off Code field is on.
+func (v *Channel_md40) ReverseBurst() bool {
+	return viewBool(v.record, FtCiReverseBurst)
+}
+
+// SetReverseBurst turns the Reverse Burst/Turn Off Code field on or off.
+func (v *Channel_md40) SetReverseBurst(on bool) error {
+	return viewSetBool(v.record, FtCiReverseBurst, on)
+}
+
+// CtcssDecode returns the CTCSS/DCS Decode field's value.
+func (v *Channel_md40) CtcssDecode() string {
+	return viewString(v.record, FtCiCtcssDecode)
+}
+
+// SetCtcssDecode sets the CTCSS/DCS Decode field's value.
+func (v *Channel_md40) SetCtcssDecode(s string) error {
+	return viewSetString(v.record, FtCiCtcssDecode, s)
+}
+
+// RxSignallingSystem returns the Rx Signaling System field's value.
+func (v *Channel_md40) RxSignallingSystem() CiRxSignallingSystem {
+	return CiRxSignallingSystem(viewString(v.record, FtCiRxSignallingSystem))
+}
+
+// SetRxSignallingSystem sets the Rx Signaling System field's value.
+func (v *Channel_md40) SetRxSignallingSystem(s CiRxSignallingSystem) error {
+	return viewSetString(v.record, FtCiRxSignallingSystem, string(s))
+}
+
+// Decode1 reports whether the Decode 1 field is on.
+func (v *Channel_md40) Decode1() bool {
+	return viewBool(v.record, FtCiDecode1)
+}
+
+// SetDecode1 turns the Decode 1 field on or off.
+func (v *Channel_md40) SetDecode1(on bool) error {
+	return viewSetBool(v.record, FtCiDecode1, on)
+}
+
+// Decode2 reports whether the Decode 2 field is on.
+func (v *Channel_md40) Decode2() bool {
+	return viewBool(v.record, FtCiDecode2)
+}
+
+// SetDecode2 turns the Decode 2 field on or off.
+func (v *Channel_md40) SetDecode2(on bool) error {
+	return viewSetBool(v.record, FtCiDecode2, on)
+}
+
+// Decode3 reports whether the Decode 3 field is on.
+func (v *Channel_md40) Decode3() bool {
+	return viewBool(v.record, FtCiDecode3)
+}
+
+// SetDecode3 turns the Decode 3 field on or off.
+func (v *Channel_md40) SetDecode3(on bool) error {
+	return viewSetBool(v.record, FtCiDecode3, on)
+}
+
+// Decode4 reports whether the Decode 4 field is on.
+func (v *Channel_md40) Decode4() bool {
+	return viewBool(v.record, FtCiDecode4)
+}
+
+// SetDecode4 turns the Decode 4 field on or off.
+func (v *Channel_md40) SetDecode4(on bool) error {
+	return viewSetBool(v.record, FtCiDecode4, on)
+}
+
+// Decode5 reports whether the Decode 5 field is on.
+func (v *Channel_md40) Decode5() bool {
+	return viewBool(v.record, FtCiDecode5)
+}
+
+// SetDecode5 turns the Decode 5 field on or off.
+func (v *Channel_md40) SetDecode5(on bool) error {
+	return viewSetBool(v.record, FtCiDecode5, on)
+}
+
+// Decode6 reports whether the Decode 6 field is on.
+func (v *Channel_md40) Decode6() bool {
+	return viewBool(v.record, FtCiDecode6)
+}
+
+// SetDecode6 turns the Decode 6 field on or off.
+func (v *Channel_md40) SetDecode6(on bool) error {
+	return viewSetBool(v.record, FtCiDecode6, on)
+}
+
+// Decode7 reports whether the Decode 7 field is on.
+func (v *Channel_md40) Decode7() bool {
+	return viewBool(v.record, FtCiDecode7)
+}
+
+// SetDecode7 turns the Decode 7 field on or off.
+func (v *Channel_md40) SetDecode7(on bool) error {
+	return viewSetBool(v.record, FtCiDecode7, on)
+}
+
+// Decode8 reports whether the Decode 8 field is on.
+func (v *Channel_md40) Decode8() bool {
+	return viewBool(v.record, FtCiDecode8)
+}
+
+// SetDecode8 turns the Decode 8 field on or off.
+func (v *Channel_md40) SetDecode8(on bool) error {
+	return viewSetBool(v.record, FtCiDecode8, on)
+}
+
+// Channel_rt84 is a typed view of a Channels_rt84 record.
+type Channel_rt84 struct {
+	record *Record
+}
+
+// NewChannel_rt84 returns a typed view of r, a Channels_rt84 record.
+func NewChannel_rt84(r *Record) *Channel_rt84 {
+	if r == nil {
+		return nil
+	}
+	return &Channel_rt84{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Channel_rt84) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Channel Name field's value.
+func (v *Channel_rt84) Name() string {
+	return viewString(v.record, FtCiName)
+}
+
+// SetName sets the Channel Name field's value.
+func (v *Channel_rt84) SetName(s string) error {
+	return viewSetString(v.record, FtCiName, s)
+}
+
+// RxFrequency returns the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_rt84) RxFrequency() float64 {
+	return viewFloat(v.record, FtCiRxFrequency)
+}
+
+// SetRxFrequency sets the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_rt84) SetRxFrequency(mhz float64) error {
+	return viewSetFloat(v.record, FtCiRxFrequency, mhz)
+}
+
+// TxFrequencyOffset returns the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_rt84) TxFrequencyOffset() float64 {
+	return viewFloat(v.record, FtCiTxFrequencyOffset)
+}
+
+// SetTxFrequencyOffset sets the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_rt84) SetTxFrequencyOffset(mhz float64) error {
+	return viewSetFloat(v.record, FtCiTxFrequencyOffset, mhz)
+}
+
+// ChannelMode returns the Channel Mode field's value.
+func (v *Channel_rt84) ChannelMode() CiChannelMode {
+	return CiChannelMode(viewString(v.record, FtCiChannelMode))
+}
+
+// SetChannelMode sets the Channel Mode field's value.
+func (v *Channel_rt84) SetChannelMode(s CiChannelMode) error {
+	return viewSetString(v.record, FtCiChannelMode, string(s))
+}
+
+// Bandwidth returns the Bandwidth (KHz) field's value.
+func (v *Channel_rt84) Bandwidth() CiBandwidth {
+	return CiBandwidth(viewString(v.record, FtCiBandwidth))
+}
+
+// SetBandwidth sets the Bandwidth (KHz) field's value.
+func (v *Channel_rt84) SetBandwidth(s CiBandwidth) error {
+	return viewSetString(v.record, FtCiBandwidth, string(s))
+}
+
+// ScanList returns the record referenced by the Scan List field,
+// or nil if it references no record.
+func (v *Channel_rt84) ScanList() *ScanList_md40 {
+	return NewScanList_md40(viewRecord(v.record, FtCiScanList_md40))
+}
+
+// SetScanList makes the Scan List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_rt84) SetScanList(ref *ScanList_md40) error {
+	return viewSetRecord(v.record, FtCiScanList_md40, ref.Record())
+}
+
+// Squelch returns the Squelch field's value.
+func (v *Channel_rt84) Squelch() CiSquelch {
+	return CiSquelch(viewString(v.record, FtCiSquelch))
+}
+
+// SetSquelch sets the Squelch field's value.
+func (v *Channel_rt84) SetSquelch(s CiSquelch) error {
+	return viewSetString(v.record, FtCiSquelch, string(s))
+}
+
+// RxRefFrequency returns the Rx Ref Frequency field's value.
+func (v *Channel_rt84) RxRefFrequency() CiRxRefFrequency {
+	return CiRxRefFrequency(viewString(v.record, FtCiRxRefFrequency))
+}
+
+// SetRxRefFrequency sets the Rx Ref Frequency field's value.
+func (v *Channel_rt84) SetRxRefFrequency(s CiRxRefFrequency) error {
+	return viewSetString(v.record, FtCiRxRefFrequency, string(s))
+}
+
+// TxRefFrequency returns the Tx Ref Frequency field's value.
+func (v *Channel_rt84) TxRefFrequency() CiTxRefFrequency {
+	return CiTxRefFrequency(viewString(v.record, FtCiTxRefFrequency))
+}
+
+// SetTxRefFrequency sets the Tx Ref Frequency field's value.
+func (v *Channel_rt84) SetTxRefFrequency(s CiTxRefFrequency) error {
+	return viewSetString(v.record, FtCiTxRefFrequency, string(s))
+}
+
+// Tot returns the TOT (S) field's value.
+func (v *Channel_rt84) Tot() int {
+	return viewInt(v.record, FtCiTot)
+}
+
+// SetTot sets the TOT (S) field's value.
+func (v *Channel_rt84) SetTot(i int) error {
+	return viewSetInt(v.record, FtCiTot, i)
+}
+
+// TotRekeyDelay returns the TOT Rekey Delay (S) field's value.
+func (v *Channel_rt84) TotRekeyDelay() int {
+	return viewInt(v.record, FtCiTotRekeyDelay)
+}
+
+// SetTotRekeyDelay sets the TOT Rekey Delay (S) field's value.
+func (v *Channel_rt84) SetTotRekeyDelay(i int) error {
+	return viewSetInt(v.record, FtCiTotRekeyDelay, i)
+}
+
+// Power returns the Power field's value.
+func (v *Channel_rt84) Power() CiPower_uv380 {
+	return CiPower_uv380(viewString(v.record, FtCiPower_uv380))
+}
+
+// SetPower sets the Power field's value.
+func (v *Channel_rt84) SetPower(s CiPower_uv380) error {
+	return viewSetString(v.record, FtCiPower_uv380, string(s))
+}
+
+// AdmitCriteria returns the Admit Criteria field's value.
+func (v *Channel_rt84) AdmitCriteria() CiAdmitCriteria {
+	return CiAdmitCriteria(viewString(v.record, FtCiAdmitCriteria))
+}
+
+// SetAdmitCriteria sets the Admit Criteria field's value.
+func (v *Channel_rt84) SetAdmitCriteria(s CiAdmitCriteria) error {
+	return viewSetString(v.record, FtCiAdmitCriteria, string(s))
+}
+
+// Autoscan reports whether the Autoscan field is on.
+func (v *Channel_rt84) Autoscan() bool {
+	return viewBool(v.record, FtCiAutoscan)
+}
+
+// SetAutoscan turns the Autoscan field on or off.
+func (v *Channel_rt84) SetAutoscan(on bool) error {
+	return viewSetBool(v.record, FtCiAutoscan, on)
+}
+
+// RxOnly reports whether the Rx Only field is on.
+func (v *Channel_rt84) RxOnly() bool {
+	return viewBool(v.record, FtCiRxOnly)
+}
+
+// SetRxOnly turns the Rx Only field on or off.
+func (v *Channel_rt84) SetRxOnly(on bool) error {
+	return viewSetBool(v.record, FtCiRxOnly, on)
+}
+
+// LoneWorker reports whether the Lone Worker field is on.
+func (v *Channel_rt84) LoneWorker() bool {
+	return viewBool(v.record, FtCiLoneWorker)
+}
+
+// SetLoneWorker turns the Lone Worker field on or off.
+func (v *Channel_rt84) SetLoneWorker(on bool) error {
+	return viewSetBool(v.record, FtCiLoneWorker, on)
+}
+
+// Vox reports whether the VOX field is on.
+func (v *Channel_rt84) Vox() bool {
+	return viewBool(v.record, FtCiVox)
+}
+
+// SetVox turns the VOX field on or off.
+func (v *Channel_rt84) SetVox(on bool) error {
+	return viewSetBool(v.record, FtCiVox, on)
+}
+
+// AllowTalkaround reports whether the Allow Talkaround field is on.
+func (v *Channel_rt84) AllowTalkaround() bool {
+	return viewBool(v.record, FtCiAllowTalkaround)
+}
+
+// SetAllowTalkaround turns the Allow Talkaround field on or off.
+func (v *Channel_rt84) SetAllowTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiAllowTalkaround, on)
+}
+
+// PrivateCallConfirmed reports whether the Private Call Confimed field is on.
+func (v *Channel_rt84) PrivateCallConfirmed() bool {
+	return viewBool(v.record, FtCiPrivateCallConfirmed)
+}
+
+// SetPrivateCallConfirmed turns the Private Call Confimed field on or off.
+func (v *Channel_rt84) SetPrivateCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiPrivateCallConfirmed, on)
+}
+
+// Talkaround reports whether the Talkaround field is on.
+func (v *Channel_rt84) Talkaround() bool {
+	return viewBool(v.record, FtCiTalkaround)
+}
+
+// SetTalkaround turns the Talkaround field on or off.
+func (v *Channel_rt84) SetTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiTalkaround, on)
+}
+
+// EmergencyAlarmAck reports whether the Emergency Alarm Ack field is on.
+func (v *Channel_rt84) EmergencyAlarmAck() bool {
+	return viewBool(v.record, FtCiEmergencyAlarmAck)
+}
+
+// SetEmergencyAlarmAck turns the Emergency Alarm Ack field on or off.
+func (v *Channel_rt84) SetEmergencyAlarmAck(on bool) error {
+	return viewSetBool(v.record, FtCiEmergencyAlarmAck, on)
+}
+
+// DataCallConfirmed reports whether the Data Call Confirmed field is on.
+func (v *Channel_rt84) DataCallConfirmed() bool {
+	return viewBool(v.record, FtCiDataCallConfirmed)
+}
+
+// SetDataCallConfirmed turns the Data Call Confirmed field on or off.
+func (v *Channel_rt84) SetDataCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiDataCallConfirmed, on)
+}
+
+// AllowInterrupt reports whether the Allow Interrupt field is on.
+func (v *Channel_rt84) AllowInterrupt() bool {
+	return viewBool(v.record, FtCiAllowInterrupt)
+}
+
+// SetAllowInterrupt turns the Allow Interrupt field on or off.
+func (v *Channel_rt84) SetAllowInterrupt(on bool) error {
+	return viewSetBool(v.record, FtCiAllowInterrupt, on)
+}
+
+// DCDMSwitch reports whether the DCDM Switch field is on.
+func (v *Channel_rt84) DCDMSwitch() bool {
+	return viewBool(v.record, FtCiDCDMSwitch)
+}
+
+// SetDCDMSwitch turns the DCDM Switch field on or off.
+func (v *Channel_rt84) SetDCDMSwitch(on bool) error {
+	return viewSetBool(v.record, FtCiDCDMSwitch, on)
+}
+
+// LeaderMS reports whether the Leader/MS field is on.
+func (v *Channel_rt84) LeaderMS() bool {
+	return viewBool(v.record, FtCiLeaderMS)
+}
+
+// SetLeaderMS turns the Leader/MS field on or off.
+func (v *Channel_rt84) SetLeaderMS(on bool) error {
+	return viewSetBool(v.record, FtCiLeaderMS, on)
+}
+
+// EmergencySystem returns the Emergency System field's value.
+func (v *Channel_rt84) EmergencySystem() int {
+	return viewInt(v.record, FtCiEmergencySystem)
+}
+
+// SetEmergencySystem sets the Emergency System field's value.
+func (v *Channel_rt84) SetEmergencySystem(i int) error {
+	return viewSetInt(v.record, FtCiEmergencySystem, i)
+}
+
+// ContactName returns the record referenced by the Contact Name field,
+// or nil if it references no record.
+func (v *Channel_rt84) ContactName() *Contact {
+	return NewContact(viewRecord(v.record, FtCiContactName))
+}
+
+// SetContactName makes the Contact Name field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_rt84) SetContactName(ref *Contact) error {
+	return viewSetRecord(v.record, FtCiContactName, ref.Record())
+}
+
+// GroupList returns the record referenced by the RX Group List field,
+// or nil if it references no record.
+func (v *Channel_rt84) GroupList() *GroupList {
+	return NewGroupList(viewRecord(v.record, FtCiGroupList))
+}
+
+// SetGroupList makes the RX Group List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_rt84) SetGroupList(ref *GroupList) error {
+	return viewSetRecord(v.record, FtCiGroupList, ref.Record())
+}
+
+// ColorCode returns the Color Code field's value.
+func (v *Channel_rt84) ColorCode() int {
+	return viewInt(v.record, FtCiColorCode)
+}
+
+// SetColorCode sets the Color Code field's value.
+func (v *Channel_rt84) SetColorCode(i int) error {
+	return viewSetInt(v.record, FtCiColorCode, i)
+}
+
+// RepeaterSlot returns the Repeater Slot field's value.
+func (v *Channel_rt84) RepeaterSlot() CiRepeaterSlot {
+	return CiRepeaterSlot(viewString(v.record, FtCiRepeaterSlot))
+}
+
+// SetRepeaterSlot sets the Repeater Slot field's value.
+func (v *Channel_rt84) SetRepeaterSlot(s CiRepeaterSlot) error {
+	return viewSetString(v.record, FtCiRepeaterSlot, string(s))
+}
+
+// Privacy returns the Privacy field's value.
+func (v *Channel_rt84) Privacy() CiPrivacy {
+	return CiPrivacy(viewString(v.record, FtCiPrivacy))
+}
+
+// SetPrivacy sets the Privacy field's value.
+func (v *Channel_rt84) SetPrivacy(s CiPrivacy) error {
+	return viewSetString(v.record, FtCiPrivacy, string(s))
+}
+
+// PrivacyNumber returns the Privacy Number field's value.
+func (v *Channel_rt84) PrivacyNumber() int {
+	return viewInt(v.record, FtCiPrivacyNumber)
+}
+
+// SetPrivacyNumber sets the Privacy Number field's value.
+func (v *Channel_rt84) SetPrivacyNumber(i int) error {
+	return viewSetInt(v.record, FtCiPrivacyNumber, i)
+}
+
+// InCallCriteria returns the In Call Criteria field's value.
+func (v *Channel_rt84) InCallCriteria() CiInCallCriteria {
+	return CiInCallCriteria(viewString(v.record, FtCiInCallCriteria))
+}
+
+// SetInCallCriteria sets the In Call Criteria field's value.
+func (v *Channel_rt84) SetInCallCriteria(s CiInCallCriteria) error {
+	return viewSetString(v.record, FtCiInCallCriteria, string(s))
+}
+
+// DisplayPTTID reports whether the Display PTT ID field is on.
+func (v *Channel_rt84) DisplayPTTID() bool {
+	return viewBool(v.record, FtCiDisplayPTTID)
+}
+
+// SetDisplayPTTID turns the Display PTT ID field on or off.
+func (v *Channel_rt84) SetDisplayPTTID(on bool) error {
+	return viewSetBool(v.record, FtCiDisplayPTTID, on)
+}
+
+// CtcssEncode returns the CTCSS/DCS Encode field's value.
+func (v *Channel_rt84) CtcssEncode() string {
+	return viewString(v.record, FtCiCtcssEncode)
+}
+
+// SetCtcssEncode sets the CTCSS/DCS Encode field's value.
+func (v *Channel_rt84) SetCtcssEncode(s string) error {
+	return viewSetString(v.record, FtCiCtcssEncode, s)
+}
+
+// TxSignallingSystem returns the Tx Signaling System field's value.
+func (v *Channel_rt84) TxSignallingSystem() CiTxSignallingSystem {
+	return CiTxSignallingSystem(viewString(v.record, FtCiTxSignallingSystem))
+}
+
+// SetTxSignallingSystem sets the Tx Signaling System field's value.
+func (v *Channel_rt84) SetTxSignallingSystem(s CiTxSignallingSystem) error {
+	return viewSetString(v.record, FtCiTxSignallingSystem, string(s))
+}
+
+// DQTTurnoffFreq returns the Non-QT/DQT Turn-off Freq field's value.
+func (v *Channel_rt84) DQTTurnoffFreq() CiDQTTurnoffFreq {
+	return CiDQTTurnoffFreq(viewString(v.record, FtCiDQTTurnoffFreq))
+}
+
+// SetDQTTurnoffFreq sets the Non-QT/DQT Turn-off Freq field's value.
+func (v *Channel_rt84) SetDQTTurnoffFreq(s CiDQTTurnoffFreq) error {
+	return viewSetString(v.record, FtCiDQTTurnoffFreq, string(s))
+}
+
+// QtReverse returns the QT Reverse field's value.
+func (v *Channel_rt84) QtReverse() CiQtReverse {
+	return CiQtReverse(viewString(v.record, FtCiQtReverse))
+}
+
+// SetQtReverse sets the QT Reverse field's value.
+func (v *Channel_rt84) SetQtReverse(s CiQtReverse) error {
+	return viewSetString(v.record, FtCiQtReverse, string(s))
+}
+
+// ReverseBurst reports whether the Reverse Burst/Turn Off Code field is on.
+func (v *Channel_rt84) ReverseBurst() bool {
+	return viewBool(v.record, FtCiReverseBurst)
+}
+
+// SetReverseBurst turns the Reverse Burst/Turn Off Code field on or off.
+func (v *Channel_rt84) SetReverseBurst(on bool) error {
+	return viewSetBool(v.record, FtCiReverseBurst, on)
+}
+
+// CtcssDecode returns the CTCSS/DCS Decode field's value.
+func (v *Channel_rt84) CtcssDecode() string {
+	return viewString(v.record, FtCiCtcssDecode)
+}
+
+// SetCtcssDecode sets the CTCSS/DCS Decode field's value.
+func (v *Channel_rt84) SetCtcssDecode(s string) error {
+	return viewSetString(v.record, FtCiCtcssDecode, s)
+}
+
+// RxSignallingSystem returns the Rx Signaling System field's value.
+func (v *Channel_rt84) RxSignallingSystem() CiRxSignallingSystem {
+	return CiRxSignallingSystem(viewString(v.record, FtCiRxSignallingSystem))
+}
+
+// SetRxSignallingSystem sets the Rx Signaling System field's value.
+func (v *Channel_rt84) SetRxSignallingSystem(s CiRxSignallingSystem) error {
+	return viewSetString(v.record, FtCiRxSignallingSystem, string(s))
+}
+
+// Decode1 reports whether the Decode 1 field is on.
+func (v *Channel_rt84) Decode1() bool {
+	return viewBool(v.record, FtCiDecode1)
+}
+
+// SetDecode1 turns the Decode 1 field on or off.
+func (v *Channel_rt84) SetDecode1(on bool) error {
+	return viewSetBool(v.record, FtCiDecode1, on)
+}
+
+// Decode2 reports whether the Decode 2 field is on.
+func (v *Channel_rt84) Decode2() bool {
+	return viewBool(v.record, FtCiDecode2)
+}
+
+// SetDecode2 turns the Decode 2 field on or off.
+func (v *Channel_rt84) SetDecode2(on bool) error {
+	return viewSetBool(v.record, FtCiDecode2, on)
+}
+
+// Decode3 reports whether the Decode 3 field is on.
+func (v *Channel_rt84) Decode3() bool {
+	return viewBool(v.record, FtCiDecode3)
+}
+
+// SetDecode3 turns the Decode 3 field on or off.
+func (v *Channel_rt84) SetDecode3(on bool) error {
+	return viewSetBool(v.record, FtCiDecode3, on)
+}
+
+// Decode4 reports whether the Decode 4 field is on.
+func (v *Channel_rt84) Decode4() bool {
+	return viewBool(v.record, FtCiDecode4)
+}
+
+// SetDecode4 turns the Decode 4 field on or off.
+func (v *Channel_rt84) SetDecode4(on bool) error {
+	return viewSetBool(v.record, FtCiDecode4, on)
+}
+
+// Decode5 reports whether the Decode 5 field is on.
+func (v *Channel_rt84) Decode5() bool {
+	return viewBool(v.record, FtCiDecode5)
+}
+
+// SetDecode5 turns the Decode 5 field on or off.
+func (v *Channel_rt84) SetDecode5(on bool) error {
+	return viewSetBool(v.record, FtCiDecode5, on)
+}
+
+// Decode6 reports whether the Decode 6 field is on.
+func (v *Channel_rt84) Decode6() bool {
+	return viewBool(v.record, FtCiDecode6)
+}
+
+// SetDecode6 turns the Decode 6 field on or off.
+func (v *Channel_rt84) SetDecode6(on bool) error {
+	return viewSetBool(v.record, FtCiDecode6, on)
+}
+
+// Decode7 reports whether the Decode 7 field is on.
+func (v *Channel_rt84) Decode7() bool {
+	return viewBool(v.record, FtCiDecode7)
+}
+
+// SetDecode7 turns the Decode 7 field on or off.
+func (v *Channel_rt84) SetDecode7(on bool) error {
+	return viewSetBool(v.record, FtCiDecode7, on)
+}
+
+// Decode8 reports whether the Decode 8 field is on.
+func (v *Channel_rt84) Decode8() bool {
+	return viewBool(v.record, FtCiDecode8)
+}
+
+// SetDecode8 turns the Decode 8 field on or off.
+func (v *Channel_rt84) SetDecode8(on bool) error {
+	return viewSetBool(v.record, FtCiDecode8, on)
+}
+
+// Channel_uv380 is a typed view of a Channels_uv380 record.
+type Channel_uv380 struct {
+	record *Record
+}
+
+// NewChannel_uv380 returns a typed view of r, a Channels_uv380 record.
+func NewChannel_uv380(r *Record) *Channel_uv380 {
+	if r == nil {
+		return nil
+	}
+	return &Channel_uv380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Channel_uv380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Channel Name field's value.
+func (v *Channel_uv380) Name() string {
+	return viewString(v.record, FtCiName)
+}
+
+// SetName sets the Channel Name field's value.
+func (v *Channel_uv380) SetName(s string) error {
+	return viewSetString(v.record, FtCiName, s)
+}
+
+// RxFrequency returns the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_uv380) RxFrequency() float64 {
+	return viewFloat(v.record, FtCiRxFrequency)
+}
+
+// SetRxFrequency sets the Rx Frequency (MHz) field's value in MHz.
+func (v *Channel_uv380) SetRxFrequency(mhz float64) error {
+	return viewSetFloat(v.record, FtCiRxFrequency, mhz)
+}
+
+// TxFrequencyOffset returns the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_uv380) TxFrequencyOffset() float64 {
+	return viewFloat(v.record, FtCiTxFrequencyOffset)
+}
+
+// SetTxFrequencyOffset sets the Tx Offset (MHz) field's value in MHz.
+func (v *Channel_uv380) SetTxFrequencyOffset(mhz float64) error {
+	return viewSetFloat(v.record, FtCiTxFrequencyOffset, mhz)
+}
+
+// ChannelMode returns the Channel Mode field's value.
+func (v *Channel_uv380) ChannelMode() CiChannelMode {
+	return CiChannelMode(viewString(v.record, FtCiChannelMode))
+}
+
+// SetChannelMode sets the Channel Mode field's value.
+func (v *Channel_uv380) SetChannelMode(s CiChannelMode) error {
+	return viewSetString(v.record, FtCiChannelMode, string(s))
+}
+
+// Bandwidth returns the Bandwidth (KHz) field's value.
+func (v *Channel_uv380) Bandwidth() CiBandwidth {
+	return CiBandwidth(viewString(v.record, FtCiBandwidth))
+}
+
+// SetBandwidth sets the Bandwidth (KHz) field's value.
+func (v *Channel_uv380) SetBandwidth(s CiBandwidth) error {
+	return viewSetString(v.record, FtCiBandwidth, string(s))
+}
+
+// ScanList returns the record referenced by the Scan List field,
+// or nil if it references no record.
+func (v *Channel_uv380) ScanList() *ScanList_md40 {
+	return NewScanList_md40(viewRecord(v.record, FtCiScanList_md40))
+}
+
+// SetScanList makes the Scan List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_uv380) SetScanList(ref *ScanList_md40) error {
+	return viewSetRecord(v.record, FtCiScanList_md40, ref.Record())
+}
+
+// Squelch returns the Squelch field's value.
+func (v *Channel_uv380) Squelch() int {
+	return viewInt(v.record, FtCiSquelch_uv380)
+}
+
+// SetSquelch sets the Squelch field's value.
+func (v *Channel_uv380) SetSquelch(i int) error {
+	return viewSetInt(v.record, FtCiSquelch_uv380, i)
+}
+
+// RxRefFrequency returns the Rx Ref Frequency field's value.
+func (v *Channel_uv380) RxRefFrequency() CiRxRefFrequency {
+	return CiRxRefFrequency(viewString(v.record, FtCiRxRefFrequency))
+}
+
+// SetRxRefFrequency sets the Rx Ref Frequency field's value.
+func (v *Channel_uv380) SetRxRefFrequency(s CiRxRefFrequency) error {
+	return viewSetString(v.record, FtCiRxRefFrequency, string(s))
+}
+
+// TxRefFrequency returns the Tx Ref Frequency field's value.
+func (v *Channel_uv380) TxRefFrequency() CiTxRefFrequency {
+	return CiTxRefFrequency(viewString(v.record, FtCiTxRefFrequency))
+}
+
+// SetTxRefFrequency sets the Tx Ref Frequency field's value.
+func (v *Channel_uv380) SetTxRefFrequency(s CiTxRefFrequency) error {
+	return viewSetString(v.record, FtCiTxRefFrequency, string(s))
+}
+
+// Tot returns the TOT (S) field's value.
+func (v *Channel_uv380) Tot() int {
+	return viewInt(v.record, FtCiTot)
+}
+
+// SetTot sets the TOT (S) field's value.
+func (v *Channel_uv380) SetTot(i int) error {
+	return viewSetInt(v.record, FtCiTot, i)
+}
+
+// TotRekeyDelay returns the TOT Rekey Delay (S) field's value.
+func (v *Channel_uv380) TotRekeyDelay() int {
+	return viewInt(v.record, FtCiTotRekeyDelay)
+}
+
+// SetTotRekeyDelay sets the TOT Rekey Delay (S) field's value.
+func (v *Channel_uv380) SetTotRekeyDelay(i int) error {
+	return viewSetInt(v.record, FtCiTotRekeyDelay, i)
+}
+
+// Power returns the Power field's value.
+func (v *Channel_uv380) Power() CiPower_uv380 {
+	return CiPower_uv380(viewString(v.record, FtCiPower_uv380))
+}
+
+// SetPower sets the Power field's value.
+func (v *Channel_uv380) SetPower(s CiPower_uv380) error {
+	return viewSetString(v.record, FtCiPower_uv380, string(s))
+}
+
+// AdmitCriteria returns the Admit Criteria field's value.
+func (v *Channel_uv380) AdmitCriteria() CiAdmitCriteria {
+	return CiAdmitCriteria(viewString(v.record, FtCiAdmitCriteria))
+}
+
+// SetAdmitCriteria sets the Admit Criteria field's value.
+func (v *Channel_uv380) SetAdmitCriteria(s CiAdmitCriteria) error {
+	return viewSetString(v.record, FtCiAdmitCriteria, string(s))
+}
+
+// Autoscan reports whether the Autoscan field is on.
+func (v *Channel_uv380) Autoscan() bool {
+	return viewBool(v.record, FtCiAutoscan)
+}
+
+// SetAutoscan turns the Autoscan field on or off.
+func (v *Channel_uv380) SetAutoscan(on bool) error {
+	return viewSetBool(v.record, FtCiAutoscan, on)
+}
+
+// RxOnly reports whether the Rx Only field is on.
+func (v *Channel_uv380) RxOnly() bool {
+	return viewBool(v.record, FtCiRxOnly)
+}
+
+// SetRxOnly turns the Rx Only field on or off.
+func (v *Channel_uv380) SetRxOnly(on bool) error {
+	return viewSetBool(v.record, FtCiRxOnly, on)
+}
+
+// LoneWorker reports whether the Lone Worker field is on.
+func (v *Channel_uv380) LoneWorker() bool {
+	return viewBool(v.record, FtCiLoneWorker)
+}
+
+// SetLoneWorker turns the Lone Worker field on or off.
+func (v *Channel_uv380) SetLoneWorker(on bool) error {
+	return viewSetBool(v.record, FtCiLoneWorker, on)
+}
+
+// Vox reports whether the VOX field is on.
+func (v *Channel_uv380) Vox() bool {
+	return viewBool(v.record, FtCiVox)
+}
+
+// SetVox turns the VOX field on or off.
+func (v *Channel_uv380) SetVox(on bool) error {
+	return viewSetBool(v.record, FtCiVox, on)
+}
+
+// AllowTalkaround reports whether the Allow Talkaround field is on.
+func (v *Channel_uv380) AllowTalkaround() bool {
+	return viewBool(v.record, FtCiAllowTalkaround)
+}
+
+// SetAllowTalkaround turns the Allow Talkaround field on or off.
+func (v *Channel_uv380) SetAllowTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiAllowTalkaround, on)
+}
+
+// SendGPSInfo reports whether the Send GPS Info field is on.
+func (v *Channel_uv380) SendGPSInfo() bool {
+	return viewBool(v.record, FtCiSendGPSInfo)
+}
+
+// SetSendGPSInfo turns the Send GPS Info field on or off.
+func (v *Channel_uv380) SetSendGPSInfo(on bool) error {
+	return viewSetBool(v.record, FtCiSendGPSInfo, on)
+}
+
+// ReceiveGPSInfo reports whether the Receive GPS Info field is on.
+func (v *Channel_uv380) ReceiveGPSInfo() bool {
+	return viewBool(v.record, FtCiReceiveGPSInfo)
+}
+
+// SetReceiveGPSInfo turns the Receive GPS Info field on or off.
+func (v *Channel_uv380) SetReceiveGPSInfo(on bool) error {
+	return viewSetBool(v.record, FtCiReceiveGPSInfo, on)
+}
+
+// PrivateCallConfirmed reports whether the Private Call Confimed field is on.
+func (v *Channel_uv380) PrivateCallConfirmed() bool {
+	return viewBool(v.record, FtCiPrivateCallConfirmed)
+}
+
+// SetPrivateCallConfirmed turns the Private Call Confimed field on or off.
+func (v *Channel_uv380) SetPrivateCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiPrivateCallConfirmed, on)
+}
+
+// Talkaround reports whether the Talkaround field is on.
+func (v *Channel_uv380) Talkaround() bool {
+	return viewBool(v.record, FtCiTalkaround)
+}
+
+// SetTalkaround turns the Talkaround field on or off.
+func (v *Channel_uv380) SetTalkaround(on bool) error {
+	return viewSetBool(v.record, FtCiTalkaround, on)
+}
+
+// EmergencyAlarmAck reports whether the Emergency Alarm Ack field is on.
+func (v *Channel_uv380) EmergencyAlarmAck() bool {
+	return viewBool(v.record, FtCiEmergencyAlarmAck)
+}
+
+// SetEmergencyAlarmAck turns the Emergency Alarm Ack field on or off.
+func (v *Channel_uv380) SetEmergencyAlarmAck(on bool) error {
+	return viewSetBool(v.record, FtCiEmergencyAlarmAck, on)
+}
+
+// DataCallConfirmed reports whether the Data Call Confirmed field is on.
+func (v *Channel_uv380) DataCallConfirmed() bool {
+	return viewBool(v.record, FtCiDataCallConfirmed)
+}
+
+// SetDataCallConfirmed turns the Data Call Confirmed field on or off.
+func (v *Channel_uv380) SetDataCallConfirmed(on bool) error {
+	return viewSetBool(v.record, FtCiDataCallConfirmed, on)
+}
+
+// DCDMSwitch reports whether the DCDM Switch field is on.
+func (v *Channel_uv380) DCDMSwitch() bool {
+	return viewBool(v.record, FtCiDCDMSwitch)
+}
+
+// SetDCDMSwitch turns the DCDM Switch field on or off.
+func (v *Channel_uv380) SetDCDMSwitch(on bool) error {
+	return viewSetBool(v.record, FtCiDCDMSwitch, on)
+}
+
+// LeaderMS reports whether the Leader/MS field is on.
+func (v *Channel_uv380) LeaderMS() bool {
+	return viewBool(v.record, FtCiLeaderMS)
+}
+
+// SetLeaderMS turns the Leader/MS field on or off.
+func (v *Channel_uv380) SetLeaderMS(on bool) error {
+	return viewSetBool(v.record, FtCiLeaderMS, on)
+}
+
+// EmergencySystem returns the Emergency System field's value.
+func (v *Channel_uv380) EmergencySystem() int {
+	return viewInt(v.record, FtCiEmergencySystem)
+}
+
+// SetEmergencySystem sets the Emergency System field's value.
+func (v *Channel_uv380) SetEmergencySystem(i int) error {
+	return viewSetInt(v.record, FtCiEmergencySystem, i)
+}
+
+// ContactName returns the record referenced by the Contact Name field,
+// or nil if it references no record.
+func (v *Channel_uv380) ContactName() *Contact {
+	return NewContact(viewRecord(v.record, FtCiContactName))
+}
+
+// SetContactName makes the Contact Name field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_uv380) SetContactName(ref *Contact) error {
+	return viewSetRecord(v.record, FtCiContactName, ref.Record())
+}
+
+// GroupList returns the record referenced by the RX Group List field,
+// or nil if it references no record.
+func (v *Channel_uv380) GroupList() *GroupList {
+	return NewGroupList(viewRecord(v.record, FtCiGroupList))
+}
+
+// SetGroupList makes the RX Group List field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *Channel_uv380) SetGroupList(ref *GroupList) error {
+	return viewSetRecord(v.record, FtCiGroupList, ref.Record())
+}
+
+// ColorCode returns the Color Code field's value.
+func (v *Channel_uv380) ColorCode() int {
+	return viewInt(v.record, FtCiColorCode)
+}
+
+// SetColorCode sets the Color Code field's value.
+func (v *Channel_uv380) SetColorCode(i int) error {
+	return viewSetInt(v.record, FtCiColorCode, i)
+}
+
+// RepeaterSlot returns the Repeater Slot field's value.
+func (v *Channel_uv380) RepeaterSlot() CiRepeaterSlot {
+	return CiRepeaterSlot(viewString(v.record, FtCiRepeaterSlot))
+}
+
+// SetRepeaterSlot sets the Repeater Slot field's value.
+func (v *Channel_uv380) SetRepeaterSlot(s CiRepeaterSlot) error {
+	return viewSetString(v.record, FtCiRepeaterSlot, string(s))
+}
+
+// Privacy returns the Privacy field's value.
+func (v *Channel_uv380) Privacy() CiPrivacy {
+	return CiPrivacy(viewString(v.record, FtCiPrivacy))
+}
+
+// SetPrivacy sets the Privacy field's value.
+func (v *Channel_uv380) SetPrivacy(s CiPrivacy) error {
+	return viewSetString(v.record, FtCiPrivacy, string(s))
+}
+
+// PrivacyNumber returns the Privacy Number field's value.
+func (v *Channel_uv380) PrivacyNumber() int {
+	return viewInt(v.record, FtCiPrivacyNumber)
+}
+
+// SetPrivacyNumber sets the Privacy Number field's value.
+func (v *Channel_uv380) SetPrivacyNumber(i int) error {
+	return viewSetInt(v.record, FtCiPrivacyNumber, i)
+}
+
+// GPSSystem returns the GPS System field's value.
+func (v *Channel_uv380) GPSSystem() int {
+	return viewInt(v.record, FtCiGPSSystem)
+}
+
+// SetGPSSystem sets the GPS System field's value.
+func (v *Channel_uv380) SetGPSSystem(i int) error {
+	return viewSetInt(v.record, FtCiGPSSystem, i)
+}
+
+// InCallCriteria returns the In Call Criteria field's value.
+func (v *Channel_uv380) InCallCriteria() CiInCallCriteria {
+	return CiInCallCriteria(viewString(v.record, FtCiInCallCriteria))
+}
+
+// SetInCallCriteria sets the In Call Criteria field's value.
+func (v *Channel_uv380) SetInCallCriteria(s CiInCallCriteria) error {
+	return viewSetString(v.record, FtCiInCallCriteria, string(s))
+}
+
+// DisplayPTTID reports whether the Display PTT ID field is on.
+func (v *Channel_uv380) DisplayPTTID() bool {
+	return viewBool(v.record, FtCiDisplayPTTID)
+}
+
+// SetDisplayPTTID turns the Display PTT ID field on or off.
+func (v *Channel_uv380) SetDisplayPTTID(on bool) error {
+	return viewSetBool(v.record, FtCiDisplayPTTID, on)
+}
+
+// CtcssEncode returns the CTCSS/DCS Encode field's value.
+func (v *Channel_uv380) CtcssEncode() string {
+	return viewString(v.record, FtCiCtcssEncode)
+}
+
+// SetCtcssEncode sets the CTCSS/DCS Encode field's value.
+func (v *Channel_uv380) SetCtcssEncode(s string) error {
+	return viewSetString(v.record, FtCiCtcssEncode, s)
+}
+
+// TxSignallingSystem returns the Tx Signaling System field's value.
+func (v *Channel_uv380) TxSignallingSystem() CiTxSignallingSystem {
+	return CiTxSignallingSystem(viewString(v.record, FtCiTxSignallingSystem))
+}
+
+// SetTxSignallingSystem sets the Tx Signaling System field's value.
+func (v *Channel_uv380) SetTxSignallingSystem(s CiTxSignallingSystem) error {
+	return viewSetString(v.record, FtCiTxSignallingSystem, string(s))
+}
+
+// QtReverse returns the QT Reverse field's value.
+func (v *Channel_uv380) QtReverse() CiQtReverse {
+	return CiQtReverse(viewString(v.record, FtCiQtReverse))
+}
+
+// SetQtReverse sets the QT Reverse field's value.
+func (v *Channel_uv380) SetQtReverse(s CiQtReverse) error {
+	return viewSetString(v.record, FtCiQtReverse, string(s))
+}
+
+// ReverseBurst reports whether the Reverse Burst/Turn Off Code field is on.
+func (v *Channel_uv380) ReverseBurst() bool {
+	return viewBool(v.record, FtCiReverseBurst)
+}
+
+// SetReverseBurst turns the Reverse Burst/Turn Off Code field on or off.
+func (v *Channel_uv380) SetReverseBurst(on bool) error {
+	return viewSetBool(v.record, FtCiReverseBurst, on)
+}
+
+// CtcssDecode returns the CTCSS/DCS Decode field's value.
+func (v *Channel_uv380) CtcssDecode() string {
+	return viewString(v.record, FtCiCtcssDecode)
+}
+
+// SetCtcssDecode sets the CTCSS/DCS Decode field's value.
+func (v *Channel_uv380) SetCtcssDecode(s string) error {
+	return viewSetString(v.record, FtCiCtcssDecode, s)
+}
+
+// RxSignallingSystem returns the Rx Signaling System field's value.
+func (v *Channel_uv380) RxSignallingSystem() CiRxSignallingSystem {
+	return CiRxSignallingSystem(viewString(v.record, FtCiRxSignallingSystem))
+}
+
+// SetRxSignallingSystem sets the Rx Signaling System field's value.
+func (v *Channel_uv380) SetRxSignallingSystem(s CiRxSignallingSystem) error {
+	return viewSetString(v.record, FtCiRxSignallingSystem, string(s))
+}
+
+// Decode1 reports whether the Decode 1 field is on.
+func (v *Channel_uv380) Decode1() bool {
+	return viewBool(v.record, FtCiDecode1)
+}
+
+// SetDecode1 turns the Decode 1 field on or off.
+func (v *Channel_uv380) SetDecode1(on bool) error {
+	return viewSetBool(v.record, FtCiDecode1, on)
+}
+
+// Decode2 reports whether the Decode 2 field is on.
+func (v *Channel_uv380) Decode2() bool {
+	return viewBool(v.record, FtCiDecode2)
+}
+
+// SetDecode2 turns the Decode 2 field on or off.
+func (v *Channel_uv380) SetDecode2(on bool) error {
+	return viewSetBool(v.record, FtCiDecode2, on)
+}
+
+// Decode3 reports whether the Decode 3 field is on.
+func (v *Channel_uv380) Decode3() bool {
+	return viewBool(v.record, FtCiDecode3)
+}
+
+// SetDecode3 turns the Decode 3 field on or off.
+func (v *Channel_uv380) SetDecode3(on bool) error {
+	return viewSetBool(v.record, FtCiDecode3, on)
+}
+
+// Decode4 reports whether the Decode 4 field is on.
+func (v *Channel_uv380) Decode4() bool {
+	return viewBool(v.record, FtCiDecode4)
+}
+
+// SetDecode4 turns the Decode 4 field on or off.
+func (v *Channel_uv380) SetDecode4(on bool) error {
+	return viewSetBool(v.record, FtCiDecode4, on)
+}
+
+// Decode5 reports whether the Decode 5 field is on.
+func (v *Channel_uv380) Decode5() bool {
+	return viewBool(v.record, FtCiDecode5)
+}
+
+// SetDecode5 turns the Decode 5 field on or off.
+func (v *Channel_uv380) SetDecode5(on bool) error {
+	return viewSetBool(v.record, FtCiDecode5, on)
+}
+
+// Decode6 reports whether the Decode 6 field is on.
+func (v *Channel_uv380) Decode6() bool {
+	return viewBool(v.record, FtCiDecode6)
+}
+
+// SetDecode6 turns the Decode 6 field on or off.
+func (v *Channel_uv380) SetDecode6(on bool) error {
+	return viewSetBool(v.record, FtCiDecode6, on)
+}
+
+// Decode7 reports whether the Decode 7 field is on.
+func (v *Channel_uv380) Decode7() bool {
+	return viewBool(v.record, FtCiDecode7)
+}
+
+// SetDecode7 turns the Decode 7 field on or off.
+func (v *Channel_uv380) SetDecode7(on bool) error {
+	return viewSetBool(v.record, FtCiDecode7, on)
+}
+
+// Decode8 reports whether the Decode 8 field is on.
+func (v *Channel_uv380) Decode8() bool {
+	return viewBool(v.record, FtCiDecode8)
+}
+
+// SetDecode8 turns the Decode 8 field on or off.
+func (v *Channel_uv380) SetDecode8(on bool) error {
+	return viewSetBool(v.record, FtCiDecode8, on)
+}
+
+// Contact is a typed view of a Contacts record.
+type Contact struct {
+	record *Record
+}
+
+// NewContact returns a typed view of r, a Contacts record.
+func NewContact(r *Record) *Contact {
+	if r == nil {
+		return nil
+	}
+	return &Contact{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Contact) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Contact Name field's value.
+func (v *Contact) Name() string {
+	return viewString(v.record, FtDcName)
+}
+
+// SetName sets the Contact Name field's value.
+func (v *Contact) SetName(s string) error {
+	return viewSetString(v.record, FtDcName, s)
+}
+
+// CallID returns the Call ID field's value.
+func (v *Contact) CallID() int {
+	return viewInt(v.record, FtDcCallID)
+}
+
+// SetCallID sets the Call ID field's value.
+func (v *Contact) SetCallID(i int) error {
+	return viewSetInt(v.record, FtDcCallID, i)
+}
+
+// CallType returns the Call Type field's value.
+func (v *Contact) CallType() DcCallType {
+	return DcCallType(viewString(v.record, FtDcCallType))
+}
+
+// SetCallType sets the Call Type field's value.
+func (v *Contact) SetCallType(s DcCallType) error {
+	return viewSetString(v.record, FtDcCallType, string(s))
+}
+
+// CallReceiveTone returns the Call Receive Tone field's value.
+func (v *Contact) CallReceiveTone() DcCallReceiveTone {
+	return DcCallReceiveTone(viewString(v.record, FtDcCallReceiveTone))
+}
+
+// SetCallReceiveTone sets the Call Receive Tone field's value.
+func (v *Contact) SetCallReceiveTone(s DcCallReceiveTone) error {
+	return viewSetString(v.record, FtDcCallReceiveTone, string(s))
+}
+
+// Contact_uv380 is a typed view of a Contacts_uv380 record.
+type Contact_uv380 struct {
+	record *Record
+}
+
+// NewContact_uv380 returns a typed view of r, a Contacts_uv380 record.
+func NewContact_uv380(r *Record) *Contact_uv380 {
+	if r == nil {
+		return nil
+	}
+	return &Contact_uv380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Contact_uv380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Contact Name field's value.
+func (v *Contact_uv380) Name() string {
+	return viewString(v.record, FtDcName)
+}
+
+// SetName sets the Contact Name field's value.
+func (v *Contact_uv380) SetName(s string) error {
+	return viewSetString(v.record, FtDcName, s)
+}
+
+// CallID returns the Call ID field's value.
+func (v *Contact_uv380) CallID() int {
+	return viewInt(v.record, FtDcCallID)
+}
+
+// SetCallID sets the Call ID field's value.
+func (v *Contact_uv380) SetCallID(i int) error {
+	return viewSetInt(v.record, FtDcCallID, i)
+}
+
+// CallType returns the Call Type field's value.
+func (v *Contact_uv380) CallType() DcCallType {
+	return DcCallType(viewString(v.record, FtDcCallType))
+}
+
+// SetCallType sets the Call Type field's value.
+func (v *Contact_uv380) SetCallType(s DcCallType) error {
+	return viewSetString(v.record, FtDcCallType, string(s))
+}
+
+// CallReceiveTone returns the Call Receive Tone field's value.
+func (v *Contact_uv380) CallReceiveTone() DcCallReceiveTone {
+	return DcCallReceiveTone(viewString(v.record, FtDcCallReceiveTone))
+}
+
+// SetCallReceiveTone sets the Call Receive Tone field's value.
+func (v *Contact_uv380) SetCallReceiveTone(s DcCallReceiveTone) error {
+	return viewSetString(v.record, FtDcCallReceiveTone, string(s))
+}
+
+// GeneralSettings_md2017 is a typed view of a GeneralSettings_md2017 record.
+type GeneralSettings_md2017 struct {
+	record *Record
+}
+
+// NewGeneralSettings_md2017 returns a typed view of r, a GeneralSettings_md2017 record.
+func NewGeneralSettings_md2017(r *Record) *GeneralSettings_md2017 {
+	if r == nil {
+		return nil
+	}
+	return &GeneralSettings_md2017{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *GeneralSettings_md2017) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// RadioName returns the Radio Name field's value.
+func (v *GeneralSettings_md2017) RadioName() string {
+	return viewString(v.record, FtGsRadioName)
+}
+
+// SetRadioName sets the Radio Name field's value.
+func (v *GeneralSettings_md2017) SetRadioName(s string) error {
+	return viewSetString(v.record, FtGsRadioName, s)
+}
+
+// RadioID returns the Radio ID field's value.
+func (v *GeneralSettings_md2017) RadioID() int {
+	return viewInt(v.record, FtGsRadioID)
+}
+
+// SetRadioID sets the Radio ID field's value.
+func (v *GeneralSettings_md2017) SetRadioID(i int) error {
+	return viewSetInt(v.record, FtGsRadioID, i)
+}
+
+// IntroScreen returns the Intro Screen field's value.
+func (v *GeneralSettings_md2017) IntroScreen() GsIntroScreen {
+	return GsIntroScreen(viewString(v.record, FtGsIntroScreen))
+}
+
+// SetIntroScreen sets the Intro Screen field's value.
+func (v *GeneralSettings_md2017) SetIntroScreen(s GsIntroScreen) error {
+	return viewSetString(v.record, FtGsIntroScreen, string(s))
+}
+
+// IntroScreenLine1 returns the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_md2017) IntroScreenLine1() string {
+	return viewString(v.record, FtGsIntroScreenLine1)
+}
+
+// SetIntroScreenLine1 sets the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_md2017) SetIntroScreenLine1(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine1, s)
+}
+
+// IntroScreenLine2 returns the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_md2017) IntroScreenLine2() string {
+	return viewString(v.record, FtGsIntroScreenLine2)
+}
+
+// SetIntroScreenLine2 sets the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_md2017) SetIntroScreenLine2(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine2, s)
+}
+
+// SavePreamble reports whether the Save Preamble field is on.
+func (v *GeneralSettings_md2017) SavePreamble() bool {
+	return viewBool(v.record, FtGsSavePreamble)
+}
+
+// SetSavePreamble turns the Save Preamble field on or off.
+func (v *GeneralSettings_md2017) SetSavePreamble(on bool) error {
+	return viewSetBool(v.record, FtGsSavePreamble, on)
+}
+
+// CHVoiceAnnouncement reports whether the CH Voice Announcement field is on.
+func (v *GeneralSettings_md2017) CHVoiceAnnouncement() bool {
+	return viewBool(v.record, FtGsCHVoiceAnnouncement)
+}
+
+// SetCHVoiceAnnouncement turns the CH Voice Announcement field on or off.
+func (v *GeneralSettings_md2017) SetCHVoiceAnnouncement(on bool) error {
+	return viewSetBool(v.record, FtGsCHVoiceAnnouncement, on)
+}
+
+// SaveModeReceive reports whether the Save Mode Receive field is on.
+func (v *GeneralSettings_md2017) SaveModeReceive() bool {
+	return viewBool(v.record, FtGsSaveModeReceive)
+}
+
+// SetSaveModeReceive turns the Save Mode Receive field on or off.
+func (v *GeneralSettings_md2017) SetSaveModeReceive(on bool) error {
+	return viewSetBool(v.record, FtGsSaveModeReceive, on)
+}
+
+// DisableAllTones reports whether the Disable All Tones field is on.
+func (v *GeneralSettings_md2017) DisableAllTones() bool {
+	return viewBool(v.record, FtGsDisableAllTones)
+}
+
+// SetDisableAllTones turns the Disable All Tones field on or off.
+func (v *GeneralSettings_md2017) SetDisableAllTones(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllTones, on)
+}
+
+// ChFreeIndicationTone reports whether the Channel Free Indication Tone field is on.
+func (v *GeneralSettings_md2017) ChFreeIndicationTone() bool {
+	return viewBool(v.record, FtGsChFreeIndicationTone)
+}
+
+// SetChFreeIndicationTone turns the Channel Free Indication Tone field on or off.
+func (v *GeneralSettings_md2017) SetChFreeIndicationTone(on bool) error {
+	return viewSetBool(v.record, FtGsChFreeIndicationTone, on)
+}
+
+// TalkPermitTone returns the Talk Permit Tone field's value.
+func (v *GeneralSettings_md2017) TalkPermitTone() GsTalkPermitTone {
+	return GsTalkPermitTone(viewString(v.record, FtGsTalkPermitTone))
+}
+
+// SetTalkPermitTone sets the Talk Permit Tone field's value.
+func (v *GeneralSettings_md2017) SetTalkPermitTone(s GsTalkPermitTone) error {
+	return viewSetString(v.record, FtGsTalkPermitTone, string(s))
+}
+
+// CallAlertToneDuration returns the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_md2017) CallAlertToneDuration() int {
+	return viewInt(v.record, FtGsCallAlertToneDuration)
+}
+
+// SetCallAlertToneDuration sets the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_md2017) SetCallAlertToneDuration(i int) error {
+	return viewSetInt(v.record, FtGsCallAlertToneDuration, i)
+}
+
+// ScanDigitalHangTime returns the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) ScanDigitalHangTime() int {
+	return viewInt(v.record, FtGsScanDigitalHangTime)
+}
+
+// SetScanDigitalHangTime sets the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) SetScanDigitalHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanDigitalHangTime, i)
+}
+
+// ScanAnalogHangTime returns the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) ScanAnalogHangTime() int {
+	return viewInt(v.record, FtGsScanAnalogHangTime)
+}
+
+// SetScanAnalogHangTime sets the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) SetScanAnalogHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanAnalogHangTime, i)
+}
+
+// LoneWorkerResponseTime returns the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_md2017) LoneWorkerResponseTime() int {
+	return viewInt(v.record, FtGsLoneWorkerResponseTime)
+}
+
+// SetLoneWorkerResponseTime sets the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_md2017) SetLoneWorkerResponseTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerResponseTime, i)
+}
+
+// LoneWorkerReminderTime returns the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_md2017) LoneWorkerReminderTime() int {
+	return viewInt(v.record, FtGsLoneWorkerReminderTime)
+}
+
+// SetLoneWorkerReminderTime sets the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_md2017) SetLoneWorkerReminderTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerReminderTime, i)
+}
+
+// PwAndLockEnable reports whether the Password And Lock Enable field is on.
+func (v *GeneralSettings_md2017) PwAndLockEnable() bool {
+	return viewBool(v.record, FtGsPwAndLockEnable)
+}
+
+// SetPwAndLockEnable turns the Password And Lock Enable field on or off.
+func (v *GeneralSettings_md2017) SetPwAndLockEnable(on bool) error {
+	return viewSetBool(v.record, FtGsPwAndLockEnable, on)
+}
+
+// PowerOnPassword returns the Power On Password field's value.
+func (v *GeneralSettings_md2017) PowerOnPassword() string {
+	return viewString(v.record, FtGsPowerOnPassword)
+}
+
+// SetPowerOnPassword sets the Power On Password field's value.
+func (v *GeneralSettings_md2017) SetPowerOnPassword(s string) error {
+	return viewSetString(v.record, FtGsPowerOnPassword, s)
+}
+
+// MonitorType returns the Monitor Type field's value.
+func (v *GeneralSettings_md2017) MonitorType() GsMonitorType {
+	return GsMonitorType(viewString(v.record, FtGsMonitorType))
+}
+
+// SetMonitorType sets the Monitor Type field's value.
+func (v *GeneralSettings_md2017) SetMonitorType(s GsMonitorType) error {
+	return viewSetString(v.record, FtGsMonitorType, string(s))
+}
+
+// VoxSensitivity returns the VOX Sensitivity field's value.
+func (v *GeneralSettings_md2017) VoxSensitivity() int {
+	return viewInt(v.record, FtGsVoxSensitivity)
+}
+
+// SetVoxSensitivity sets the VOX Sensitivity field's value.
+func (v *GeneralSettings_md2017) SetVoxSensitivity(i int) error {
+	return viewSetInt(v.record, FtGsVoxSensitivity, i)
+}
+
+// TxPreambleDuration returns the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_md2017) TxPreambleDuration() int {
+	return viewInt(v.record, FtGsTxPreambleDuration)
+}
+
+// SetTxPreambleDuration sets the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_md2017) SetTxPreambleDuration(i int) error {
+	return viewSetInt(v.record, FtGsTxPreambleDuration, i)
+}
+
+// RxLowBatteryInterval returns the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_md2017) RxLowBatteryInterval() int {
+	return viewInt(v.record, FtGsRxLowBatteryInterval)
+}
+
+// SetRxLowBatteryInterval sets the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_md2017) SetRxLowBatteryInterval(i int) error {
+	return viewSetInt(v.record, FtGsRxLowBatteryInterval, i)
+}
+
+// ChannelsHangTime returns the Channels Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) ChannelsHangTime() int {
+	return viewInt(v.record, FtGsChannelsHangTime)
+}
+
+// SetChannelsHangTime sets the Channels Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) SetChannelsHangTime(i int) error {
+	return viewSetInt(v.record, FtGsChannelsHangTime, i)
+}
+
+// PcProgPassword returns the PC Programming Password field's value.
+func (v *GeneralSettings_md2017) PcProgPassword() string {
+	return viewString(v.record, FtGsPcProgPassword)
+}
+
+// SetPcProgPassword sets the PC Programming Password field's value.
+func (v *GeneralSettings_md2017) SetPcProgPassword(s string) error {
+	return viewSetString(v.record, FtGsPcProgPassword, s)
+}
+
+// RadioProgPassword returns the Radio Programming Password field's value.
+func (v *GeneralSettings_md2017) RadioProgPassword() string {
+	return viewString(v.record, FtGsRadioProgPassword)
+}
+
+// SetRadioProgPassword sets the Radio Programming Password field's value.
+func (v *GeneralSettings_md2017) SetRadioProgPassword(s string) error {
+	return viewSetString(v.record, FtGsRadioProgPassword, s)
+}
+
+// SetKeypadLockTime returns the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_md2017) SetKeypadLockTime() GsSetKeypadLockTime {
+	return GsSetKeypadLockTime(viewString(v.record, FtGsSetKeypadLockTime))
+}
+
+// SetSetKeypadLockTime sets the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_md2017) SetSetKeypadLockTime(s GsSetKeypadLockTime) error {
+	return viewSetString(v.record, FtGsSetKeypadLockTime, string(s))
+}
+
+// FreqChannelMode returns the Freq/Channel Mode field's value.
+func (v *GeneralSettings_md2017) FreqChannelMode() GsFreqChannelMode_uv380 {
+	return GsFreqChannelMode_uv380(viewString(v.record, FtGsFreqChannelMode_uv380))
+}
+
+// SetFreqChannelMode sets the Freq/Channel Mode field's value.
+func (v *GeneralSettings_md2017) SetFreqChannelMode(s GsFreqChannelMode_uv380) error {
+	return viewSetString(v.record, FtGsFreqChannelMode_uv380, string(s))
+}
+
+// ModeSelectA returns the Mode Select A field's value.
+func (v *GeneralSettings_md2017) ModeSelectA() GsModeSelectA {
+	return GsModeSelectA(viewString(v.record, FtGsModeSelectA))
+}
+
+// SetModeSelectA sets the Mode Select A field's value.
+func (v *GeneralSettings_md2017) SetModeSelectA(s GsModeSelectA) error {
+	return viewSetString(v.record, FtGsModeSelectA, string(s))
+}
+
+// ModeSelectB returns the Mode Select B field's value.
+func (v *GeneralSettings_md2017) ModeSelectB() GsModeSelectB {
+	return GsModeSelectB(viewString(v.record, FtGsModeSelectB))
+}
+
+// SetModeSelectB sets the Mode Select B field's value.
+func (v *GeneralSettings_md2017) SetModeSelectB(s GsModeSelectB) error {
+	return viewSetString(v.record, FtGsModeSelectB, string(s))
+}
+
+// TimeZone returns the Time Zone field's value.
+func (v *GeneralSettings_md2017) TimeZone() GsTimeZone {
+	return GsTimeZone(viewString(v.record, FtGsTimeZone))
+}
+
+// SetTimeZone sets the Time Zone field's value.
+func (v *GeneralSettings_md2017) SetTimeZone(s GsTimeZone) error {
+	return viewSetString(v.record, FtGsTimeZone, string(s))
+}
+
+// BacklightTime returns the Backlight Time (S) field's value.
+func (v *GeneralSettings_md2017) BacklightTime() GsBacklightTime {
+	return GsBacklightTime(viewString(v.record, FtGsBacklightTime))
+}
+
+// SetBacklightTime sets the Backlight Time (S) field's value.
+func (v *GeneralSettings_md2017) SetBacklightTime(s GsBacklightTime) error {
+	return viewSetString(v.record, FtGsBacklightTime, string(s))
+}
+
+// DisableAllLeds reports whether the Disable All LEDS field is on.
+func (v *GeneralSettings_md2017) DisableAllLeds() bool {
+	return viewBool(v.record, FtGsDisableAllLeds)
+}
+
+// SetDisableAllLeds turns the Disable All LEDS field on or off.
+func (v *GeneralSettings_md2017) SetDisableAllLeds(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllLeds, on)
+}
+
+// GroupCallMatch reports whether the Group Call Match field is on.
+func (v *GeneralSettings_md2017) GroupCallMatch() bool {
+	return viewBool(v.record, FtGsGroupCallMatch)
+}
+
+// SetGroupCallMatch turns the Group Call Match field on or off.
+func (v *GeneralSettings_md2017) SetGroupCallMatch(on bool) error {
+	return viewSetBool(v.record, FtGsGroupCallMatch, on)
+}
+
+// PrivateCallMatch reports whether the Private Call Match field is on.
+func (v *GeneralSettings_md2017) PrivateCallMatch() bool {
+	return viewBool(v.record, FtGsPrivateCallMatch)
+}
+
+// SetPrivateCallMatch turns the Private Call Match field on or off.
+func (v *GeneralSettings_md2017) SetPrivateCallMatch(on bool) error {
+	return viewSetBool(v.record, FtGsPrivateCallMatch, on)
+}
+
+// GroupCallHangTime returns the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) GroupCallHangTime() int {
+	return viewInt(v.record, FtGsGroupCallHangTime)
+}
+
+// SetGroupCallHangTime sets the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) SetGroupCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsGroupCallHangTime, i)
+}
+
+// PrivateCallHangTime returns the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) PrivateCallHangTime() int {
+	return viewInt(v.record, FtGsPrivateCallHangTime)
+}
+
+// SetPrivateCallHangTime sets the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md2017) SetPrivateCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsPrivateCallHangTime, i)
+}
+
+// RadioID1 returns the Radio ID 1 field's value.
+func (v *GeneralSettings_md2017) RadioID1() int {
+	return viewInt(v.record, FtGsRadioID1)
+}
+
+// SetRadioID1 sets the Radio ID 1 field's value.
+func (v *GeneralSettings_md2017) SetRadioID1(i int) error {
+	return viewSetInt(v.record, FtGsRadioID1, i)
+}
+
+// RadioID2 returns the Radio ID 2 field's value.
+func (v *GeneralSettings_md2017) RadioID2() int {
+	return viewInt(v.record, FtGsRadioID2)
+}
+
+// SetRadioID2 sets the Radio ID 2 field's value.
+func (v *GeneralSettings_md2017) SetRadioID2(i int) error {
+	return viewSetInt(v.record, FtGsRadioID2, i)
+}
+
+// RadioID3 returns the Radio ID 3 field's value.
+func (v *GeneralSettings_md2017) RadioID3() int {
+	return viewInt(v.record, FtGsRadioID3)
+}
+
+// SetRadioID3 sets the Radio ID 3 field's value.
+func (v *GeneralSettings_md2017) SetRadioID3(i int) error {
+	return viewSetInt(v.record, FtGsRadioID3, i)
+}
+
+// MicLevel returns the MIC Level field's value.
+func (v *GeneralSettings_md2017) MicLevel() GsMicLevel {
+	return GsMicLevel(viewString(v.record, FtGsMicLevel))
+}
+
+// SetMicLevel sets the MIC Level field's value.
+func (v *GeneralSettings_md2017) SetMicLevel(s GsMicLevel) error {
+	return viewSetString(v.record, FtGsMicLevel, string(s))
+}
+
+// TxMode returns the Tx Mode field's value.
+func (v *GeneralSettings_md2017) TxMode() GsTxMode {
+	return GsTxMode(viewString(v.record, FtGsTxMode))
+}
+
+// SetTxMode sets the Tx Mode field's value.
+func (v *GeneralSettings_md2017) SetTxMode(s GsTxMode) error {
+	return viewSetString(v.record, FtGsTxMode, string(s))
+}
+
+// EditRadioID reports whether the Edit Radio ID field is on.
+func (v *GeneralSettings_md2017) EditRadioID() bool {
+	return viewBool(v.record, FtGsEditRadioID)
+}
+
+// SetEditRadioID turns the Edit Radio ID field on or off.
+func (v *GeneralSettings_md2017) SetEditRadioID(on bool) error {
+	return viewSetBool(v.record, FtGsEditRadioID, on)
+}
+
+// PublicZone reports whether the Public Zone field is on.
+func (v *GeneralSettings_md2017) PublicZone() bool {
+	return viewBool(v.record, FtGsPublicZone)
+}
+
+// SetPublicZone turns the Public Zone field on or off.
+func (v *GeneralSettings_md2017) SetPublicZone(on bool) error {
+	return viewSetBool(v.record, FtGsPublicZone, on)
+}
+
+// EnableContactsCSV reports whether the Enable Contacts CSV field is on.
+func (v *GeneralSettings_md2017) EnableContactsCSV() bool {
+	return viewBool(v.record, FtGsEnableContactsCSV)
+}
+
+// SetEnableContactsCSV turns the Enable Contacts CSV field on or off.
+func (v *GeneralSettings_md2017) SetEnableContactsCSV(on bool) error {
+	return viewSetBool(v.record, FtGsEnableContactsCSV, on)
+}
+
+// MenuControl reports whether the Menu Control field is on.
+func (v *GeneralSettings_md2017) MenuControl() bool {
+	return viewBool(v.record, FtGsMenuControl)
+}
+
+// SetMenuControl turns the Menu Control field on or off.
+func (v *GeneralSettings_md2017) SetMenuControl(on bool) error {
+	return viewSetBool(v.record, FtGsMenuControl, on)
+}
+
+// TwoChannel reports whether the Two Channel field is on.
+func (v *GeneralSettings_md2017) TwoChannel() bool {
+	return viewBool(v.record, FtGsTwoChannel)
+}
+
+// SetTwoChannel turns the Two Channel field on or off.
+func (v *GeneralSettings_md2017) SetTwoChannel(on bool) error {
+	return viewSetBool(v.record, FtGsTwoChannel, on)
+}
+
+// GeneralSettings_md380 is a typed view of a GeneralSettings_md380 record.
+type GeneralSettings_md380 struct {
+	record *Record
+}
+
+// NewGeneralSettings_md380 returns a typed view of r, a GeneralSettings_md380 record.
+func NewGeneralSettings_md380(r *Record) *GeneralSettings_md380 {
+	if r == nil {
+		return nil
+	}
+	return &GeneralSettings_md380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *GeneralSettings_md380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// RadioName returns the Radio Name field's value.
+func (v *GeneralSettings_md380) RadioName() string {
+	return viewString(v.record, FtGsRadioName)
+}
+
+// SetRadioName sets the Radio Name field's value.
+func (v *GeneralSettings_md380) SetRadioName(s string) error {
+	return viewSetString(v.record, FtGsRadioName, s)
+}
+
+// RadioID returns the Radio ID field's value.
+func (v *GeneralSettings_md380) RadioID() int {
+	return viewInt(v.record, FtGsRadioID)
+}
+
+// SetRadioID sets the Radio ID field's value.
+func (v *GeneralSettings_md380) SetRadioID(i int) error {
+	return viewSetInt(v.record, FtGsRadioID, i)
+}
+
+// IntroScreen returns the Intro Screen field's value.
+func (v *GeneralSettings_md380) IntroScreen() GsIntroScreen {
+	return GsIntroScreen(viewString(v.record, FtGsIntroScreen))
+}
+
+// SetIntroScreen sets the Intro Screen field's value.
+func (v *GeneralSettings_md380) SetIntroScreen(s GsIntroScreen) error {
+	return viewSetString(v.record, FtGsIntroScreen, string(s))
+}
+
+// IntroScreenLine1 returns the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_md380) IntroScreenLine1() string {
+	return viewString(v.record, FtGsIntroScreenLine1)
+}
+
+// SetIntroScreenLine1 sets the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_md380) SetIntroScreenLine1(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine1, s)
+}
+
+// IntroScreenLine2 returns the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_md380) IntroScreenLine2() string {
+	return viewString(v.record, FtGsIntroScreenLine2)
+}
+
+// SetIntroScreenLine2 sets the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_md380) SetIntroScreenLine2(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine2, s)
+}
+
+// SavePreamble reports whether the Save Preamble field is on.
+func (v *GeneralSettings_md380) SavePreamble() bool {
+	return viewBool(v.record, FtGsSavePreamble)
+}
+
+// SetSavePreamble turns the Save Preamble field on or off.
+func (v *GeneralSettings_md380) SetSavePreamble(on bool) error {
+	return viewSetBool(v.record, FtGsSavePreamble, on)
+}
+
+// SaveModeReceive reports whether the Save Mode Receive field is on.
+func (v *GeneralSettings_md380) SaveModeReceive() bool {
+	return viewBool(v.record, FtGsSaveModeReceive)
+}
+
+// SetSaveModeReceive turns the Save Mode Receive field on or off.
+func (v *GeneralSettings_md380) SetSaveModeReceive(on bool) error {
+	return viewSetBool(v.record, FtGsSaveModeReceive, on)
+}
+
+// DisableAllTones reports whether the Disable All Tones field is on.
+func (v *GeneralSettings_md380) DisableAllTones() bool {
+	return viewBool(v.record, FtGsDisableAllTones)
+}
+
+// SetDisableAllTones turns the Disable All Tones field on or off.
+func (v *GeneralSettings_md380) SetDisableAllTones(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllTones, on)
+}
+
+// ChFreeIndicationTone reports whether the Channel Free Indication Tone field is on.
+func (v *GeneralSettings_md380) ChFreeIndicationTone() bool {
+	return viewBool(v.record, FtGsChFreeIndicationTone)
+}
+
+// SetChFreeIndicationTone turns the Channel Free Indication Tone field on or off.
+func (v *GeneralSettings_md380) SetChFreeIndicationTone(on bool) error {
+	return viewSetBool(v.record, FtGsChFreeIndicationTone, on)
+}
+
+// TalkPermitTone returns the Talk Permit Tone field's value.
+func (v *GeneralSettings_md380) TalkPermitTone() GsTalkPermitTone {
+	return GsTalkPermitTone(viewString(v.record, FtGsTalkPermitTone))
+}
+
+// SetTalkPermitTone sets the Talk Permit Tone field's value.
+func (v *GeneralSettings_md380) SetTalkPermitTone(s GsTalkPermitTone) error {
+	return viewSetString(v.record, FtGsTalkPermitTone, string(s))
+}
+
+// KeypadTones reports whether the Keypad Tones field is on.
+func (v *GeneralSettings_md380) KeypadTones() bool {
+	return viewBool(v.record, FtGsKeypadTones)
+}
+
+// SetKeypadTones turns the Keypad Tones field on or off.
+func (v *GeneralSettings_md380) SetKeypadTones(on bool) error {
+	return viewSetBool(v.record, FtGsKeypadTones, on)
+}
+
+// CallAlertToneDuration returns the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_md380) CallAlertToneDuration() int {
+	return viewInt(v.record, FtGsCallAlertToneDuration)
+}
+
+// SetCallAlertToneDuration sets the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_md380) SetCallAlertToneDuration(i int) error {
+	return viewSetInt(v.record, FtGsCallAlertToneDuration, i)
+}
+
+// ScanDigitalHangTime returns the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) ScanDigitalHangTime() int {
+	return viewInt(v.record, FtGsScanDigitalHangTime)
+}
+
+// SetScanDigitalHangTime sets the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) SetScanDigitalHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanDigitalHangTime, i)
+}
+
+// ScanAnalogHangTime returns the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) ScanAnalogHangTime() int {
+	return viewInt(v.record, FtGsScanAnalogHangTime)
+}
+
+// SetScanAnalogHangTime sets the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) SetScanAnalogHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanAnalogHangTime, i)
+}
+
+// LoneWorkerResponseTime returns the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_md380) LoneWorkerResponseTime() int {
+	return viewInt(v.record, FtGsLoneWorkerResponseTime)
+}
+
+// SetLoneWorkerResponseTime sets the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_md380) SetLoneWorkerResponseTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerResponseTime, i)
+}
+
+// LoneWorkerReminderTime returns the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_md380) LoneWorkerReminderTime() int {
+	return viewInt(v.record, FtGsLoneWorkerReminderTime)
+}
+
+// SetLoneWorkerReminderTime sets the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_md380) SetLoneWorkerReminderTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerReminderTime, i)
+}
+
+// PwAndLockEnable reports whether the Password And Lock Enable field is on.
+func (v *GeneralSettings_md380) PwAndLockEnable() bool {
+	return viewBool(v.record, FtGsPwAndLockEnable)
+}
+
+// SetPwAndLockEnable turns the Password And Lock Enable field on or off.
+func (v *GeneralSettings_md380) SetPwAndLockEnable(on bool) error {
+	return viewSetBool(v.record, FtGsPwAndLockEnable, on)
+}
+
+// PowerOnPassword returns the Power On Password field's value.
+func (v *GeneralSettings_md380) PowerOnPassword() string {
+	return viewString(v.record, FtGsPowerOnPassword)
+}
+
+// SetPowerOnPassword sets the Power On Password field's value.
+func (v *GeneralSettings_md380) SetPowerOnPassword(s string) error {
+	return viewSetString(v.record, FtGsPowerOnPassword, s)
+}
+
+// MonitorType returns the Monitor Type field's value.
+func (v *GeneralSettings_md380) MonitorType() GsMonitorType {
+	return GsMonitorType(viewString(v.record, FtGsMonitorType))
+}
+
+// SetMonitorType sets the Monitor Type field's value.
+func (v *GeneralSettings_md380) SetMonitorType(s GsMonitorType) error {
+	return viewSetString(v.record, FtGsMonitorType, string(s))
+}
+
+// VoxSensitivity returns the VOX Sensitivity field's value.
+func (v *GeneralSettings_md380) VoxSensitivity() int {
+	return viewInt(v.record, FtGsVoxSensitivity)
+}
+
+// SetVoxSensitivity sets the VOX Sensitivity field's value.
+func (v *GeneralSettings_md380) SetVoxSensitivity(i int) error {
+	return viewSetInt(v.record, FtGsVoxSensitivity, i)
+}
+
+// TxPreambleDuration returns the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_md380) TxPreambleDuration() int {
+	return viewInt(v.record, FtGsTxPreambleDuration)
+}
+
+// SetTxPreambleDuration sets the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_md380) SetTxPreambleDuration(i int) error {
+	return viewSetInt(v.record, FtGsTxPreambleDuration, i)
+}
+
+// RxLowBatteryInterval returns the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_md380) RxLowBatteryInterval() int {
+	return viewInt(v.record, FtGsRxLowBatteryInterval)
+}
+
+// SetRxLowBatteryInterval sets the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_md380) SetRxLowBatteryInterval(i int) error {
+	return viewSetInt(v.record, FtGsRxLowBatteryInterval, i)
+}
+
+// PcProgPassword returns the PC Programming Password field's value.
+func (v *GeneralSettings_md380) PcProgPassword() string {
+	return viewString(v.record, FtGsPcProgPassword)
+}
+
+// SetPcProgPassword sets the PC Programming Password field's value.
+func (v *GeneralSettings_md380) SetPcProgPassword(s string) error {
+	return viewSetString(v.record, FtGsPcProgPassword, s)
+}
+
+// RadioProgPassword returns the Radio Programming Password field's value.
+func (v *GeneralSettings_md380) RadioProgPassword() string {
+	return viewString(v.record, FtGsRadioProgPassword)
+}
+
+// SetRadioProgPassword sets the Radio Programming Password field's value.
+func (v *GeneralSettings_md380) SetRadioProgPassword(s string) error {
+	return viewSetString(v.record, FtGsRadioProgPassword, s)
+}
+
+// BacklightTime returns the Backlight Time (S) field's value.
+func (v *GeneralSettings_md380) BacklightTime() GsBacklightTime {
+	return GsBacklightTime(viewString(v.record, FtGsBacklightTime))
+}
+
+// SetBacklightTime sets the Backlight Time (S) field's value.
+func (v *GeneralSettings_md380) SetBacklightTime(s GsBacklightTime) error {
+	return viewSetString(v.record, FtGsBacklightTime, string(s))
+}
+
+// SetKeypadLockTime returns the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_md380) SetKeypadLockTime() GsSetKeypadLockTime {
+	return GsSetKeypadLockTime(viewString(v.record, FtGsSetKeypadLockTime))
+}
+
+// SetSetKeypadLockTime sets the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_md380) SetSetKeypadLockTime(s GsSetKeypadLockTime) error {
+	return viewSetString(v.record, FtGsSetKeypadLockTime, string(s))
+}
+
+// DisableAllLeds reports whether the Disable All LEDS field is on.
+func (v *GeneralSettings_md380) DisableAllLeds() bool {
+	return viewBool(v.record, FtGsDisableAllLeds)
+}
+
+// SetDisableAllLeds turns the Disable All LEDS field on or off.
+func (v *GeneralSettings_md380) SetDisableAllLeds(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllLeds, on)
+}
+
+// GroupCallHangTime returns the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) GroupCallHangTime() int {
+	return viewInt(v.record, FtGsGroupCallHangTime)
+}
+
+// SetGroupCallHangTime sets the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) SetGroupCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsGroupCallHangTime, i)
+}
+
+// PrivateCallHangTime returns the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) PrivateCallHangTime() int {
+	return viewInt(v.record, FtGsPrivateCallHangTime)
+}
+
+// SetPrivateCallHangTime sets the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md380) SetPrivateCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsPrivateCallHangTime, i)
+}
+
+// GeneralSettings_md40 is a typed view of a GeneralSettings_md40 record.
+type GeneralSettings_md40 struct {
+	record *Record
+}
+
+// NewGeneralSettings_md40 returns a typed view of r, a GeneralSettings_md40 record.
+func NewGeneralSettings_md40(r *Record) *GeneralSettings_md40 {
+	if r == nil {
+		return nil
+	}
+	return &GeneralSettings_md40{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *GeneralSettings_md40) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// RadioName returns the Radio Name field's value.
+func (v *GeneralSettings_md40) RadioName() string {
+	return viewString(v.record, FtGsRadioName)
+}
+
+// SetRadioName sets the Radio Name field's value.
+func (v *GeneralSettings_md40) SetRadioName(s string) error {
+	return viewSetString(v.record, FtGsRadioName, s)
+}
+
+// RadioID returns the Radio ID field's value.
+func (v *GeneralSettings_md40) RadioID() int {
+	return viewInt(v.record, FtGsRadioID)
+}
+
+// SetRadioID sets the Radio ID field's value.
+func (v *GeneralSettings_md40) SetRadioID(i int) error {
+	return viewSetInt(v.record, FtGsRadioID, i)
+}
+
+// IntroScreen returns the Intro Screen field's value.
+func (v *GeneralSettings_md40) IntroScreen() GsIntroScreen {
+	return GsIntroScreen(viewString(v.record, FtGsIntroScreen))
+}
+
+// SetIntroScreen sets the Intro Screen field's value.
+func (v *GeneralSettings_md40) SetIntroScreen(s GsIntroScreen) error {
+	return viewSetString(v.record, FtGsIntroScreen, string(s))
+}
+
+// IntroScreenLine1 returns the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_md40) IntroScreenLine1() string {
+	return viewString(v.record, FtGsIntroScreenLine1)
+}
+
+// SetIntroScreenLine1 sets the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_md40) SetIntroScreenLine1(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine1, s)
+}
+
+// IntroScreenLine2 returns the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_md40) IntroScreenLine2() string {
+	return viewString(v.record, FtGsIntroScreenLine2)
+}
+
+// SetIntroScreenLine2 sets the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_md40) SetIntroScreenLine2(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine2, s)
+}
+
+// SavePreamble reports whether the Save Preamble field is on.
+func (v *GeneralSettings_md40) SavePreamble() bool {
+	return viewBool(v.record, FtGsSavePreamble)
+}
+
+// SetSavePreamble turns the Save Preamble field on or off.
+func (v *GeneralSettings_md40) SetSavePreamble(on bool) error {
+	return viewSetBool(v.record, FtGsSavePreamble, on)
+}
+
+// SaveModeReceive reports whether the Save Mode Receive field is on.
+func (v *GeneralSettings_md40) SaveModeReceive() bool {
+	return viewBool(v.record, FtGsSaveModeReceive)
+}
+
+// SetSaveModeReceive turns the Save Mode Receive field on or off.
+func (v *GeneralSettings_md40) SetSaveModeReceive(on bool) error {
+	return viewSetBool(v.record, FtGsSaveModeReceive, on)
+}
+
+// DisableAllTones reports whether the Disable All Tones field is on.
+func (v *GeneralSettings_md40) DisableAllTones() bool {
+	return viewBool(v.record, FtGsDisableAllTones)
+}
+
+// SetDisableAllTones turns the Disable All Tones field on or off.
+func (v *GeneralSettings_md40) SetDisableAllTones(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllTones, on)
+}
+
+// ChFreeIndicationTone reports whether the Channel Free Indication Tone field is on.
+func (v *GeneralSettings_md40) ChFreeIndicationTone() bool {
+	return viewBool(v.record, FtGsChFreeIndicationTone)
+}
+
+// SetChFreeIndicationTone turns the Channel Free Indication Tone field on or off.
+func (v *GeneralSettings_md40) SetChFreeIndicationTone(on bool) error {
+	return viewSetBool(v.record, FtGsChFreeIndicationTone, on)
+}
+
+// TalkPermitTone returns the Talk Permit Tone field's value.
+func (v *GeneralSettings_md40) TalkPermitTone() GsTalkPermitTone {
+	return GsTalkPermitTone(viewString(v.record, FtGsTalkPermitTone))
+}
+
+// SetTalkPermitTone sets the Talk Permit Tone field's value.
+func (v *GeneralSettings_md40) SetTalkPermitTone(s GsTalkPermitTone) error {
+	return viewSetString(v.record, FtGsTalkPermitTone, string(s))
+}
+
+// KeypadTones reports whether the Keypad Tones field is on.
+func (v *GeneralSettings_md40) KeypadTones() bool {
+	return viewBool(v.record, FtGsKeypadTones)
+}
+
+// SetKeypadTones turns the Keypad Tones field on or off.
+func (v *GeneralSettings_md40) SetKeypadTones(on bool) error {
+	return viewSetBool(v.record, FtGsKeypadTones, on)
+}
+
+// CallAlertToneDuration returns the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_md40) CallAlertToneDuration() int {
+	return viewInt(v.record, FtGsCallAlertToneDuration)
+}
+
+// SetCallAlertToneDuration sets the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_md40) SetCallAlertToneDuration(i int) error {
+	return viewSetInt(v.record, FtGsCallAlertToneDuration, i)
+}
+
+// ScanDigitalHangTime returns the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) ScanDigitalHangTime() int {
+	return viewInt(v.record, FtGsScanDigitalHangTime)
+}
+
+// SetScanDigitalHangTime sets the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) SetScanDigitalHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanDigitalHangTime, i)
+}
+
+// ScanAnalogHangTime returns the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) ScanAnalogHangTime() int {
+	return viewInt(v.record, FtGsScanAnalogHangTime)
+}
+
+// SetScanAnalogHangTime sets the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) SetScanAnalogHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanAnalogHangTime, i)
+}
+
+// LoneWorkerResponseTime returns the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_md40) LoneWorkerResponseTime() int {
+	return viewInt(v.record, FtGsLoneWorkerResponseTime)
+}
+
+// SetLoneWorkerResponseTime sets the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_md40) SetLoneWorkerResponseTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerResponseTime, i)
+}
+
+// LoneWorkerReminderTime returns the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_md40) LoneWorkerReminderTime() int {
+	return viewInt(v.record, FtGsLoneWorkerReminderTime)
+}
+
+// SetLoneWorkerReminderTime sets the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_md40) SetLoneWorkerReminderTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerReminderTime, i)
+}
+
+// PwAndLockEnable reports whether the Password And Lock Enable field is on.
+func (v *GeneralSettings_md40) PwAndLockEnable() bool {
+	return viewBool(v.record, FtGsPwAndLockEnable)
+}
+
+// SetPwAndLockEnable turns the Password And Lock Enable field on or off.
+func (v *GeneralSettings_md40) SetPwAndLockEnable(on bool) error {
+	return viewSetBool(v.record, FtGsPwAndLockEnable, on)
+}
+
+// PowerOnPassword returns the Power On Password field's value.
+func (v *GeneralSettings_md40) PowerOnPassword() string {
+	return viewString(v.record, FtGsPowerOnPassword)
+}
+
+// SetPowerOnPassword sets the Power On Password field's value.
+func (v *GeneralSettings_md40) SetPowerOnPassword(s string) error {
+	return viewSetString(v.record, FtGsPowerOnPassword, s)
+}
+
+// MonitorType returns the Monitor Type field's value.
+func (v *GeneralSettings_md40) MonitorType() GsMonitorType {
+	return GsMonitorType(viewString(v.record, FtGsMonitorType))
+}
+
+// SetMonitorType sets the Monitor Type field's value.
+func (v *GeneralSettings_md40) SetMonitorType(s GsMonitorType) error {
+	return viewSetString(v.record, FtGsMonitorType, string(s))
+}
+
+// VoxSensitivity returns the VOX Sensitivity field's value.
+func (v *GeneralSettings_md40) VoxSensitivity() int {
+	return viewInt(v.record, FtGsVoxSensitivity)
+}
+
+// SetVoxSensitivity sets the VOX Sensitivity field's value.
+func (v *GeneralSettings_md40) SetVoxSensitivity(i int) error {
+	return viewSetInt(v.record, FtGsVoxSensitivity, i)
+}
+
+// TxPreambleDuration returns the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_md40) TxPreambleDuration() int {
+	return viewInt(v.record, FtGsTxPreambleDuration)
+}
+
+// SetTxPreambleDuration sets the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_md40) SetTxPreambleDuration(i int) error {
+	return viewSetInt(v.record, FtGsTxPreambleDuration, i)
+}
+
+// RxLowBatteryInterval returns the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_md40) RxLowBatteryInterval() int {
+	return viewInt(v.record, FtGsRxLowBatteryInterval)
+}
+
+// SetRxLowBatteryInterval sets the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_md40) SetRxLowBatteryInterval(i int) error {
+	return viewSetInt(v.record, FtGsRxLowBatteryInterval, i)
+}
+
+// PcProgPassword returns the PC Programming Password field's value.
+func (v *GeneralSettings_md40) PcProgPassword() string {
+	return viewString(v.record, FtGsPcProgPassword)
+}
+
+// SetPcProgPassword sets the PC Programming Password field's value.
+func (v *GeneralSettings_md40) SetPcProgPassword(s string) error {
+	return viewSetString(v.record, FtGsPcProgPassword, s)
+}
+
+// RadioProgPassword returns the Radio Programming Password field's value.
+func (v *GeneralSettings_md40) RadioProgPassword() string {
+	return viewString(v.record, FtGsRadioProgPassword)
+}
+
+// SetRadioProgPassword sets the Radio Programming Password field's value.
+func (v *GeneralSettings_md40) SetRadioProgPassword(s string) error {
+	return viewSetString(v.record, FtGsRadioProgPassword, s)
+}
+
+// FreqChannelMode returns the Freq/Channel Mode field's value.
+func (v *GeneralSettings_md40) FreqChannelMode() GsFreqChannelMode {
+	return GsFreqChannelMode(viewString(v.record, FtGsFreqChannelMode))
+}
+
+// SetFreqChannelMode sets the Freq/Channel Mode field's value.
+func (v *GeneralSettings_md40) SetFreqChannelMode(s GsFreqChannelMode) error {
+	return viewSetString(v.record, FtGsFreqChannelMode, string(s))
+}
+
+// BacklightColor returns the Backlight Color field's value.
+func (v *GeneralSettings_md40) BacklightColor() GsBacklightColor {
+	return GsBacklightColor(viewString(v.record, FtGsBacklightColor))
+}
+
+// SetBacklightColor sets the Backlight Color field's value.
+func (v *GeneralSettings_md40) SetBacklightColor(s GsBacklightColor) error {
+	return viewSetString(v.record, FtGsBacklightColor, string(s))
+}
+
+// ModeSelect returns the Mode Select field's value.
+func (v *GeneralSettings_md40) ModeSelect() GsModeSelect {
+	return GsModeSelect(viewString(v.record, FtGsModeSelect))
+}
+
+// SetModeSelect sets the Mode Select field's value.
+func (v *GeneralSettings_md40) SetModeSelect(s GsModeSelect) error {
+	return viewSetString(v.record, FtGsModeSelect, string(s))
+}
+
+// LockUnlock returns the Lock/Unlock field's value.
+func (v *GeneralSettings_md40) LockUnlock() GsLockUnlock {
+	return GsLockUnlock(viewString(v.record, FtGsLockUnlock))
+}
+
+// SetLockUnlock sets the Lock/Unlock field's value.
+func (v *GeneralSettings_md40) SetLockUnlock(s GsLockUnlock) error {
+	return viewSetString(v.record, FtGsLockUnlock, string(s))
+}
+
+// SetKeypadLockTime returns the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_md40) SetKeypadLockTime() GsSetKeypadLockTime {
+	return GsSetKeypadLockTime(viewString(v.record, FtGsSetKeypadLockTime))
+}
+
+// SetSetKeypadLockTime sets the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_md40) SetSetKeypadLockTime(s GsSetKeypadLockTime) error {
+	return viewSetString(v.record, FtGsSetKeypadLockTime, string(s))
+}
+
+// DisableAllLeds reports whether the Disable All LEDS field is on.
+func (v *GeneralSettings_md40) DisableAllLeds() bool {
+	return viewBool(v.record, FtGsDisableAllLeds)
+}
+
+// SetDisableAllLeds turns the Disable All LEDS field on or off.
+func (v *GeneralSettings_md40) SetDisableAllLeds(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllLeds, on)
+}
+
+// GroupCallHangTime returns the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) GroupCallHangTime() int {
+	return viewInt(v.record, FtGsGroupCallHangTime)
+}
+
+// SetGroupCallHangTime sets the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) SetGroupCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsGroupCallHangTime, i)
+}
+
+// PrivateCallHangTime returns the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) PrivateCallHangTime() int {
+	return viewInt(v.record, FtGsPrivateCallHangTime)
+}
+
+// SetPrivateCallHangTime sets the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_md40) SetPrivateCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsPrivateCallHangTime, i)
+}
+
+// GeneralSettings_rt84 is a typed view of a GeneralSettings_rt84 record.
+type GeneralSettings_rt84 struct {
+	record *Record
+}
+
+// NewGeneralSettings_rt84 returns a typed view of r, a GeneralSettings_rt84 record.
+func NewGeneralSettings_rt84(r *Record) *GeneralSettings_rt84 {
+	if r == nil {
+		return nil
+	}
+	return &GeneralSettings_rt84{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *GeneralSettings_rt84) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// RadioName returns the Radio Name field's value.
+func (v *GeneralSettings_rt84) RadioName() string {
+	return viewString(v.record, FtGsRadioName)
+}
+
+// SetRadioName sets the Radio Name field's value.
+func (v *GeneralSettings_rt84) SetRadioName(s string) error {
+	return viewSetString(v.record, FtGsRadioName, s)
+}
+
+// RadioID returns the Radio ID field's value.
+func (v *GeneralSettings_rt84) RadioID() int {
+	return viewInt(v.record, FtGsRadioID)
+}
+
+// SetRadioID sets the Radio ID field's value.
+func (v *GeneralSettings_rt84) SetRadioID(i int) error {
+	return viewSetInt(v.record, FtGsRadioID, i)
+}
+
+// IntroScreen returns the Intro Screen field's value.
+func (v *GeneralSettings_rt84) IntroScreen() GsIntroScreen {
+	return GsIntroScreen(viewString(v.record, FtGsIntroScreen))
+}
+
+// SetIntroScreen sets the Intro Screen field's value.
+func (v *GeneralSettings_rt84) SetIntroScreen(s GsIntroScreen) error {
+	return viewSetString(v.record, FtGsIntroScreen, string(s))
+}
+
+// IntroScreenLine1 returns the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_rt84) IntroScreenLine1() string {
+	return viewString(v.record, FtGsIntroScreenLine1)
+}
+
+// SetIntroScreenLine1 sets the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_rt84) SetIntroScreenLine1(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine1, s)
+}
+
+// IntroScreenLine2 returns the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_rt84) IntroScreenLine2() string {
+	return viewString(v.record, FtGsIntroScreenLine2)
+}
+
+// SetIntroScreenLine2 sets the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_rt84) SetIntroScreenLine2(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine2, s)
+}
+
+// SavePreamble reports whether the Save Preamble field is on.
+func (v *GeneralSettings_rt84) SavePreamble() bool {
+	return viewBool(v.record, FtGsSavePreamble)
+}
+
+// SetSavePreamble turns the Save Preamble field on or off.
+func (v *GeneralSettings_rt84) SetSavePreamble(on bool) error {
+	return viewSetBool(v.record, FtGsSavePreamble, on)
+}
+
+// SaveModeReceive reports whether the Save Mode Receive field is on.
+func (v *GeneralSettings_rt84) SaveModeReceive() bool {
+	return viewBool(v.record, FtGsSaveModeReceive)
+}
+
+// SetSaveModeReceive turns the Save Mode Receive field on or off.
+func (v *GeneralSettings_rt84) SetSaveModeReceive(on bool) error {
+	return viewSetBool(v.record, FtGsSaveModeReceive, on)
+}
+
+// DisableAllTones reports whether the Disable All Tones field is on.
+func (v *GeneralSettings_rt84) DisableAllTones() bool {
+	return viewBool(v.record, FtGsDisableAllTones)
+}
+
+// SetDisableAllTones turns the Disable All Tones field on or off.
+func (v *GeneralSettings_rt84) SetDisableAllTones(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllTones, on)
+}
+
+// ChFreeIndicationTone reports whether the Channel Free Indication Tone field is on.
+func (v *GeneralSettings_rt84) ChFreeIndicationTone() bool {
+	return viewBool(v.record, FtGsChFreeIndicationTone)
+}
+
+// SetChFreeIndicationTone turns the Channel Free Indication Tone field on or off.
+func (v *GeneralSettings_rt84) SetChFreeIndicationTone(on bool) error {
+	return viewSetBool(v.record, FtGsChFreeIndicationTone, on)
+}
+
+// TalkPermitTone returns the Talk Permit Tone field's value.
+func (v *GeneralSettings_rt84) TalkPermitTone() GsTalkPermitTone {
+	return GsTalkPermitTone(viewString(v.record, FtGsTalkPermitTone))
+}
+
+// SetTalkPermitTone sets the Talk Permit Tone field's value.
+func (v *GeneralSettings_rt84) SetTalkPermitTone(s GsTalkPermitTone) error {
+	return viewSetString(v.record, FtGsTalkPermitTone, string(s))
+}
+
+// CallAlertToneDuration returns the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_rt84) CallAlertToneDuration() int {
+	return viewInt(v.record, FtGsCallAlertToneDuration)
+}
+
+// SetCallAlertToneDuration sets the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_rt84) SetCallAlertToneDuration(i int) error {
+	return viewSetInt(v.record, FtGsCallAlertToneDuration, i)
+}
+
+// ScanDigitalHangTime returns the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) ScanDigitalHangTime() int {
+	return viewInt(v.record, FtGsScanDigitalHangTime)
+}
+
+// SetScanDigitalHangTime sets the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) SetScanDigitalHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanDigitalHangTime, i)
+}
+
+// ScanAnalogHangTime returns the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) ScanAnalogHangTime() int {
+	return viewInt(v.record, FtGsScanAnalogHangTime)
+}
+
+// SetScanAnalogHangTime sets the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) SetScanAnalogHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanAnalogHangTime, i)
+}
+
+// LoneWorkerResponseTime returns the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_rt84) LoneWorkerResponseTime() int {
+	return viewInt(v.record, FtGsLoneWorkerResponseTime)
+}
+
+// SetLoneWorkerResponseTime sets the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_rt84) SetLoneWorkerResponseTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerResponseTime, i)
+}
+
+// LoneWorkerReminderTime returns the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_rt84) LoneWorkerReminderTime() int {
+	return viewInt(v.record, FtGsLoneWorkerReminderTime)
+}
+
+// SetLoneWorkerReminderTime sets the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_rt84) SetLoneWorkerReminderTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerReminderTime, i)
+}
+
+// PwAndLockEnable reports whether the Password And Lock Enable field is on.
+func (v *GeneralSettings_rt84) PwAndLockEnable() bool {
+	return viewBool(v.record, FtGsPwAndLockEnable)
+}
+
+// SetPwAndLockEnable turns the Password And Lock Enable field on or off.
+func (v *GeneralSettings_rt84) SetPwAndLockEnable(on bool) error {
+	return viewSetBool(v.record, FtGsPwAndLockEnable, on)
+}
+
+// PowerOnPassword returns the Power On Password field's value.
+func (v *GeneralSettings_rt84) PowerOnPassword() string {
+	return viewString(v.record, FtGsPowerOnPassword)
+}
+
+// SetPowerOnPassword sets the Power On Password field's value.
+func (v *GeneralSettings_rt84) SetPowerOnPassword(s string) error {
+	return viewSetString(v.record, FtGsPowerOnPassword, s)
+}
+
+// CHVoiceAnnouncement reports whether the CH Voice Announcement field is on.
+func (v *GeneralSettings_rt84) CHVoiceAnnouncement() bool {
+	return viewBool(v.record, FtGsCHVoiceAnnouncement)
+}
+
+// SetCHVoiceAnnouncement turns the CH Voice Announcement field on or off.
+func (v *GeneralSettings_rt84) SetCHVoiceAnnouncement(on bool) error {
+	return viewSetBool(v.record, FtGsCHVoiceAnnouncement, on)
+}
+
+// MonitorType returns the Monitor Type field's value.
+func (v *GeneralSettings_rt84) MonitorType() GsMonitorType {
+	return GsMonitorType(viewString(v.record, FtGsMonitorType))
+}
+
+// SetMonitorType sets the Monitor Type field's value.
+func (v *GeneralSettings_rt84) SetMonitorType(s GsMonitorType) error {
+	return viewSetString(v.record, FtGsMonitorType, string(s))
+}
+
+// VoxSensitivity returns the VOX Sensitivity field's value.
+func (v *GeneralSettings_rt84) VoxSensitivity() int {
+	return viewInt(v.record, FtGsVoxSensitivity)
+}
+
+// SetVoxSensitivity sets the VOX Sensitivity field's value.
+func (v *GeneralSettings_rt84) SetVoxSensitivity(i int) error {
+	return viewSetInt(v.record, FtGsVoxSensitivity, i)
+}
+
+// TxPreambleDuration returns the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_rt84) TxPreambleDuration() int {
+	return viewInt(v.record, FtGsTxPreambleDuration)
+}
+
+// SetTxPreambleDuration sets the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_rt84) SetTxPreambleDuration(i int) error {
+	return viewSetInt(v.record, FtGsTxPreambleDuration, i)
+}
+
+// RxLowBatteryInterval returns the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_rt84) RxLowBatteryInterval() int {
+	return viewInt(v.record, FtGsRxLowBatteryInterval)
+}
+
+// SetRxLowBatteryInterval sets the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_rt84) SetRxLowBatteryInterval(i int) error {
+	return viewSetInt(v.record, FtGsRxLowBatteryInterval, i)
+}
+
+// ChannelsHangTime returns the Channels Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) ChannelsHangTime() int {
+	return viewInt(v.record, FtGsChannelsHangTime)
+}
+
+// SetChannelsHangTime sets the Channels Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) SetChannelsHangTime(i int) error {
+	return viewSetInt(v.record, FtGsChannelsHangTime, i)
+}
+
+// PcProgPassword returns the PC Programming Password field's value.
+func (v *GeneralSettings_rt84) PcProgPassword() string {
+	return viewString(v.record, FtGsPcProgPassword)
+}
+
+// SetPcProgPassword sets the PC Programming Password field's value.
+func (v *GeneralSettings_rt84) SetPcProgPassword(s string) error {
+	return viewSetString(v.record, FtGsPcProgPassword, s)
+}
+
+// RadioProgPassword returns the Radio Programming Password field's value.
+func (v *GeneralSettings_rt84) RadioProgPassword() string {
+	return viewString(v.record, FtGsRadioProgPassword)
+}
+
+// SetRadioProgPassword sets the Radio Programming Password field's value.
+func (v *GeneralSettings_rt84) SetRadioProgPassword(s string) error {
+	return viewSetString(v.record, FtGsRadioProgPassword, s)
+}
+
+// BacklightTime returns the Backlight Time (S) field's value.
+func (v *GeneralSettings_rt84) BacklightTime() GsBacklightTime {
+	return GsBacklightTime(viewString(v.record, FtGsBacklightTime))
+}
+
+// SetBacklightTime sets the Backlight Time (S) field's value.
+func (v *GeneralSettings_rt84) SetBacklightTime(s GsBacklightTime) error {
+	return viewSetString(v.record, FtGsBacklightTime, string(s))
+}
+
+// SetKeypadLockTime returns the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_rt84) SetKeypadLockTime() GsSetKeypadLockTime {
+	return GsSetKeypadLockTime(viewString(v.record, FtGsSetKeypadLockTime))
+}
+
+// SetSetKeypadLockTime sets the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_rt84) SetSetKeypadLockTime(s GsSetKeypadLockTime) error {
+	return viewSetString(v.record, FtGsSetKeypadLockTime, string(s))
+}
+
+// FreqChannelMode returns the Freq/Channel Mode field's value.
+func (v *GeneralSettings_rt84) FreqChannelMode() GsFreqChannelMode_uv380 {
+	return GsFreqChannelMode_uv380(viewString(v.record, FtGsFreqChannelMode_uv380))
+}
+
+// SetFreqChannelMode sets the Freq/Channel Mode field's value.
+func (v *GeneralSettings_rt84) SetFreqChannelMode(s GsFreqChannelMode_uv380) error {
+	return viewSetString(v.record, FtGsFreqChannelMode_uv380, string(s))
+}
+
+// ModeSelectA returns the Mode Select A field's value.
+func (v *GeneralSettings_rt84) ModeSelectA() GsModeSelectA {
+	return GsModeSelectA(viewString(v.record, FtGsModeSelectA))
+}
+
+// SetModeSelectA sets the Mode Select A field's value.
+func (v *GeneralSettings_rt84) SetModeSelectA(s GsModeSelectA) error {
+	return viewSetString(v.record, FtGsModeSelectA, string(s))
+}
+
+// ModeSelectB returns the Mode Select B field's value.
+func (v *GeneralSettings_rt84) ModeSelectB() GsModeSelectB {
+	return GsModeSelectB(viewString(v.record, FtGsModeSelectB))
+}
+
+// SetModeSelectB sets the Mode Select B field's value.
+func (v *GeneralSettings_rt84) SetModeSelectB(s GsModeSelectB) error {
+	return viewSetString(v.record, FtGsModeSelectB, string(s))
+}
+
+// TimeZone returns the Time Zone field's value.
+func (v *GeneralSettings_rt84) TimeZone() GsTimeZone {
+	return GsTimeZone(viewString(v.record, FtGsTimeZone))
+}
+
+// SetTimeZone sets the Time Zone field's value.
+func (v *GeneralSettings_rt84) SetTimeZone(s GsTimeZone) error {
+	return viewSetString(v.record, FtGsTimeZone, string(s))
+}
+
+// DisableAllLeds reports whether the Disable All LEDS field is on.
+func (v *GeneralSettings_rt84) DisableAllLeds() bool {
+	return viewBool(v.record, FtGsDisableAllLeds)
+}
+
+// SetDisableAllLeds turns the Disable All LEDS field on or off.
+func (v *GeneralSettings_rt84) SetDisableAllLeds(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllLeds, on)
+}
+
+// GroupCallMatch reports whether the Group Call Match field is on.
+func (v *GeneralSettings_rt84) GroupCallMatch() bool {
+	return viewBool(v.record, FtGsGroupCallMatch)
+}
+
+// SetGroupCallMatch turns the Group Call Match field on or off.
+func (v *GeneralSettings_rt84) SetGroupCallMatch(on bool) error {
+	return viewSetBool(v.record, FtGsGroupCallMatch, on)
+}
+
+// PrivateCallMatch reports whether the Private Call Match field is on.
+func (v *GeneralSettings_rt84) PrivateCallMatch() bool {
+	return viewBool(v.record, FtGsPrivateCallMatch)
+}
+
+// SetPrivateCallMatch turns the Private Call Match field on or off.
+func (v *GeneralSettings_rt84) SetPrivateCallMatch(on bool) error {
+	return viewSetBool(v.record, FtGsPrivateCallMatch, on)
+}
+
+// GroupCallHangTime returns the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) GroupCallHangTime() int {
+	return viewInt(v.record, FtGsGroupCallHangTime)
+}
+
+// SetGroupCallHangTime sets the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) SetGroupCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsGroupCallHangTime, i)
+}
+
+// PrivateCallHangTime returns the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) PrivateCallHangTime() int {
+	return viewInt(v.record, FtGsPrivateCallHangTime)
+}
+
+// SetPrivateCallHangTime sets the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_rt84) SetPrivateCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsPrivateCallHangTime, i)
+}
+
+// GeneralSettings_uv380 is a typed view of a GeneralSettings_uv380 record.
+type GeneralSettings_uv380 struct {
+	record *Record
+}
+
+// NewGeneralSettings_uv380 returns a typed view of r, a GeneralSettings_uv380 record.
+func NewGeneralSettings_uv380(r *Record) *GeneralSettings_uv380 {
+	if r == nil {
+		return nil
+	}
+	return &GeneralSettings_uv380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *GeneralSettings_uv380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// RadioName returns the Radio Name field's value.
+func (v *GeneralSettings_uv380) RadioName() string {
+	return viewString(v.record, FtGsRadioName)
+}
+
+// SetRadioName sets the Radio Name field's value.
+func (v *GeneralSettings_uv380) SetRadioName(s string) error {
+	return viewSetString(v.record, FtGsRadioName, s)
+}
+
+// RadioID returns the Radio ID field's value.
+func (v *GeneralSettings_uv380) RadioID() int {
+	return viewInt(v.record, FtGsRadioID)
+}
+
+// SetRadioID sets the Radio ID field's value.
+func (v *GeneralSettings_uv380) SetRadioID(i int) error {
+	return viewSetInt(v.record, FtGsRadioID, i)
+}
+
+// IntroScreen returns the Intro Screen field's value.
+func (v *GeneralSettings_uv380) IntroScreen() GsIntroScreen {
+	return GsIntroScreen(viewString(v.record, FtGsIntroScreen))
+}
+
+// SetIntroScreen sets the Intro Screen field's value.
+func (v *GeneralSettings_uv380) SetIntroScreen(s GsIntroScreen) error {
+	return viewSetString(v.record, FtGsIntroScreen, string(s))
+}
+
+// IntroScreenLine1 returns the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_uv380) IntroScreenLine1() string {
+	return viewString(v.record, FtGsIntroScreenLine1)
+}
+
+// SetIntroScreenLine1 sets the Intro Screen Line 1 field's value.
+func (v *GeneralSettings_uv380) SetIntroScreenLine1(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine1, s)
+}
+
+// IntroScreenLine2 returns the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_uv380) IntroScreenLine2() string {
+	return viewString(v.record, FtGsIntroScreenLine2)
+}
+
+// SetIntroScreenLine2 sets the Intro Screen Line 2 field's value.
+func (v *GeneralSettings_uv380) SetIntroScreenLine2(s string) error {
+	return viewSetString(v.record, FtGsIntroScreenLine2, s)
+}
+
+// SavePreamble reports whether the Save Preamble field is on.
+func (v *GeneralSettings_uv380) SavePreamble() bool {
+	return viewBool(v.record, FtGsSavePreamble)
+}
+
+// SetSavePreamble turns the Save Preamble field on or off.
+func (v *GeneralSettings_uv380) SetSavePreamble(on bool) error {
+	return viewSetBool(v.record, FtGsSavePreamble, on)
+}
+
+// CHVoiceAnnouncement reports whether the CH Voice Announcement field is on.
+func (v *GeneralSettings_uv380) CHVoiceAnnouncement() bool {
+	return viewBool(v.record, FtGsCHVoiceAnnouncement)
+}
+
+// SetCHVoiceAnnouncement turns the CH Voice Announcement field on or off.
+func (v *GeneralSettings_uv380) SetCHVoiceAnnouncement(on bool) error {
+	return viewSetBool(v.record, FtGsCHVoiceAnnouncement, on)
+}
+
+// SaveModeReceive reports whether the Save Mode Receive field is on.
+func (v *GeneralSettings_uv380) SaveModeReceive() bool {
+	return viewBool(v.record, FtGsSaveModeReceive)
+}
+
+// SetSaveModeReceive turns the Save Mode Receive field on or off.
+func (v *GeneralSettings_uv380) SetSaveModeReceive(on bool) error {
+	return viewSetBool(v.record, FtGsSaveModeReceive, on)
+}
+
+// DisableAllTones reports whether the Disable All Tones field is on.
+func (v *GeneralSettings_uv380) DisableAllTones() bool {
+	return viewBool(v.record, FtGsDisableAllTones)
+}
+
+// SetDisableAllTones turns the Disable All Tones field on or off.
+func (v *GeneralSettings_uv380) SetDisableAllTones(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllTones, on)
+}
+
+// ChFreeIndicationTone reports whether the Channel Free Indication Tone field is on.
+func (v *GeneralSettings_uv380) ChFreeIndicationTone() bool {
+	return viewBool(v.record, FtGsChFreeIndicationTone)
+}
+
+// SetChFreeIndicationTone turns the Channel Free Indication Tone field on or off.
+func (v *GeneralSettings_uv380) SetChFreeIndicationTone(on bool) error {
+	return viewSetBool(v.record, FtGsChFreeIndicationTone, on)
+}
+
+// TalkPermitTone returns the Talk Permit Tone field's value.
+func (v *GeneralSettings_uv380) TalkPermitTone() GsTalkPermitTone {
+	return GsTalkPermitTone(viewString(v.record, FtGsTalkPermitTone))
+}
+
+// SetTalkPermitTone sets the Talk Permit Tone field's value.
+func (v *GeneralSettings_uv380) SetTalkPermitTone(s GsTalkPermitTone) error {
+	return viewSetString(v.record, FtGsTalkPermitTone, string(s))
+}
+
+// CallAlertToneDuration returns the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_uv380) CallAlertToneDuration() int {
+	return viewInt(v.record, FtGsCallAlertToneDuration)
+}
+
+// SetCallAlertToneDuration sets the Call Alert Tone Duration (S) field's value.
+func (v *GeneralSettings_uv380) SetCallAlertToneDuration(i int) error {
+	return viewSetInt(v.record, FtGsCallAlertToneDuration, i)
+}
+
+// ScanDigitalHangTime returns the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) ScanDigitalHangTime() int {
+	return viewInt(v.record, FtGsScanDigitalHangTime)
+}
+
+// SetScanDigitalHangTime sets the Scan Digital Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) SetScanDigitalHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanDigitalHangTime, i)
+}
+
+// ScanAnalogHangTime returns the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) ScanAnalogHangTime() int {
+	return viewInt(v.record, FtGsScanAnalogHangTime)
+}
+
+// SetScanAnalogHangTime sets the Scan Analog Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) SetScanAnalogHangTime(i int) error {
+	return viewSetInt(v.record, FtGsScanAnalogHangTime, i)
+}
+
+// LoneWorkerResponseTime returns the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_uv380) LoneWorkerResponseTime() int {
+	return viewInt(v.record, FtGsLoneWorkerResponseTime)
+}
+
+// SetLoneWorkerResponseTime sets the Lone Worker Response Time (min) field's value.
+func (v *GeneralSettings_uv380) SetLoneWorkerResponseTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerResponseTime, i)
+}
+
+// LoneWorkerReminderTime returns the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_uv380) LoneWorkerReminderTime() int {
+	return viewInt(v.record, FtGsLoneWorkerReminderTime)
+}
+
+// SetLoneWorkerReminderTime sets the Lone Worker Reminder Time (S) field's value.
+func (v *GeneralSettings_uv380) SetLoneWorkerReminderTime(i int) error {
+	return viewSetInt(v.record, FtGsLoneWorkerReminderTime, i)
+}
+
+// PwAndLockEnable reports whether the Password And Lock Enable field is on.
+func (v *GeneralSettings_uv380) PwAndLockEnable() bool {
+	return viewBool(v.record, FtGsPwAndLockEnable)
+}
+
+// SetPwAndLockEnable turns the Password And Lock Enable field on or off.
+func (v *GeneralSettings_uv380) SetPwAndLockEnable(on bool) error {
+	return viewSetBool(v.record, FtGsPwAndLockEnable, on)
+}
+
+// PowerOnPassword returns the Power On Password field's value.
+func (v *GeneralSettings_uv380) PowerOnPassword() string {
+	return viewString(v.record, FtGsPowerOnPassword)
+}
+
+// SetPowerOnPassword sets the Power On Password field's value.
+func (v *GeneralSettings_uv380) SetPowerOnPassword(s string) error {
+	return viewSetString(v.record, FtGsPowerOnPassword, s)
+}
+
+// MonitorType returns the Monitor Type field's value.
+func (v *GeneralSettings_uv380) MonitorType() GsMonitorType {
+	return GsMonitorType(viewString(v.record, FtGsMonitorType))
+}
+
+// SetMonitorType sets the Monitor Type field's value.
+func (v *GeneralSettings_uv380) SetMonitorType(s GsMonitorType) error {
+	return viewSetString(v.record, FtGsMonitorType, string(s))
+}
+
+// VoxSensitivity returns the VOX Sensitivity field's value.
+func (v *GeneralSettings_uv380) VoxSensitivity() int {
+	return viewInt(v.record, FtGsVoxSensitivity)
+}
+
+// SetVoxSensitivity sets the VOX Sensitivity field's value.
+func (v *GeneralSettings_uv380) SetVoxSensitivity(i int) error {
+	return viewSetInt(v.record, FtGsVoxSensitivity, i)
+}
+
+// TxPreambleDuration returns the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_uv380) TxPreambleDuration() int {
+	return viewInt(v.record, FtGsTxPreambleDuration)
+}
+
+// SetTxPreambleDuration sets the Tx Preamble Duration (mS) field's value.
+func (v *GeneralSettings_uv380) SetTxPreambleDuration(i int) error {
+	return viewSetInt(v.record, FtGsTxPreambleDuration, i)
+}
+
+// RxLowBatteryInterval returns the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_uv380) RxLowBatteryInterval() int {
+	return viewInt(v.record, FtGsRxLowBatteryInterval)
+}
+
+// SetRxLowBatteryInterval sets the Rx Low Battery Interval (S) field's value.
+func (v *GeneralSettings_uv380) SetRxLowBatteryInterval(i int) error {
+	return viewSetInt(v.record, FtGsRxLowBatteryInterval, i)
+}
+
+// ChannelsHangTime returns the Channels Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) ChannelsHangTime() int {
+	return viewInt(v.record, FtGsChannelsHangTime)
+}
+
+// SetChannelsHangTime sets the Channels Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) SetChannelsHangTime(i int) error {
+	return viewSetInt(v.record, FtGsChannelsHangTime, i)
+}
+
+// PcProgPassword returns the PC Programming Password field's value.
+func (v *GeneralSettings_uv380) PcProgPassword() string {
+	return viewString(v.record, FtGsPcProgPassword)
+}
+
+// SetPcProgPassword sets the PC Programming Password field's value.
+func (v *GeneralSettings_uv380) SetPcProgPassword(s string) error {
+	return viewSetString(v.record, FtGsPcProgPassword, s)
+}
+
+// RadioProgPassword returns the Radio Programming Password field's value.
+func (v *GeneralSettings_uv380) RadioProgPassword() string {
+	return viewString(v.record, FtGsRadioProgPassword)
+}
+
+// SetRadioProgPassword sets the Radio Programming Password field's value.
+func (v *GeneralSettings_uv380) SetRadioProgPassword(s string) error {
+	return viewSetString(v.record, FtGsRadioProgPassword, s)
+}
+
+// SetKeypadLockTime returns the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_uv380) SetKeypadLockTime() GsSetKeypadLockTime {
+	return GsSetKeypadLockTime(viewString(v.record, FtGsSetKeypadLockTime))
+}
+
+// SetSetKeypadLockTime sets the Set Keypad Lock Time (S) field's value.
+func (v *GeneralSettings_uv380) SetSetKeypadLockTime(s GsSetKeypadLockTime) error {
+	return viewSetString(v.record, FtGsSetKeypadLockTime, string(s))
+}
+
+// FreqChannelMode returns the Freq/Channel Mode field's value.
+func (v *GeneralSettings_uv380) FreqChannelMode() GsFreqChannelMode_uv380 {
+	return GsFreqChannelMode_uv380(viewString(v.record, FtGsFreqChannelMode_uv380))
+}
+
+// SetFreqChannelMode sets the Freq/Channel Mode field's value.
+func (v *GeneralSettings_uv380) SetFreqChannelMode(s GsFreqChannelMode_uv380) error {
+	return viewSetString(v.record, FtGsFreqChannelMode_uv380, string(s))
+}
+
+// ModeSelectA returns the Mode Select A field's value.
+func (v *GeneralSettings_uv380) ModeSelectA() GsModeSelectA {
+	return GsModeSelectA(viewString(v.record, FtGsModeSelectA))
+}
+
+// SetModeSelectA sets the Mode Select A field's value.
+func (v *GeneralSettings_uv380) SetModeSelectA(s GsModeSelectA) error {
+	return viewSetString(v.record, FtGsModeSelectA, string(s))
+}
+
+// ModeSelectB returns the Mode Select B field's value.
+func (v *GeneralSettings_uv380) ModeSelectB() GsModeSelectB {
+	return GsModeSelectB(viewString(v.record, FtGsModeSelectB))
+}
+
+// SetModeSelectB sets the Mode Select B field's value.
+func (v *GeneralSettings_uv380) SetModeSelectB(s GsModeSelectB) error {
+	return viewSetString(v.record, FtGsModeSelectB, string(s))
+}
+
+// TimeZone returns the Time Zone field's value.
+func (v *GeneralSettings_uv380) TimeZone() GsTimeZone {
+	return GsTimeZone(viewString(v.record, FtGsTimeZone))
+}
+
+// SetTimeZone sets the Time Zone field's value.
+func (v *GeneralSettings_uv380) SetTimeZone(s GsTimeZone) error {
+	return viewSetString(v.record, FtGsTimeZone, string(s))
+}
+
+// BacklightTime returns the Backlight Time (S) field's value.
+func (v *GeneralSettings_uv380) BacklightTime() GsBacklightTime {
+	return GsBacklightTime(viewString(v.record, FtGsBacklightTime))
+}
+
+// SetBacklightTime sets the Backlight Time (S) field's value.
+func (v *GeneralSettings_uv380) SetBacklightTime(s GsBacklightTime) error {
+	return viewSetString(v.record, FtGsBacklightTime, string(s))
+}
+
+// DisableAllLeds reports whether the Disable All LEDS field is on.
+func (v *GeneralSettings_uv380) DisableAllLeds() bool {
+	return viewBool(v.record, FtGsDisableAllLeds)
+}
+
+// SetDisableAllLeds turns the Disable All LEDS field on or off.
+func (v *GeneralSettings_uv380) SetDisableAllLeds(on bool) error {
+	return viewSetBool(v.record, FtGsDisableAllLeds, on)
+}
+
+// GroupCallMatch reports whether the Group Call Match field is on.
+func (v *GeneralSettings_uv380) GroupCallMatch() bool {
+	return viewBool(v.record, FtGsGroupCallMatch)
+}
+
+// SetGroupCallMatch turns the Group Call Match field on or off.
+func (v *GeneralSettings_uv380) SetGroupCallMatch(on bool) error {
+	return viewSetBool(v.record, FtGsGroupCallMatch, on)
+}
+
+// PrivateCallMatch reports whether the Private Call Match field is on.
+func (v *GeneralSettings_uv380) PrivateCallMatch() bool {
+	return viewBool(v.record, FtGsPrivateCallMatch)
+}
+
+// SetPrivateCallMatch turns the Private Call Match field on or off.
+func (v *GeneralSettings_uv380) SetPrivateCallMatch(on bool) error {
+	return viewSetBool(v.record, FtGsPrivateCallMatch, on)
+}
+
+// GroupCallHangTime returns the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) GroupCallHangTime() int {
+	return viewInt(v.record, FtGsGroupCallHangTime)
+}
+
+// SetGroupCallHangTime sets the Group Call Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) SetGroupCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsGroupCallHangTime, i)
+}
+
+// PrivateCallHangTime returns the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) PrivateCallHangTime() int {
+	return viewInt(v.record, FtGsPrivateCallHangTime)
+}
+
+// SetPrivateCallHangTime sets the Private Call Hang Time (mS) field's value.
+func (v *GeneralSettings_uv380) SetPrivateCallHangTime(i int) error {
+	return viewSetInt(v.record, FtGsPrivateCallHangTime, i)
+}
+
+// RadioID1 returns the Radio ID 1 field's value.
+func (v *GeneralSettings_uv380) RadioID1() int {
+	return viewInt(v.record, FtGsRadioID1)
+}
+
+// SetRadioID1 sets the Radio ID 1 field's value.
+func (v *GeneralSettings_uv380) SetRadioID1(i int) error {
+	return viewSetInt(v.record, FtGsRadioID1, i)
+}
+
+// RadioID2 returns the Radio ID 2 field's value.
+func (v *GeneralSettings_uv380) RadioID2() int {
+	return viewInt(v.record, FtGsRadioID2)
+}
+
+// SetRadioID2 sets the Radio ID 2 field's value.
+func (v *GeneralSettings_uv380) SetRadioID2(i int) error {
+	return viewSetInt(v.record, FtGsRadioID2, i)
+}
+
+// RadioID3 returns the Radio ID 3 field's value.
+func (v *GeneralSettings_uv380) RadioID3() int {
+	return viewInt(v.record, FtGsRadioID3)
+}
+
+// SetRadioID3 sets the Radio ID 3 field's value.
+func (v *GeneralSettings_uv380) SetRadioID3(i int) error {
+	return viewSetInt(v.record, FtGsRadioID3, i)
+}
+
+// MicLevel returns the MIC Level field's value.
+func (v *GeneralSettings_uv380) MicLevel() GsMicLevel {
+	return GsMicLevel(viewString(v.record, FtGsMicLevel))
+}
+
+// SetMicLevel sets the MIC Level field's value.
+func (v *GeneralSettings_uv380) SetMicLevel(s GsMicLevel) error {
+	return viewSetString(v.record, FtGsMicLevel, string(s))
+}
+
+// TxMode returns the Tx Mode field's value.
+func (v *GeneralSettings_uv380) TxMode() GsTxMode {
+	return GsTxMode(viewString(v.record, FtGsTxMode))
+}
+
+// SetTxMode sets the Tx Mode field's value.
+func (v *GeneralSettings_uv380) SetTxMode(s GsTxMode) error {
+	return viewSetString(v.record, FtGsTxMode, string(s))
+}
+
+// EditRadioID reports whether the Edit Radio ID field is on.
+func (v *GeneralSettings_uv380) EditRadioID() bool {
+	return viewBool(v.record, FtGsEditRadioID)
+}
+
+// SetEditRadioID turns the Edit Radio ID field on or off.
+func (v *GeneralSettings_uv380) SetEditRadioID(on bool) error {
+	return viewSetBool(v.record, FtGsEditRadioID, on)
+}
+
+// PublicZone reports whether the Public Zone field is on.
+func (v *GeneralSettings_uv380) PublicZone() bool {
+	return viewBool(v.record, FtGsPublicZone)
+}
+
+// SetPublicZone turns the Public Zone field on or off.
+func (v *GeneralSettings_uv380) SetPublicZone(on bool) error {
+	return viewSetBool(v.record, FtGsPublicZone, on)
+}
+
+// EnableContactsCSV reports whether the Enable Contacts CSV field is on.
+func (v *GeneralSettings_uv380) EnableContactsCSV() bool {
+	return viewBool(v.record, FtGsEnableContactsCSV)
+}
+
+// SetEnableContactsCSV turns the Enable Contacts CSV field on or off.
+func (v *GeneralSettings_uv380) SetEnableContactsCSV(on bool) error {
+	return viewSetBool(v.record, FtGsEnableContactsCSV, on)
+}
+
+// GroupList is a typed view of a GroupLists record.
+type GroupList struct {
+	record *Record
+}
+
+// NewGroupList returns a typed view of r, a GroupLists record.
+func NewGroupList(r *Record) *GroupList {
+	if r == nil {
+		return nil
+	}
+	return &GroupList{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *GroupList) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the RX Group List Name field's value.
+func (v *GroupList) Name() string {
+	return viewString(v.record, FtGlName)
+}
+
+// SetName sets the RX Group List Name field's value.
+func (v *GroupList) SetName(s string) error {
+	return viewSetString(v.record, FtGlName, s)
+}
+
+// Contact returns the records referenced by the Contacts fields.
+func (v *GroupList) Contact() []*Contact {
+	records := viewRecords(v.record, FtGlContact)
+	refs := make([]*Contact, len(records))
+	for i, r := range records {
+		refs[i] = NewContact(r)
+	}
+	return refs
+}
+
+// SetContact replaces the Contacts fields with references to refs.
+func (v *GroupList) SetContact(refs []*Contact) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtGlContact, records)
+}
+
+// ScanList_md380 is a typed view of a ScanLists_md380 record.
+type ScanList_md380 struct {
+	record *Record
+}
+
+// NewScanList_md380 returns a typed view of r, a ScanLists_md380 record.
+func NewScanList_md380(r *Record) *ScanList_md380 {
+	if r == nil {
+		return nil
+	}
+	return &ScanList_md380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *ScanList_md380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Scan List Name field's value.
+func (v *ScanList_md380) Name() string {
+	return viewString(v.record, FtSlName)
+}
+
+// SetName sets the Scan List Name field's value.
+func (v *ScanList_md380) SetName(s string) error {
+	return viewSetString(v.record, FtSlName, s)
+}
+
+// PriorityChannel1 returns the record referenced by the Priority Channel 1 field,
+// or nil if it references no record.
+func (v *ScanList_md380) PriorityChannel1() *Channel_md380 {
+	return NewChannel_md380(viewRecord(v.record, FtSlPriorityChannel1_md380))
+}
+
+// SetPriorityChannel1 makes the Priority Channel 1 field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_md380) SetPriorityChannel1(ref *Channel_md380) error {
+	return viewSetRecord(v.record, FtSlPriorityChannel1_md380, ref.Record())
+}
+
+// PriorityChannel2 returns the record referenced by the Priority Channel 2 field,
+// or nil if it references no record.
+func (v *ScanList_md380) PriorityChannel2() *Channel_md380 {
+	return NewChannel_md380(viewRecord(v.record, FtSlPriorityChannel2_md380))
+}
+
+// SetPriorityChannel2 makes the Priority Channel 2 field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_md380) SetPriorityChannel2(ref *Channel_md380) error {
+	return viewSetRecord(v.record, FtSlPriorityChannel2_md380, ref.Record())
+}
+
+// TxDesignatedChannel returns the record referenced by the Tx Designated Channel field,
+// or nil if it references no record.
+func (v *ScanList_md380) TxDesignatedChannel() *Channel_md380 {
+	return NewChannel_md380(viewRecord(v.record, FtSlTxDesignatedChannel_md380))
+}
+
+// SetTxDesignatedChannel makes the Tx Designated Channel field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_md380) SetTxDesignatedChannel(ref *Channel_md380) error {
+	return viewSetRecord(v.record, FtSlTxDesignatedChannel_md380, ref.Record())
+}
+
+// SignallingHoldTime returns the Signalling Hold Time (mS) field's value.
+func (v *ScanList_md380) SignallingHoldTime() int {
+	return viewInt(v.record, FtSlSignallingHoldTime)
+}
+
+// SetSignallingHoldTime sets the Signalling Hold Time (mS) field's value.
+func (v *ScanList_md380) SetSignallingHoldTime(i int) error {
+	return viewSetInt(v.record, FtSlSignallingHoldTime, i)
+}
+
+// PrioritySampleTime returns the Priority Sample Time (mS) field's value.
+func (v *ScanList_md380) PrioritySampleTime() int {
+	return viewInt(v.record, FtSlPrioritySampleTime)
+}
+
+// SetPrioritySampleTime sets the Priority Sample Time (mS) field's value.
+func (v *ScanList_md380) SetPrioritySampleTime(i int) error {
+	return viewSetInt(v.record, FtSlPrioritySampleTime, i)
+}
+
+// Channel returns the records referenced by the Channels fields.
+func (v *ScanList_md380) Channel() []*Channel_md380 {
+	records := viewRecords(v.record, FtSlChannel_md380)
+	refs := make([]*Channel_md380, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_md380(r)
+	}
+	return refs
+}
+
+// SetChannel replaces the Channels fields with references to refs.
+func (v *ScanList_md380) SetChannel(refs []*Channel_md380) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtSlChannel_md380, records)
+}
+
+// ScanList_md40 is a typed view of a ScanLists_md40 record.
+type ScanList_md40 struct {
+	record *Record
+}
+
+// NewScanList_md40 returns a typed view of r, a ScanLists_md40 record.
+func NewScanList_md40(r *Record) *ScanList_md40 {
+	if r == nil {
+		return nil
+	}
+	return &ScanList_md40{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *ScanList_md40) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Scan List Name field's value.
+func (v *ScanList_md40) Name() string {
+	return viewString(v.record, FtSlName)
+}
+
+// SetName sets the Scan List Name field's value.
+func (v *ScanList_md40) SetName(s string) error {
+	return viewSetString(v.record, FtSlName, s)
+}
+
+// PriorityChannel1 returns the record referenced by the Priority Channel 1 field,
+// or nil if it references no record.
+func (v *ScanList_md40) PriorityChannel1() *Channel_md40 {
+	return NewChannel_md40(viewRecord(v.record, FtSlPriorityChannel1_md40))
+}
+
+// SetPriorityChannel1 makes the Priority Channel 1 field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_md40) SetPriorityChannel1(ref *Channel_md40) error {
+	return viewSetRecord(v.record, FtSlPriorityChannel1_md40, ref.Record())
+}
+
+// PriorityChannel2 returns the record referenced by the Priority Channel 2 field,
+// or nil if it references no record.
+func (v *ScanList_md40) PriorityChannel2() *Channel_md40 {
+	return NewChannel_md40(viewRecord(v.record, FtSlPriorityChannel2_md40))
+}
+
+// SetPriorityChannel2 makes the Priority Channel 2 field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_md40) SetPriorityChannel2(ref *Channel_md40) error {
+	return viewSetRecord(v.record, FtSlPriorityChannel2_md40, ref.Record())
+}
+
+// TxDesignatedChannel returns the record referenced by the Tx Designated Channel field,
+// or nil if it references no record.
+func (v *ScanList_md40) TxDesignatedChannel() *Channel_md40 {
+	return NewChannel_md40(viewRecord(v.record, FtSlTxDesignatedChannel_md40))
+}
+
+// SetTxDesignatedChannel makes the Tx Designated Channel field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_md40) SetTxDesignatedChannel(ref *Channel_md40) error {
+	return viewSetRecord(v.record, FtSlTxDesignatedChannel_md40, ref.Record())
+}
+
+// SignallingHoldTime returns the Signalling Hold Time (mS) field's value.
+func (v *ScanList_md40) SignallingHoldTime() int {
+	return viewInt(v.record, FtSlSignallingHoldTime)
+}
+
+// SetSignallingHoldTime sets the Signalling Hold Time (mS) field's value.
+func (v *ScanList_md40) SetSignallingHoldTime(i int) error {
+	return viewSetInt(v.record, FtSlSignallingHoldTime, i)
+}
+
+// PrioritySampleTime returns the Priority Sample Time (mS) field's value.
+func (v *ScanList_md40) PrioritySampleTime() int {
+	return viewInt(v.record, FtSlPrioritySampleTime)
+}
+
+// SetPrioritySampleTime sets the Priority Sample Time (mS) field's value.
+func (v *ScanList_md40) SetPrioritySampleTime(i int) error {
+	return viewSetInt(v.record, FtSlPrioritySampleTime, i)
+}
+
+// Channel returns the records referenced by the Channels fields.
+func (v *ScanList_md40) Channel() []*Channel_md40 {
+	records := viewRecords(v.record, FtSlChannel_md40)
+	refs := make([]*Channel_md40, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_md40(r)
+	}
+	return refs
+}
+
+// SetChannel replaces the Channels fields with references to refs.
+func (v *ScanList_md40) SetChannel(refs []*Channel_md40) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtSlChannel_md40, records)
+}
+
+// ScanList_uv380 is a typed view of a ScanLists_uv380 record.
+type ScanList_uv380 struct {
+	record *Record
+}
+
+// NewScanList_uv380 returns a typed view of r, a ScanLists_uv380 record.
+func NewScanList_uv380(r *Record) *ScanList_uv380 {
+	if r == nil {
+		return nil
+	}
+	return &ScanList_uv380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *ScanList_uv380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Scan List Name field's value.
+func (v *ScanList_uv380) Name() string {
+	return viewString(v.record, FtSlName)
+}
+
+// SetName sets the Scan List Name field's value.
+func (v *ScanList_uv380) SetName(s string) error {
+	return viewSetString(v.record, FtSlName, s)
+}
+
+// PriorityChannel1 returns the record referenced by the Priority Channel 1 field,
+// or nil if it references no record.
+func (v *ScanList_uv380) PriorityChannel1() *Channel_md40 {
+	return NewChannel_md40(viewRecord(v.record, FtSlPriorityChannel1_md40))
+}
+
+// SetPriorityChannel1 makes the Priority Channel 1 field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_uv380) SetPriorityChannel1(ref *Channel_md40) error {
+	return viewSetRecord(v.record, FtSlPriorityChannel1_md40, ref.Record())
+}
+
+// PriorityChannel2 returns the record referenced by the Priority Channel 2 field,
+// or nil if it references no record.
+func (v *ScanList_uv380) PriorityChannel2() *Channel_md40 {
+	return NewChannel_md40(viewRecord(v.record, FtSlPriorityChannel2_md40))
+}
+
+// SetPriorityChannel2 makes the Priority Channel 2 field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_uv380) SetPriorityChannel2(ref *Channel_md40) error {
+	return viewSetRecord(v.record, FtSlPriorityChannel2_md40, ref.Record())
+}
+
+// TxDesignatedChannel returns the record referenced by the Tx Designated Channel field,
+// or nil if it references no record.
+func (v *ScanList_uv380) TxDesignatedChannel() *Channel_md40 {
+	return NewChannel_md40(viewRecord(v.record, FtSlTxDesignatedChannel_md40))
+}
+
+// SetTxDesignatedChannel makes the Tx Designated Channel field reference ref.
+// A nil ref sets the field to its first special value, such as "None".
+func (v *ScanList_uv380) SetTxDesignatedChannel(ref *Channel_md40) error {
+	return viewSetRecord(v.record, FtSlTxDesignatedChannel_md40, ref.Record())
+}
+
+// SignallingHoldTime returns the Signalling Hold Time (mS) field's value.
+func (v *ScanList_uv380) SignallingHoldTime() int {
+	return viewInt(v.record, FtSlSignallingHoldTime)
+}
+
+// SetSignallingHoldTime sets the Signalling Hold Time (mS) field's value.
+func (v *ScanList_uv380) SetSignallingHoldTime(i int) error {
+	return viewSetInt(v.record, FtSlSignallingHoldTime, i)
+}
+
+// PrioritySampleTime returns the Priority Sample Time (mS) field's value.
+func (v *ScanList_uv380) PrioritySampleTime() int {
+	return viewInt(v.record, FtSlPrioritySampleTime)
+}
+
+// SetPrioritySampleTime sets the Priority Sample Time (mS) field's value.
+func (v *ScanList_uv380) SetPrioritySampleTime(i int) error {
+	return viewSetInt(v.record, FtSlPrioritySampleTime, i)
+}
+
+// Channel returns the records referenced by the Channels fields.
+func (v *ScanList_uv380) Channel() []*Channel_md40 {
+	records := viewRecords(v.record, FtSlChannel_md40)
+	refs := make([]*Channel_md40, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_md40(r)
+	}
+	return refs
+}
+
+// SetChannel replaces the Channels fields with references to refs.
+func (v *ScanList_uv380) SetChannel(refs []*Channel_md40) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtSlChannel_md40, records)
+}
+
+// Zone_md380 is a typed view of a Zones_md380 record.
+type Zone_md380 struct {
+	record *Record
+}
+
+// NewZone_md380 returns a typed view of r, a Zones_md380 record.
+func NewZone_md380(r *Record) *Zone_md380 {
+	if r == nil {
+		return nil
+	}
+	return &Zone_md380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Zone_md380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Zone Name field's value.
+func (v *Zone_md380) Name() string {
+	return viewString(v.record, FtZiName)
+}
+
+// SetName sets the Zone Name field's value.
+func (v *Zone_md380) SetName(s string) error {
+	return viewSetString(v.record, FtZiName, s)
+}
+
+// Channel returns the records referenced by the Channels fields.
+func (v *Zone_md380) Channel() []*Channel_md380 {
+	records := viewRecords(v.record, FtZiChannel_md380)
+	refs := make([]*Channel_md380, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_md380(r)
+	}
+	return refs
+}
+
+// SetChannel replaces the Channels fields with references to refs.
+func (v *Zone_md380) SetChannel(refs []*Channel_md380) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtZiChannel_md380, records)
+}
+
+// Zone_md40 is a typed view of a Zones_md40 record.
+type Zone_md40 struct {
+	record *Record
+}
+
+// NewZone_md40 returns a typed view of r, a Zones_md40 record.
+func NewZone_md40(r *Record) *Zone_md40 {
+	if r == nil {
+		return nil
+	}
+	return &Zone_md40{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Zone_md40) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Zone Name field's value.
+func (v *Zone_md40) Name() string {
+	return viewString(v.record, FtZiName)
+}
+
+// SetName sets the Zone Name field's value.
+func (v *Zone_md40) SetName(s string) error {
+	return viewSetString(v.record, FtZiName, s)
+}
+
+// Channel returns the records referenced by the Channels fields.
+func (v *Zone_md40) Channel() []*Channel_md40 {
+	records := viewRecords(v.record, FtZiChannel_md40)
+	refs := make([]*Channel_md40, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_md40(r)
+	}
+	return refs
+}
+
+// SetChannel replaces the Channels fields with references to refs.
+func (v *Zone_md40) SetChannel(refs []*Channel_md40) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtZiChannel_md40, records)
+}
+
+// Zone_uv380 is a typed view of a Zones_uv380 record.
+type Zone_uv380 struct {
+	record *Record
+}
+
+// NewZone_uv380 returns a typed view of r, a Zones_uv380 record.
+func NewZone_uv380(r *Record) *Zone_uv380 {
+	if r == nil {
+		return nil
+	}
+	return &Zone_uv380{record: r}
+}
+
+// Record returns the record underlying the view.
+func (v *Zone_uv380) Record() *Record {
+	if v == nil {
+		return nil
+	}
+	return v.record
+}
+
+// Name returns the Zone Name field's value.
+func (v *Zone_uv380) Name() string {
+	return viewString(v.record, FtZiName)
+}
+
+// SetName sets the Zone Name field's value.
+func (v *Zone_uv380) SetName(s string) error {
+	return viewSetString(v.record, FtZiName, s)
+}
+
+// ChannelA returns the records referenced by the A Channels fields.
+func (v *Zone_uv380) ChannelA() []*Channel_uv380 {
+	records := viewRecords(v.record, FtZiChannelA_uv380)
+	refs := make([]*Channel_uv380, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_uv380(r)
+	}
+	return refs
+}
+
+// SetChannelA replaces the A Channels fields with references to refs.
+func (v *Zone_uv380) SetChannelA(refs []*Channel_uv380) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtZiChannelA_uv380, records)
+}
+
+// ChannelB returns the records referenced by the B Channels fields.
+func (v *Zone_uv380) ChannelB() []*Channel_uv380 {
+	records := viewRecords(v.record, FtZiChannelB_uv380)
+	refs := make([]*Channel_uv380, len(records))
+	for i, r := range records {
+		refs[i] = NewChannel_uv380(r)
+	}
+	return refs
+}
+
+// SetChannelB replaces the B Channels fields with references to refs.
+func (v *Zone_uv380) SetChannelB(refs []*Channel_uv380) error {
+	records := make([]*Record, len(refs))
+	for i, ref := range refs {
+		records[i] = ref.Record()
+	}
+	return viewSetRecords(v.record, FtZiChannelB_uv380, records)
+}
+
 //go:generate genCodeplugInfo
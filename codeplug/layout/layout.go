@@ -0,0 +1,415 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package layout checks the layout of codeplug descriptions in the
+// codeplugs.json format.  The same checks are made when generating
+// the compiled-in descriptions and when loading descriptions at run
+// time.
+package layout
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A Layout holds the parts of codeplug descriptions that determine
+// where their records and fields lie.
+type Layout struct {
+	Codeplugs []*Codeplug
+	Records   []*Record
+	Fields    []*Field
+}
+
+// A Codeplug describes a codeplug type's records.
+type Codeplug struct {
+	Type        string
+	RdtSize     int
+	RecordTypes []string
+}
+
+// A Record describes the layout of a record type.  A Max of 0 means 1,
+// as in codeplugs.json.
+type Record struct {
+	Type       string
+	Offset     int
+	Size       int
+	Max        int
+	DelDesc    *DelDesc
+	FieldTypes []string
+}
+
+// A DelDesc describes the bytes marking a record as deleted.
+type DelDesc struct {
+	Offset int
+	Size   int
+}
+
+// A Field describes the layout of a field type.  A Max of 0 means 1,
+// as in codeplugs.json.
+type Field struct {
+	Type         string
+	ValueType    string
+	BitOffset    int
+	BitSize      int
+	Max          int
+	ExtOffset    int
+	ExtSize      int
+	ExtIndex     int
+	ExtBitOffset int
+	ListType     string
+	Enables      []Enable
+}
+
+// An Enable lists the fields enabled by one of a field's values.
+type Enable struct {
+	Value   string
+	Enables []string
+}
+
+// typeString removes the variant suffix from a codeplugs.json type name.
+func typeString(s string) string {
+	index := strings.LastIndex(s, "_")
+	if index > 0 {
+		s = s[:index]
+	}
+
+	return s
+}
+
+// max returns n, or 1 if n is 0.
+func max(n int) int {
+	if n == 0 {
+		return 1
+	}
+
+	return n
+}
+
+// span is a half-open range of bits or bytes used by the layout checks.
+type span struct {
+	start int
+	end   int
+	name  string
+	fType string
+}
+
+// overlaps returns a description of each pair of overlapping spans,
+// other than those for which exclusive returns true.
+func overlaps(spans []span, exclusive func(s, t span) bool) []string {
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	var problems []string
+	for i, s := range spans {
+		for _, t := range spans[i+1:] {
+			if t.start >= s.end {
+				break
+			}
+			if exclusive != nil && exclusive(s, t) {
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("%s overlaps %s", s.name, t.name))
+		}
+	}
+
+	return problems
+}
+
+// checkField returns the problems with the layout of a field within
+// a record.
+func checkField(r *Record, f *Field) []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("record %s: field %s: ", r.Type, f.Type)+fmt.Sprintf(format, args...))
+	}
+
+	if f.BitSize <= 0 {
+		add("bitSize must be positive")
+		return problems
+	}
+
+	if f.BitSize < 8 {
+		if f.BitOffset%8+f.BitSize > 8 {
+			add("bits %d-%d cross a byte boundary", f.BitOffset, f.BitOffset+f.BitSize-1)
+		}
+	} else if f.BitOffset%8 != 0 || f.BitSize%8 != 0 {
+		add("multi-byte %s value is not byte aligned", f.ValueType)
+	}
+
+	count := max(f.Max)
+	if f.ExtSize != 0 {
+		count = f.ExtIndex
+		if f.ExtIndex < 0 || f.ExtIndex >= max(f.Max) {
+			add("extIndex %d must be between 0 and %d", f.ExtIndex, max(f.Max)-1)
+		}
+		if f.ExtBitOffset%8 != 0 {
+			add("extBitOffset %d is not byte aligned", f.ExtBitOffset)
+		}
+		extBytes := f.ExtBitOffset/8 + (max(f.Max)-f.ExtIndex)*((f.BitSize+7)/8)
+		if extBytes > f.ExtSize {
+			add("extended fields need %d bytes, but extSize is %d", extBytes, f.ExtSize)
+		}
+	}
+
+	end := f.BitOffset + count*f.BitSize
+	if end > r.Size*8 {
+		add("bits %d-%d extend past the record's %d bytes", f.BitOffset, end-1, r.Size)
+	}
+
+	return problems
+}
+
+// checkRecord returns the problems with the layout of a record's fields.
+func checkRecord(r *Record, fieldMap map[string]*Field, recordMap map[string]*Record) []string {
+	var problems []string
+	var spans []span
+
+	seen := make(map[string]bool)
+	for _, fType := range r.FieldTypes {
+		if seen[fType] {
+			// Some records list a field twice.
+			continue
+		}
+		seen[fType] = true
+
+		f := fieldMap[fType]
+		if f == nil {
+			problems = append(problems, fmt.Sprintf("record %s: undefined field type %s", r.Type, fType))
+			continue
+		}
+
+		problems = append(problems, checkField(r, f)...)
+
+		if f.ListType != "" && recordMap[f.ListType] == nil {
+			problems = append(problems, fmt.Sprintf("record %s: field %s: undefined list record type %s", r.Type, fType, f.ListType))
+		}
+
+		count := max(f.Max)
+		if f.ExtSize != 0 {
+			count = f.ExtIndex
+		}
+		if count == 0 {
+			continue
+		}
+		spans = append(spans, span{
+			start: f.BitOffset,
+			end:   f.BitOffset + count*f.BitSize,
+			name:  "field " + fType,
+			fType: fType,
+		})
+	}
+
+	enablers := enablingValues(r, fieldMap)
+	exclusive := func(s, t span) bool {
+		return exclusiveFields(enablers, s.fType, t.fType)
+	}
+	for _, p := range overlaps(spans, exclusive) {
+		problems = append(problems, fmt.Sprintf("record %s: %s", r.Type, p))
+	}
+
+	if r.DelDesc != nil && r.DelDesc.Offset+r.DelDesc.Size > r.Size {
+		problems = append(problems, fmt.Sprintf("record %s: delDesc extends past the record's end", r.Type))
+	}
+
+	return problems
+}
+
+// checkCodeplug returns the problems with the layout of a codeplug's
+// record arrays and extended fields.
+func checkCodeplug(c *Codeplug, fieldMap map[string]*Field, recordMap map[string]*Record) []string {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf("codeplug %s: ", c.Type)+fmt.Sprintf(format, args...))
+	}
+
+	rTypes := make(map[string]bool)
+	for _, rType := range c.RecordTypes {
+		rTypes[typeString(rType)] = true
+	}
+
+	var spans []span
+	extSpans := make(map[string][]span)
+	for _, rType := range c.RecordTypes {
+		r := recordMap[rType]
+		if r == nil {
+			add("undefined record type %s", rType)
+			continue
+		}
+
+		end := r.Offset + max(r.Max)*r.Size
+		if end > c.RdtSize {
+			add("record %s ends at %d, past rdtSize %d", rType, end, c.RdtSize)
+		}
+		spans = append(spans, span{r.Offset, end, "record " + rType, ""})
+
+		for _, fType := range r.FieldTypes {
+			f := fieldMap[fType]
+			if f == nil {
+				continue
+			}
+
+			if f.ListType != "" && !rTypes[typeString(f.ListType)] {
+				add("field %s references %s, which the codeplug lacks", fType, f.ListType)
+			}
+
+			if f.ExtSize == 0 {
+				continue
+			}
+			extEnd := f.ExtOffset + max(r.Max)*f.ExtSize
+			if extEnd > c.RdtSize {
+				add("field %s extension ends at %d, past rdtSize %d", fType, extEnd, c.RdtSize)
+			}
+
+			// Fields may share an extension area, each using its
+			// own part of each record's extension.
+			area := fmt.Sprintf("%d:%d", f.ExtOffset, f.ExtSize)
+			if extSpans[area] == nil {
+				spans = append(spans, span{f.ExtOffset, extEnd, "extension of record " + rType, ""})
+			}
+			start := f.ExtBitOffset / 8
+			extSpans[area] = append(extSpans[area], span{
+				start: start,
+				end:   start + (max(f.Max)-f.ExtIndex)*((f.BitSize+7)/8),
+				name:  "extension of field " + fType,
+			})
+		}
+	}
+
+	var areas []string
+	for area := range extSpans {
+		areas = append(areas, area)
+	}
+	sort.Strings(areas)
+	for _, area := range areas {
+		for _, p := range overlaps(extSpans[area], nil) {
+			add("%s", p)
+		}
+	}
+
+	for _, p := range overlaps(spans, nil) {
+		add("%s", p)
+	}
+
+	return problems
+}
+
+// Check returns a description of each inconsistency found in the
+// layout: fields that overlap, are misaligned or extend past their
+// record, records that overlap or extend past their codeplug, out of
+// range extended fields and references to undefined record types.
+// Fields that are enabled by different values of a common field may
+// share bits.
+func (l *Layout) Check() []string {
+	var problems []string
+
+	fieldMap := make(map[string]*Field)
+	for _, f := range l.Fields {
+		fieldMap[f.Type] = f
+	}
+
+	recordMap := make(map[string]*Record)
+	for _, r := range l.Records {
+		recordMap[r.Type] = r
+	}
+
+	for _, r := range l.Records {
+		problems = append(problems, checkRecord(r, fieldMap, recordMap)...)
+	}
+
+	for _, c := range l.Codeplugs {
+		problems = append(problems, checkCodeplug(c, fieldMap, recordMap)...)
+	}
+
+	return problems
+}
+
+// enablingValues returns, for each field of the record, the values of
+// each of its direct or indirect enabling fields that enable it.
+func enablingValues(r *Record, fieldMap map[string]*Field) map[string]map[string][]string {
+	direct := make(map[string]map[string][]string)
+	for _, fType := range r.FieldTypes {
+		f := fieldMap[fType]
+		if f == nil {
+			continue
+		}
+
+		for _, enable := range f.Enables {
+			for _, enabled := range enable.Enables {
+				if direct[enabled] == nil {
+					direct[enabled] = make(map[string][]string)
+				}
+				direct[enabled][fType] = append(direct[enabled][fType], enable.Value)
+			}
+		}
+	}
+
+	all := make(map[string]map[string][]string)
+	for _, fType := range r.FieldTypes {
+		values := make(map[string][]string)
+		seen := map[string]bool{fType: true}
+		pending := []string{fType}
+		for len(pending) > 0 {
+			enabled := pending[0]
+			pending = pending[1:]
+			for enabler, vals := range direct[enabled] {
+				if seen[enabler] {
+					continue
+				}
+				seen[enabler] = true
+				values[enabler] = vals
+				pending = append(pending, enabler)
+			}
+		}
+		all[fType] = values
+	}
+
+	return all
+}
+
+// exclusiveFields returns true if the two fields are never enabled at
+// the same time, because they are enabled by different values of a
+// common enabling field.  Such fields may share the same bits.
+func exclusiveFields(enablers map[string]map[string][]string, fType1, fType2 string) bool {
+	for enabler, values1 := range enablers[fType1] {
+		values2, ok := enablers[fType2][enabler]
+		if !ok {
+			continue
+		}
+
+		common := false
+		for _, v1 := range values1 {
+			for _, v2 := range values2 {
+				if v1 == v2 {
+					common = true
+				}
+			}
+		}
+		if !common {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,122 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+package layout
+
+import (
+	"strings"
+	"testing"
+)
+
+// testLayout returns a layout of one codeplug holding a record of two
+// one byte fields, each with a single value enabled by a mode field.
+func testLayout() *Layout {
+	return &Layout{
+		Codeplugs: []*Codeplug{{
+			Type:        "TEST",
+			RdtSize:     64,
+			RecordTypes: []string{"A", "B"},
+		}},
+		Records: []*Record{
+			{Type: "A", Offset: 0, Size: 4, Max: 4, FieldTypes: []string{"Mode", "X", "Y"}},
+			{Type: "B", Offset: 16, Size: 8, FieldTypes: []string{"Z"}},
+		},
+		Fields: []*Field{
+			{Type: "Mode", ValueType: "onOff", BitOffset: 0, BitSize: 1, Enables: []Enable{
+				{Value: "On", Enables: []string{"X"}},
+				{Value: "Off", Enables: []string{"Y"}},
+			}},
+			{Type: "X", ValueType: "ascii", BitOffset: 8, BitSize: 8},
+			{Type: "Y", ValueType: "ascii", BitOffset: 8, BitSize: 8},
+			{Type: "Z", ValueType: "ascii", BitOffset: 0, BitSize: 8, Max: 8},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		change   func(l *Layout)
+		problems []string
+	}{
+		{"valid", func(l *Layout) {}, nil},
+		{"field overlap", func(l *Layout) {
+			l.Fields[3].BitOffset = 0
+			l.Fields[0].Enables = nil
+		}, []string{"record A: field X overlaps field Y"}},
+		{"same enabling value", func(l *Layout) {
+			l.Fields[0].Enables[1].Value = "On"
+		}, []string{"record A: field X overlaps field Y"}},
+		{"field past record", func(l *Layout) {
+			l.Fields[3].Max = 9
+		}, []string{"record B: field Z: bits 0-71 extend past the record's 8 bytes"}},
+		{"cross byte", func(l *Layout) {
+			l.Fields[0].BitOffset = 7
+			l.Fields[0].BitSize = 2
+		}, []string{"record A: field Mode: bits 7-8 cross a byte boundary",
+			"record A: field Mode overlaps field X",
+			"record A: field Mode overlaps field Y"}},
+		{"unaligned", func(l *Layout) {
+			l.Fields[1].BitOffset = 12
+		}, []string{"record A: field X: multi-byte ascii value is not byte aligned"}},
+		{"record overlap", func(l *Layout) {
+			l.Records[1].Offset = 15
+		}, []string{"codeplug TEST: record A overlaps record B"}},
+		{"record past rdtSize", func(l *Layout) {
+			l.Records[1].Offset = 60
+		}, []string{"codeplug TEST: record B ends at 68, past rdtSize 64"}},
+		{"undefined field", func(l *Layout) {
+			l.Records[1].FieldTypes = append(l.Records[1].FieldTypes, "W")
+		}, []string{"record B: undefined field type W"}},
+		{"undefined list type", func(l *Layout) {
+			l.Fields[3].ListType = "C"
+		}, []string{"record B: field Z: undefined list record type C",
+			"codeplug TEST: field Z references C, which the codeplug lacks"}},
+		{"extension overlap", func(l *Layout) {
+			for _, f := range l.Fields[1:3] {
+				f.Max = 4
+				f.ExtIndex = 1
+				f.ExtOffset = 32
+				f.ExtSize = 8
+			}
+			l.Fields[2].BitOffset = 16
+		}, []string{"codeplug TEST: extension of field X overlaps extension of field Y"}},
+		{"extension past rdtSize", func(l *Layout) {
+			l.Fields[1].Max = 2
+			l.Fields[1].ExtIndex = 1
+			l.Fields[1].ExtOffset = 48
+			l.Fields[1].ExtSize = 8
+		}, []string{"codeplug TEST: field X extension ends at 80, past rdtSize 64"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			l := testLayout()
+			test.change(l)
+			problems := l.Check()
+			if strings.Join(problems, "\n") != strings.Join(test.problems, "\n") {
+				t.Errorf("got problems:\n\t%s\nwant:\n\t%s",
+					strings.Join(problems, "\n\t"),
+					strings.Join(test.problems, "\n\t"))
+			}
+		})
+	}
+}
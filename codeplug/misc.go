@@ -32,6 +32,7 @@ import (
 	"math/rand"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 	"unicode/utf16"
@@ -50,8 +51,12 @@ const (
 )
 
 var src = rand.NewSource(time.Now().UnixNano())
+var srcMutex sync.Mutex
 
 func RandomString(n int) string {
+	srcMutex.Lock()
+	defer srcMutex.Unlock()
+
 	b := make([]byte, n)
 	// A src.Int63() generates 63 random bits,
 	// enough for letterIdxMax characters!
@@ -96,6 +101,175 @@ func AddSuffix(f *Field, str string) string {
 	return str
 }
 
+// importName returns the name by which an imported record of type
+// rType will be referenced, given its name in the imported file and the
+// names already used by records of that type.
+func (cp *Codeplug) importName(rType RecordType, name string, used map[string]bool) string {
+	if rType == RtContacts && !cp.uniqueContactNames {
+		if name != "" {
+			name += "_" + RandomString(contactSuffixLength)
+		}
+	} else if used[name] {
+		base := name
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s%d", base, i)
+		}
+	}
+	used[name] = true
+
+	return name
+}
+
+// importContactName returns the name by which an imported contact will
+// be referenced.  When contact names carry a unique suffix, the suffix
+// of an existing contact with the same call ID is kept, so that names
+// referring to that contact remain valid.
+func (cp *Codeplug) importContactName(name string, callID string, used map[string]bool) string {
+	if !cp.uniqueContactNames && name != "" {
+		r := cp.findContactByCallID(callID)
+		if r != nil {
+			oldName := r.Name()
+			suffix := oldName[len(removeSuffix(r.NameField(), oldName)):]
+			if suffix != "" && !used[name+suffix] {
+				name += suffix
+				used[name] = true
+				return name
+			}
+		}
+	}
+
+	return cp.importName(RtContacts, name, used)
+}
+
+// findContactByCallID returns the first contact with the given call ID,
+// or nil if there is none.
+func (cp *Codeplug) findContactByCallID(callID string) *Record {
+	if callID == "" || !cp.HasRecordType(RtContacts) {
+		return nil
+	}
+
+	for _, r := range cp.records(RtContacts) {
+		f := r.Field(FtDcCallID)
+		if f != nil && f.String() == callID {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// An importRef is a reference from a field of a record left in place by
+// an import to a record of a type that the import replaces.
+type importRef struct {
+	field  *Field
+	name   string
+	callID string
+}
+
+// importRefs returns the references to records of the types replaced
+// by storing records, from the fields of records of other types.
+func (cp *Codeplug) importRefs(records []*Record) []importRef {
+	var rTypes []RecordType
+	replaced := make(map[RecordType]bool)
+	for _, r := range records {
+		if !replaced[r.rType] {
+			replaced[r.rType] = true
+			rTypes = append(rTypes, r.rType)
+		}
+	}
+
+	var refs []importRef
+	for _, rType := range rTypes {
+		if !cp.HasRecordType(rType) {
+			continue
+		}
+
+		for _, fRef := range fieldRefsTo(rType) {
+			if replaced[fRef.rType] || !cp.HasRecordType(fRef.rType) {
+				continue
+			}
+
+			for _, f := range cp.fields(fRef.rType, fRef.fType) {
+				if f.listRecordType != rType {
+					continue
+				}
+
+				r := cp.FindRecordByName(rType, f.String())
+				if r == nil {
+					continue
+				}
+
+				ref := importRef{field: f, name: r.Name()}
+				if rType == RtContacts {
+					if idField := r.Field(FtDcCallID); idField != nil {
+						ref.callID = idField.String()
+					}
+				}
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+// restoreImportRefs points each reference at the imported contact with
+// the same call ID or, failing that, the imported record with the same
+// name.  References to records that weren't imported are removed.  A
+// description of each removed or changed reference is returned.
+func (cp *Codeplug) restoreImportRefs(refs []importRef) (removed []string) {
+	for _, ref := range refs {
+		f := ref.field
+		rType := f.listRecordType
+
+		r := cp.findContactByCallID(ref.callID)
+		if r == nil {
+			r = cp.FindRecordByName(rType, ref.name)
+		}
+		if r != nil {
+			err := f.SetString(r.Name())
+			if err == nil {
+				continue
+			}
+		}
+
+		what := fmt.Sprintf("%s %s", rType, ref.name)
+		if ref.callID != "" {
+			what = fmt.Sprintf("%s with call ID %s", rType, ref.callID)
+		}
+		msg := fmt.Sprintf("%s: %s not imported, ", f.FullTypeName(), what)
+
+		if f.max > 1 {
+			r := f.record
+			change := r.RemoveFieldsChange([]*Field{f})
+			err := r.RemoveField(f)
+			if err != nil {
+				removed = append(removed, msg+err.Error())
+				continue
+			}
+			change.Complete()
+			removed = append(removed, msg+"reference removed")
+			continue
+		}
+
+		// Fields with no empty value refer to the first record instead.
+		err := f.SetString(f.defaultValue)
+		if err != nil && len(f.Strings()) > 0 {
+			value := f.Strings()[0]
+			f.SetString(value)
+			if r := cp.FindRecordByName(rType, value); r != nil {
+				value = removeSuffix(r.NameField(), value)
+			}
+			msg += "reference changed to " + value
+		} else {
+			msg += "reference removed"
+		}
+		removed = append(removed, msg)
+	}
+
+	return removed
+}
+
 func removeSuffix(f *Field, str string) string {
 	if f.Codeplug().uniqueContactNames {
 		return str
@@ -377,3 +551,41 @@ func maxNamesString(names []string, max int) string {
 	}
 	return strings.Join(names, ", ")
 }
+
+// unsupportedFields returns a description of each field of the given
+// record type that is set to other than its default value but is not
+// among the supported field types.
+func (cp *Codeplug) unsupportedFields(rType RecordType, fTypes []FieldType) []string {
+	supported := make(map[FieldType]bool)
+	for _, fType := range fTypes {
+		supported[fType] = true
+	}
+
+	var unsupportedTypes []FieldType
+	counts := make(map[FieldType]int)
+	for _, r := range cp.records(rType) {
+		for _, fType := range r.FieldTypes() {
+			if supported[fType] {
+				continue
+			}
+			f := r.Field(fType)
+			if f == nil || f.max > 1 || f.defaultValue == "" {
+				continue
+			}
+			if !f.IsEnabled() || f.String() == f.defaultValue {
+				continue
+			}
+			if counts[fType] == 0 {
+				unsupportedTypes = append(unsupportedTypes, fType)
+			}
+			counts[fType]++
+		}
+	}
+
+	var unsupported []string
+	for _, fType := range unsupportedTypes {
+		unsupported = append(unsupported, fmt.Sprintf("%s.%s: not supported, set in %d records", rType, fType, counts[fType]))
+	}
+
+	return unsupported
+}
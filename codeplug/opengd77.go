@@ -0,0 +1,302 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// OpenGD77's CPS imports a codeplug as a set of CSV files: Channels,
+// Contacts, TG_Lists and Zones.  Records are referred to by name.
+
+// OpenGD77's limits on the number of records and their members.
+const (
+	openGD77MaxChannels     = 1024
+	openGD77MaxContacts     = 1024
+	openGD77MaxTGLists      = 76
+	openGD77MaxTGListLength = 32
+	openGD77MaxZones        = 68
+	openGD77MaxZoneLength   = 80
+)
+
+// openGD77ChannelFieldTypes lists the channel fields that are
+// represented in OpenGD77 CSV files.
+var openGD77ChannelFieldTypes = []FieldType{
+	FtCiName,
+	FtCiChannelMode,
+	FtCiRxFrequency,
+	FtCiTxFrequencyOffset,
+	FtCiBandwidth,
+	FtCiColorCode,
+	FtCiRepeaterSlot,
+	FtCiContactName,
+	FtCiGroupList,
+	FtCiCtcssDecode,
+	FtCiCtcssEncode,
+	FtCiPower,
+	FtCiRxOnly,
+	FtCiTot,
+	FtCiVox,
+}
+
+// openGD77Values maps codeplug field values to OpenGD77 values, for
+// those fields where they differ.
+var openGD77Values = map[FieldType]map[string]string{
+	FtCiChannelMode: map[string]string{
+		"Analog":  "Analogue",
+		"Digital": "Digital",
+	},
+	FtCiPower: map[string]string{
+		"Low":    "P5",
+		"Medium": "P7",
+		"High":   "P9",
+	},
+	FtCiRxOnly: map[string]string{
+		"Off": "No",
+		"On":  "Yes",
+	},
+	FtCiTot: map[string]string{
+		"Infinite": "0",
+	},
+	FtDcCallType: map[string]string{
+		"Group":   "Group",
+		"Private": "Private",
+		"All":     "AllCall",
+	},
+}
+
+// toOpenGD77 returns the OpenGD77 value for a field's value.
+func toOpenGD77(f *Field) string {
+	if v, ok := openGD77Values[f.fType][f.String()]; ok {
+		return v
+	}
+
+	return f.String()
+}
+
+// writeOpenGD77File writes rows to the named CSV file in dir.
+func writeOpenGD77File(dir string, name string, rows [][]string) (err error) {
+	file, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	w := csv.NewWriter(file)
+	w.UseCRLF = true
+	err = w.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+
+	return w.Error()
+}
+
+// ExportOpenGD77 writes the codeplug into dir, creating it if
+// necessary, as the set of CSV files imported by OpenGD77's CPS:
+// Channels.csv, Contacts.csv, TG_Lists.csv and Zones.csv.  Records and
+// members beyond OpenGD77's limits are left out.  A description of
+// each of the codeplug's settings that is not exported is returned.
+func (cp *Codeplug) ExportOpenGD77(dir string) (unsupported []string, err error) {
+	report := func(format string, v ...interface{}) {
+		unsupported = append(unsupported, fmt.Sprintf(format, v...))
+	}
+
+	// limit returns at most max of the given records.
+	limit := func(rType RecordType, records []*Record, max int) []*Record {
+		if len(records) > max {
+			report("%s: only the first %d of %d are exported", rType, max, len(records))
+			records = records[:max]
+		}
+		return records
+	}
+
+	contactName := func(name string) string {
+		c := cp.FindRecordByName(RtContacts, name)
+		if c == nil {
+			return "None"
+		}
+		return removeSuffix(c.NameField(), c.Name())
+	}
+
+	contacts := limit(RtContacts, cp.records(RtContacts), openGD77MaxContacts)
+	exported := make(map[string]bool)
+	contactRows := [][]string{{"Contact Name", "ID", "ID Type", "TS Override"}}
+	for _, r := range contacts {
+		exported[r.Name()] = true
+		contactRows = append(contactRows, []string{
+			contactName(r.Name()),
+			r.Field(FtDcCallID).String(),
+			toOpenGD77(r.Field(FtDcCallType)),
+			"Disabled",
+		})
+	}
+
+	tgListHeader := []string{"TG List Name"}
+	for i := 1; i <= openGD77MaxTGListLength; i++ {
+		tgListHeader = append(tgListHeader, fmt.Sprintf("Contact%d", i))
+	}
+	tgListRows := [][]string{tgListHeader}
+	tgLists := make(map[string]bool)
+	for _, r := range limit(RtGroupLists, cp.records(RtGroupLists), openGD77MaxTGLists) {
+		tgLists[r.Name()] = true
+		row := []string{r.Name()}
+		for _, f := range r.Fields(FtGlContact) {
+			if !exported[f.String()] {
+				continue
+			}
+			if len(row) > openGD77MaxTGListLength {
+				report("%s %s: only the first %d contacts are exported", r.rType, r.Name(), openGD77MaxTGListLength)
+				break
+			}
+			row = append(row, contactName(f.String()))
+		}
+		for len(row) < len(tgListHeader) {
+			row = append(row, "")
+		}
+		tgListRows = append(tgListRows, row)
+	}
+
+	channelRows := [][]string{{
+		"Channel Number", "Channel Name", "Channel Type",
+		"Rx Frequency", "Tx Frequency", "Bandwidth (kHz)",
+		"Colour Code", "Timeslot", "Contact", "TG List", "DMR ID",
+		"TS1_TA_Tx", "TS2_TA_Tx ID", "RX Tone", "TX Tone",
+		"Squelch", "Power", "Rx Only", "Zone Skip", "All Skip",
+		"TOT", "VOX", "No Beep", "No Eco", "APRS",
+	}}
+	channels := make(map[string]bool)
+	for i, r := range limit(RtChannels_md380, cp.records(RtChannels_md380), openGD77MaxChannels) {
+		channels[r.Name()] = true
+		rxFreq, _ := strconv.ParseFloat(r.Field(FtCiRxFrequency).String(), 64)
+		offset, _ := strconv.ParseFloat(r.Field(FtCiTxFrequencyOffset).String(), 64)
+
+		var bandwidth, colorCode, slot, contact, tgList, rxTone, txTone string
+		if r.Field(FtCiChannelMode).String() == "Digital" {
+			colorCode = r.Field(FtCiColorCode).String()
+			slot = r.Field(FtCiRepeaterSlot).String()
+			contact = contactName(r.Field(FtCiContactName).String())
+			tgList = "None"
+			if name := r.Field(FtCiGroupList).String(); tgLists[name] {
+				tgList = name
+			}
+		} else {
+			bandwidth = r.Field(FtCiBandwidth).String()
+			if bandwidth != "12.5" && bandwidth != "25" {
+				report("%s %s: %s KHz bandwidth is not supported", r.rType, r.Name(), bandwidth)
+				bandwidth = "25"
+			}
+			rxTone = r.Field(FtCiCtcssDecode).String()
+			txTone = r.Field(FtCiCtcssEncode).String()
+		}
+
+		channelRows = append(channelRows, []string{
+			strconv.Itoa(i + 1),
+			r.Name(),
+			toOpenGD77(r.Field(FtCiChannelMode)),
+			frequencyToString(rxFreq),
+			frequencyToString(rxFreq + offset),
+			bandwidth, colorCode, slot, contact, tgList,
+			"None", "Off", "Off", rxTone, txTone,
+			"Disabled",
+			toOpenGD77(r.Field(FtCiPower)),
+			toOpenGD77(r.Field(FtCiRxOnly)),
+			"No", "No",
+			toOpenGD77(r.Field(FtCiTot)),
+			r.Field(FtCiVox).String(),
+			"No", "No", "None",
+		})
+	}
+
+	zoneHeader := []string{"Zone Name"}
+	for i := 1; i <= openGD77MaxZoneLength; i++ {
+		zoneHeader = append(zoneHeader, fmt.Sprintf("Channel%d", i))
+	}
+	zoneRows := [][]string{zoneHeader}
+	for _, r := range limit(RtZones_md380, cp.records(RtZones_md380), openGD77MaxZones) {
+		var fields []*Field
+		fields = append(fields, r.Fields(FtZiChannel_md380)...)
+		fields = append(fields, r.Fields(FtZiChannelA_uv380)...)
+		fields = append(fields, r.Fields(FtZiChannelB_uv380)...)
+
+		row := []string{r.Name()}
+		seen := make(map[string]bool)
+		for _, f := range fields {
+			name := f.String()
+			if !channels[name] || seen[name] {
+				continue
+			}
+			if len(row) > openGD77MaxZoneLength {
+				report("%s %s: only the first %d channels are exported", r.rType, r.Name(), openGD77MaxZoneLength)
+				break
+			}
+			seen[name] = true
+			row = append(row, name)
+		}
+		for len(row) < len(zoneHeader) {
+			row = append(row, "")
+		}
+		zoneRows = append(zoneRows, row)
+	}
+
+	if n := len(cp.records(RtScanLists_md380)); n > 0 {
+		report("%s: not supported, %d records", RtScanLists_md380, n)
+	}
+	unsupported = append(unsupported, cp.unsupportedFields(RtChannels_md380, openGD77ChannelFieldTypes)...)
+
+	files := []struct {
+		name string
+		rows [][]string
+	}{
+		{"Channels.csv", channelRows},
+		{"Contacts.csv", contactRows},
+		{"TG_Lists.csv", tgListRows},
+		{"Zones.csv", zoneRows},
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return unsupported, err
+	}
+
+	for _, file := range files {
+		err = writeOpenGD77File(dir, file.name, file.rows)
+		if err != nil {
+			return unsupported, err
+		}
+	}
+
+	return unsupported, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportOpenGD77(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		tmp, cleanup := tempDir(t)
+		defer cleanup()
+
+		dir := filepath.Join(tmp, "opengd77")
+		unsupported, err := cp.ExportOpenGD77(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range unsupported {
+			t.Log("export:", msg)
+		}
+
+		checkFiles(t, dir, "Channels.csv", "Contacts.csv", "TG_Lists.csv", "Zones.csv")
+	})
+}
+
+func TestExportOpenGD77Values(t *testing.T) {
+	cp := exportTestCodeplug(t)
+	tmp, cleanup := tempDir(t)
+	defer cleanup()
+
+	low := NewChannel_uv380(cp.Records(RtChannels_md380)[0])
+	err := low.SetPower(CiPower_uv380Low)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsupported, err := cp.ExportOpenGD77(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range unsupported {
+		t.Log("export:", msg)
+	}
+
+	channels := readExportedCSV(t, tmp, "Channels.csv", "Channel Name")
+	checkColumns(t, "DMR Rpt", channels["DMR Rpt"], map[string]string{
+		"Channel Type": "Digital",
+		"Rx Frequency": frequencyToString(cp.lowFrequency + 10),
+		"Tx Frequency": frequencyToString(cp.lowFrequency + 10.6),
+		"Colour Code":  "3",
+		"Timeslot":     "2",
+		"Contact":      "Worldwide",
+		"TG List":      "Local",
+		"RX Tone":      "",
+		"Power":        "P9",
+	})
+	checkColumns(t, "FM Rpt", channels["FM Rpt"], map[string]string{
+		"Channel Type":    "Analogue",
+		"Bandwidth (kHz)": "25",
+		"Colour Code":     "",
+		"Contact":         "",
+		"RX Tone":         "D023N",
+		"TX Tone":         "100.0",
+		"Power":           "P7",
+	})
+	checkColumns(t, low.Name(), channels[low.Name()], map[string]string{
+		"Power": "P5",
+	})
+
+	contacts := readExportedCSV(t, tmp, "Contacts.csv", "Contact Name")
+	checkColumns(t, "Worldwide", contacts["Worldwide"], map[string]string{
+		"ID":      "91",
+		"ID Type": "Group",
+	})
+
+	tgLists := readExportedCSV(t, tmp, "TG_Lists.csv", "TG List Name")
+	checkColumns(t, "Local", tgLists["Local"], map[string]string{
+		"Contact1": "Worldwide",
+		"Contact2": "",
+	})
+
+	zones := readExportedCSV(t, tmp, "Zones.csv", "Zone Name")
+	checkColumns(t, "Both", zones["Both"], map[string]string{
+		"Channel1": "DMR Rpt",
+		"Channel2": "FM Rpt",
+		"Channel3": "",
+	})
+}
+
+func TestExportOpenGD77LongZone(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-UV380")
+	tmp, cleanup := tempDir(t)
+	defer cleanup()
+
+	zone := NewZone_uv380(insertTestRecord(t, cp, RtZones_md380, "Long"))
+	maxA := zone.Record().MaxFields(FtZiChannelA_uv380)
+	maxB := zone.Record().MaxFields(FtZiChannelB_uv380)
+	if maxA+maxB <= openGD77MaxZoneLength {
+		t.Fatalf("zones hold only %d channels", maxA+maxB)
+	}
+
+	var channels []*Channel_uv380
+	for i := 0; i <= openGD77MaxZoneLength; i++ {
+		r := insertTestRecord(t, cp, RtChannels_md380, fmt.Sprintf("Ch %d", i))
+		channels = append(channels, NewChannel_uv380(r))
+	}
+	err := zone.SetChannelA(channels[:maxA])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zone.SetChannelB(channels[maxA:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsupported, err := cp.ExportOpenGD77(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf("%s Long: only the first %d channels are exported",
+		RtZones_md380, openGD77MaxZoneLength)
+	found := false
+	for _, msg := range unsupported {
+		if msg == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("got unsupported %q, want %q", unsupported, want)
+	}
+
+	zones := readExportedCSV(t, tmp, "Zones.csv", "Zone Name")
+	last := fmt.Sprintf("Channel%d", openGD77MaxZoneLength)
+	checkColumns(t, "Long", zones["Long"], map[string]string{
+		"Channel1": "Ch 0",
+		last:       fmt.Sprintf("Ch %d", openGD77MaxZoneLength-1),
+	})
+	dropped := fmt.Sprintf("Ch %d", openGD77MaxZoneLength)
+	for column, value := range zones["Long"] {
+		if value == dropped {
+			t.Errorf("%s is %s, past the zone's limit", column, value)
+		}
+	}
+}
@@ -0,0 +1,1060 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v2"
+)
+
+// qdmr is the YAML codeplug format used by the qdmr programming
+// software.  Records are referred to by id in qdmr, and by name in a
+// codeplug.  Fields of the records qdmr represents that have no qdmr
+// equivalent are kept in "tyt" extension blocks, so that they survive
+// a round trip.
+
+const (
+	qdmrVersion   = "0.9.0"
+	qdmrExtension = "tyt"
+)
+
+// qdmrIDPrefixes holds the prefix of the ids given to each record type.
+var qdmrIDPrefixes = map[RecordType]string{
+	RtChannels_md380:  "ch",
+	RtZones_md380:     "zone",
+	RtScanLists_md380: "scan",
+	RtContacts:        "cont",
+	RtGroupLists:      "grp",
+	RtGPSSystems:      "gps",
+}
+
+// qdmrRecordTypes lists the record types replaced by a qdmr import.
+// The codeplug's records of other types are left untouched.
+var qdmrRecordTypes = []RecordType{
+	RtChannels_md380,
+	RtZones_md380,
+	RtScanLists_md380,
+	RtContacts,
+	RtGroupLists,
+	RtGPSSystems,
+}
+
+// qdmrRadioIDs lists the general settings fields holding radio IDs.
+var qdmrRadioIDs = []FieldType{
+	FtGsRadioID,
+	FtGsRadioID1,
+	FtGsRadioID2,
+	FtGsRadioID3,
+}
+
+// qdmrValues maps codeplug field values to qdmr values, for those
+// fields where they differ.
+var qdmrValues = map[FieldType]map[string]interface{}{
+	FtCiAdmitCriteria: map[string]interface{}{
+		"Always":       "Always",
+		"Channel free": "Free",
+		"CTCSS/DCS":    "Tone",
+		"Color code":   "ColorCode",
+	},
+	FtCiPower: map[string]interface{}{
+		"Low":    "Low",
+		"Medium": "Mid",
+		"High":   "High",
+	},
+	FtCiRepeaterSlot: map[string]interface{}{
+		"1": "TS1",
+		"2": "TS2",
+	},
+	FtCiRxOnly: map[string]interface{}{
+		"Off": false,
+		"On":  true,
+	},
+	FtCiBandwidth: map[string]interface{}{
+		"12.5": "Narrow",
+		"25":   "Wide",
+	},
+	FtDcCallType: map[string]interface{}{
+		"Group":   "GroupCall",
+		"Private": "PrivateCall",
+		"All":     "AllCall",
+	},
+	FtDcCallReceiveTone: map[string]interface{}{
+		"No":  false,
+		"Yes": true,
+	},
+}
+
+// toQdmr returns the qdmr value for a codeplug field value.
+func toQdmr(fType FieldType, value string) (interface{}, bool) {
+	v, ok := qdmrValues[fType][value]
+	return v, ok
+}
+
+// fromQdmr returns the codeplug field value for a qdmr value.
+func fromQdmr(fType FieldType, value interface{}) (string, bool) {
+	for k, v := range qdmrValues[fType] {
+		if v == value {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+// qdmrKey returns the extension block key for a field type.
+func qdmrKey(fType FieldType) string {
+	r, size := utf8.DecodeRuneInString(string(fType))
+	return string(unicode.ToLower(r)) + string(fType)[size:]
+}
+
+// qdmrFieldName returns the field type name for an extension block key.
+func qdmrFieldName(key string) string {
+	r, size := utf8.DecodeRuneInString(key)
+	return string(unicode.ToUpper(r)) + key[size:]
+}
+
+// qdmrFrequency rounds a frequency in MHz to the nearest 10 Hz.
+func qdmrFrequency(freq float64) float64 {
+	return math.Round(freq*1e5) / 1e5
+}
+
+// qdmrWriter holds the state used while exporting a qdmr file.
+type qdmrWriter struct {
+	cp  *Codeplug
+	ids map[RecordType]map[string]string
+}
+
+// qdmrRecord accumulates the qdmr representation of a record, noting
+// which of the record's fields have been represented.
+type qdmrRecord struct {
+	w    *qdmrWriter
+	r    *Record
+	m    yaml.MapSlice
+	used map[FieldType]bool
+}
+
+func (w *qdmrWriter) newRecord(r *Record) *qdmrRecord {
+	qr := &qdmrRecord{
+		w:    w,
+		r:    r,
+		used: make(map[FieldType]bool),
+	}
+
+	if prefix, ok := qdmrIDPrefixes[r.rType]; ok {
+		qr.add("id", fmt.Sprintf("%s%d", prefix, r.rIndex+1))
+	}
+	if f := r.NameField(); f != nil {
+		qr.used[f.fType] = true
+		qr.add("name", removeSuffix(f, f.String()))
+	}
+
+	return qr
+}
+
+func (qr *qdmrRecord) add(key string, value interface{}) {
+	qr.m = append(qr.m, yaml.MapItem{Key: key, Value: value})
+}
+
+// value adds a field whose qdmr value is given by qdmrValues.
+func (qr *qdmrRecord) value(key string, fType FieldType) {
+	f := qr.r.Field(fType)
+	if f == nil {
+		return
+	}
+	v, ok := toQdmr(fType, f.String())
+	if ok {
+		qr.used[fType] = true
+		qr.add(key, v)
+	}
+}
+
+// number adds a field with an integer value.  Values matching zero
+// are represented in qdmr by 0.
+func (qr *qdmrRecord) number(key string, fType FieldType, zero string) {
+	f := qr.r.Field(fType)
+	if f == nil {
+		return
+	}
+	str := f.String()
+	if str == zero {
+		str = "0"
+	}
+	n, err := strconv.Atoi(str)
+	if err == nil {
+		qr.used[fType] = true
+		qr.add(key, n)
+	}
+}
+
+// ref adds a field referring to another record by its qdmr id.
+func (qr *qdmrRecord) ref(key string, fType FieldType, none string) {
+	f := qr.r.Field(fType)
+	if f == nil {
+		return
+	}
+	if f.String() == none {
+		qr.used[fType] = true
+		return
+	}
+	if id, ok := qr.w.ids[f.listRecordType][f.String()]; ok {
+		qr.used[fType] = true
+		qr.add(key, id)
+	}
+}
+
+// refs adds the fields of a type as a list of qdmr ids.
+func (qr *qdmrRecord) refs(key string, fType FieldType) {
+	fields := qr.r.Fields(fType)
+	if len(fields) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(fields))
+	for _, f := range fields {
+		id, ok := qr.w.ids[f.listRecordType][f.String()]
+		if !ok {
+			return
+		}
+		ids = append(ids, id)
+	}
+	qr.used[fType] = true
+	qr.add(key, ids)
+}
+
+// tone adds a CTCSS/DCS field.
+func (qr *qdmrRecord) tone(key string, fType FieldType) {
+	f := qr.r.Field(fType)
+	if f == nil {
+		return
+	}
+	str := f.String()
+	if str == "None" {
+		qr.used[fType] = true
+		return
+	}
+
+	var tone yaml.MapSlice
+	if strings.HasPrefix(str, "D") && len(str) == 5 {
+		code, err := strconv.Atoi(str[1:4])
+		if err != nil {
+			return
+		}
+		if str[4] == 'I' {
+			code = -code
+		}
+		tone = yaml.MapSlice{{Key: "dcs", Value: code}}
+	} else {
+		freq, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return
+		}
+		tone = yaml.MapSlice{{Key: "ctcss", Value: freq}}
+	}
+	qr.used[fType] = true
+	qr.add(key, tone)
+}
+
+// extension returns the record's fields that have not been represented,
+// in a form suitable for an extension block.
+func (qr *qdmrRecord) extension() yaml.MapSlice {
+	var ext yaml.MapSlice
+	for _, fType := range qr.r.FieldTypes() {
+		if qr.used[fType] {
+			continue
+		}
+		fields := qr.r.Fields(fType)
+		if len(fields) == 0 || !fields[0].IsEnabled() {
+			continue
+		}
+		if qr.r.MaxFields(fType) == 1 {
+			ext = append(ext, yaml.MapItem{Key: qdmrKey(fType), Value: fields[0].String()})
+			continue
+		}
+		strs := make([]string, len(fields))
+		for i, f := range fields {
+			strs[i] = f.String()
+		}
+		ext = append(ext, yaml.MapItem{Key: qdmrKey(fType), Value: strs})
+	}
+
+	return ext
+}
+
+// mapSlice returns the record's qdmr representation, including the
+// extension block holding its remaining fields.
+func (qr *qdmrRecord) mapSlice() yaml.MapSlice {
+	if ext := qr.extension(); len(ext) > 0 {
+		qr.add(qdmrExtension, ext)
+	}
+
+	return qr.m
+}
+
+// ExportQdmr writes the codeplug to filename in qdmr's YAML format.
+func (cp *Codeplug) ExportQdmr(filename string) (err error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	bytes, err := yaml.Marshal(cp.qdmrDocument())
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(file)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func (cp *Codeplug) qdmrDocument() yaml.MapSlice {
+	w := &qdmrWriter{
+		cp:  cp,
+		ids: make(map[RecordType]map[string]string),
+	}
+	// records returns the codeplug's records of type rType, if it
+	// has that type.
+	records := func(rType RecordType) []*Record {
+		if !cp.HasRecordType(rType) {
+			return nil
+		}
+		return cp.records(rType)
+	}
+
+	for rType, prefix := range qdmrIDPrefixes {
+		w.ids[rType] = make(map[string]string)
+		for i, r := range records(rType) {
+			w.ids[rType][r.Name()] = fmt.Sprintf("%s%d", prefix, i+1)
+		}
+	}
+
+	doc := yaml.MapSlice{
+		{Key: "version", Value: qdmrVersion},
+	}
+	add := func(key string, value interface{}) {
+		doc = append(doc, yaml.MapItem{Key: key, Value: value})
+	}
+
+	var radioIDs []yaml.MapSlice
+	if gs := cp.record(RtGeneralSettings_md380); gs != nil {
+		qr := w.newRecord(gs)
+		qr.used[FtGsRadioName] = true
+		name := ""
+		if f := gs.Field(FtGsRadioName); f != nil {
+			name = f.String()
+		}
+		for _, fType := range qdmrRadioIDs {
+			f := gs.Field(fType)
+			if f == nil {
+				continue
+			}
+			qr.used[fType] = true
+			number, _ := strconv.Atoi(f.String())
+			radioIDs = append(radioIDs, yaml.MapSlice{
+				{Key: "dmr", Value: yaml.MapSlice{
+					{Key: "id", Value: fmt.Sprintf("id%d", len(radioIDs)+1)},
+					{Key: "name", Value: name},
+					{Key: "number", Value: number},
+				}},
+			})
+		}
+		if len(radioIDs) > 0 {
+			qr.add("defaultID", "id1")
+		}
+		for i, fType := range []FieldType{FtGsIntroScreenLine1, FtGsIntroScreenLine2} {
+			if f := gs.Field(fType); f != nil {
+				qr.used[fType] = true
+				qr.add(fmt.Sprintf("introLine%d", i+1), f.String())
+			}
+		}
+		add("settings", qr.mapSlice())
+	}
+	add("radioIDs", radioIDs)
+
+	var contacts []yaml.MapSlice
+	for _, r := range records(RtContacts) {
+		qr := w.newRecord(r)
+		qr.value("type", FtDcCallType)
+		qr.number("number", FtDcCallID, "")
+		qr.value("ring", FtDcCallReceiveTone)
+		contacts = append(contacts, yaml.MapSlice{
+			{Key: "dmr", Value: qr.mapSlice()},
+		})
+	}
+	add("contacts", contacts)
+
+	var groupLists []yaml.MapSlice
+	for _, r := range records(RtGroupLists) {
+		qr := w.newRecord(r)
+		qr.refs("contacts", FtGlContact)
+		groupLists = append(groupLists, qr.mapSlice())
+	}
+	add("groupLists", groupLists)
+
+	var channels []yaml.MapSlice
+	for _, r := range records(RtChannels_md380) {
+		channels = append(channels, w.channel(r))
+	}
+	add("channels", channels)
+
+	var zones []yaml.MapSlice
+	for _, r := range records(RtZones_md380) {
+		qr := w.newRecord(r)
+		qr.refs("A", FtZiChannel_md380)
+		qr.refs("A", FtZiChannelA_uv380)
+		qr.refs("B", FtZiChannelB_uv380)
+		zones = append(zones, qr.mapSlice())
+	}
+	add("zones", zones)
+
+	var scanLists []yaml.MapSlice
+	for _, r := range records(RtScanLists_md380) {
+		qr := w.newRecord(r)
+		for _, ch := range []struct {
+			key   string
+			fType FieldType
+			none  string
+		}{
+			{"primary", FtSlPriorityChannel1_md380, "None"},
+			{"secondary", FtSlPriorityChannel2_md380, "None"},
+			{"revert", FtSlTxDesignatedChannel_md380, "Last Active Channel"},
+		} {
+			f := r.Field(ch.fType)
+			if f != nil && f.String() == "Selected" {
+				qr.used[ch.fType] = true
+				qr.add(ch.key, "selected")
+				continue
+			}
+			qr.ref(ch.key, ch.fType, ch.none)
+		}
+		qr.refs("channels", FtSlChannel_md380)
+		scanLists = append(scanLists, qr.mapSlice())
+	}
+	add("scanLists", scanLists)
+
+	var positioning []yaml.MapSlice
+	for _, r := range records(RtGPSSystems) {
+		qr := w.newRecord(r)
+		qr.add("name", r.Name())
+		qr.ref("destination", FtGpDestinationID, "None")
+		qr.number("period", FtGpGPSDefaultReportInterval, "Off")
+		qr.ref("revert", FtGpGPSRevertChannel, "Current Channel")
+		positioning = append(positioning, yaml.MapSlice{
+			{Key: "dmr", Value: qr.mapSlice()},
+		})
+	}
+	add("positioning", positioning)
+
+	return doc
+}
+
+func (w *qdmrWriter) channel(r *Record) yaml.MapSlice {
+	qr := w.newRecord(r)
+
+	rx, err := strconv.ParseFloat(r.Field(FtCiRxFrequency).String(), 64)
+	if err == nil {
+		qr.used[FtCiRxFrequency] = true
+		qr.add("rxFrequency", qdmrFrequency(rx))
+
+		offset, err := strconv.ParseFloat(r.Field(FtCiTxFrequencyOffset).String(), 64)
+		if err == nil {
+			qr.used[FtCiTxFrequencyOffset] = true
+			qr.add("txFrequency", qdmrFrequency(rx+offset))
+		}
+	}
+	qr.value("rxOnly", FtCiRxOnly)
+	qr.value("admit", FtCiAdmitCriteria)
+	qr.value("power", FtCiPower)
+	qr.number("timeout", FtCiTot, "Infinite")
+	qr.ref("scanList", FtCiScanList_md380, "None")
+
+	mode := "analog"
+	qr.used[FtCiChannelMode] = true
+	if r.Field(FtCiChannelMode).String() == "Digital" {
+		mode = "digital"
+		qr.number("colorCode", FtCiColorCode, "")
+		qr.value("timeSlot", FtCiRepeaterSlot)
+		qr.ref("groupList", FtCiGroupList, "None")
+		qr.ref("contact", FtCiContactName, "None")
+		if f := r.Field(FtCiGPSSystem); f != nil {
+			n, err := strconv.Atoi(f.String())
+			switch {
+			case f.String() == "None":
+				qr.used[FtCiGPSSystem] = true
+			case err == nil && n <= len(w.ids[RtGPSSystems]):
+				qr.used[FtCiGPSSystem] = true
+				qr.add("aprs", fmt.Sprintf("gps%d", n))
+			}
+		}
+	} else {
+		qr.number("squelch", FtCiSquelch, "")
+		qr.tone("rxTone", FtCiCtcssDecode)
+		qr.tone("txTone", FtCiCtcssEncode)
+		qr.value("bandwidth", FtCiBandwidth)
+	}
+
+	return yaml.MapSlice{
+		{Key: mode, Value: qr.mapSlice()},
+	}
+}
+
+// qdmrObject is a qdmr YAML mapping, with the path used to refer to it
+// in reports.
+type qdmrObject struct {
+	path string
+	m    map[interface{}]interface{}
+}
+
+// qdmrString returns the string form of a qdmr scalar value.
+func qdmrString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// qdmrList returns the elements of a qdmr sequence value.
+func qdmrList(v interface{}) []interface{} {
+	list, _ := v.([]interface{})
+	return list
+}
+
+// qdmrMap returns a qdmr mapping value, or nil.
+func qdmrMap(v interface{}) map[interface{}]interface{} {
+	m, _ := v.(map[interface{}]interface{})
+	return m
+}
+
+// qdmrTypedObjects returns the objects of a qdmr list whose elements
+// are single-key mappings from an object type to the object.
+func qdmrTypedObjects(path string, v interface{}) (types []string, objects []*qdmrObject) {
+	for i, elem := range qdmrList(v) {
+		for k, v := range qdmrMap(elem) {
+			types = append(types, qdmrString(k))
+			objects = append(objects, &qdmrObject{
+				path: fmt.Sprintf("%s[%d]", path, i),
+				m:    qdmrMap(v),
+			})
+		}
+	}
+
+	return types, objects
+}
+
+// qdmrObjects returns the objects of a qdmr list of mappings.
+func qdmrObjects(path string, v interface{}) []*qdmrObject {
+	var objects []*qdmrObject
+	for i, elem := range qdmrList(v) {
+		objects = append(objects, &qdmrObject{
+			path: fmt.Sprintf("%s[%d]", path, i),
+			m:    qdmrMap(elem),
+		})
+	}
+
+	return objects
+}
+
+func (o *qdmrObject) get(key string) interface{} {
+	return o.m[key]
+}
+
+func (o *qdmrObject) str(key string) string {
+	return qdmrString(o.m[key])
+}
+
+// ImportQdmr replaces the codeplug's channels, zones, scan lists,
+// contacts, group lists and GPS systems with those read from a qdmr
+// YAML file.  Fields held in the file's extension blocks are restored.
+// References from other records to the replaced records are kept where
+// the referenced record was imported, and removed otherwise.
+// It returns a description of each of the file's settings that have no
+// codeplug equivalent, and of each removed reference.
+func (cp *Codeplug) ImportQdmr(reader io.Reader) (unsupported []string, err error) {
+	report := func(path string, format string, v ...interface{}) {
+		unsupported = append(unsupported, path+": "+fmt.Sprintf(format, v...))
+	}
+
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid qdmr file: %s", err.Error())
+	}
+
+	settings := &qdmrObject{path: "settings", m: qdmrMap(doc["settings"])}
+	_, radioIDs := qdmrTypedObjects("radioIDs", doc["radioIDs"])
+	contactTypes, contacts := qdmrTypedObjects("contacts", doc["contacts"])
+	groupLists := qdmrObjects("groupLists", doc["groupLists"])
+	channelTypes, channels := qdmrTypedObjects("channels", doc["channels"])
+	zones := qdmrObjects("zones", doc["zones"])
+	scanLists := qdmrObjects("scanLists", doc["scanLists"])
+	gpsKey := "positioning"
+	if doc[gpsKey] == nil {
+		gpsKey = "gpsSystems"
+	}
+	gpsTypes, gpsSystems := qdmrTypedObjects(gpsKey, doc[gpsKey])
+
+	handled := map[string]bool{
+		"version":     true,
+		"settings":    true,
+		"radioIDs":    true,
+		"contacts":    true,
+		"groupLists":  true,
+		"channels":    true,
+		"zones":       true,
+		"scanLists":   true,
+		"positioning": true,
+		"gpsSystems":  true,
+	}
+	var keys []string
+	for k := range doc {
+		if !handled[qdmrString(k)] {
+			keys = append(keys, qdmrString(k))
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		report(key, "not supported")
+	}
+
+	// Discard the objects of types with no codeplug equivalent.
+	filter := func(types []string, objects []*qdmrObject, allowed ...string) []*qdmrObject {
+		var filtered []*qdmrObject
+		for i, o := range objects {
+			ok := false
+			for _, t := range allowed {
+				if types[i] == t {
+					ok = true
+				}
+			}
+			if !ok {
+				report(o.path, "%s is not supported", types[i])
+				continue
+			}
+			filtered = append(filtered, o)
+		}
+		return filtered
+	}
+	contacts = filter(contactTypes, contacts, "dmr")
+	digital := make(map[*qdmrObject]bool)
+	for i, o := range channels {
+		if channelTypes[i] == "digital" {
+			digital[o] = true
+		}
+	}
+	channels = filter(channelTypes, channels, "digital", "analog")
+	gpsSystems = filter(gpsTypes, gpsSystems, "dmr")
+
+	// Discard the objects of record types the codeplug doesn't have.
+	present := func(rType RecordType, objects []*qdmrObject) []*qdmrObject {
+		if cp.HasRecordType(rType) {
+			return objects
+		}
+		for _, o := range objects {
+			report(o.path, "not supported")
+		}
+		return nil
+	}
+	contacts = present(RtContacts, contacts)
+	groupLists = present(RtGroupLists, groupLists)
+	channels = present(RtChannels_md380, channels)
+	zones = present(RtZones_md380, zones)
+	scanLists = present(RtScanLists_md380, scanLists)
+	gpsSystems = present(RtGPSSystems, gpsSystems)
+
+	// Map each object's id to the unique name by which it will be
+	// referenced.
+	names := make(map[RecordType]map[string]string)
+	nameRecords := func(rType RecordType, objects []*qdmrObject) {
+		names[rType] = make(map[string]string)
+		used := make(map[string]bool)
+		for i, o := range objects {
+			name := o.str("name")
+			if rType == RtGPSSystems {
+				name = strconv.Itoa(i + 1)
+			} else {
+				var unique string
+				if rType == RtContacts {
+					unique = cp.importContactName(name, o.str("number"), used)
+				} else {
+					unique = cp.importName(rType, name, used)
+				}
+				renamed := rType != RtContacts || cp.uniqueContactNames
+				if renamed && unique != name {
+					report(o.path, "duplicate name %s renamed to %s", name, unique)
+				}
+				name = unique
+			}
+			names[rType][o.str("id")] = name
+		}
+	}
+	nameRecords(RtChannels_md380, channels)
+	nameRecords(RtZones_md380, zones)
+	nameRecords(RtScanLists_md380, scanLists)
+	nameRecords(RtContacts, contacts)
+	nameRecords(RtGroupLists, groupLists)
+	nameRecords(RtGPSSystems, gpsSystems)
+
+	var pRecs []*parsedRecord
+	var pRec *parsedRecord
+	var obj *qdmrObject
+	var objKeys map[string]bool
+	counts := make(map[RecordType]int)
+
+	addField := func(fType FieldType, index int, value string) {
+		pRec.pFields = append(pRec.pFields, &parsedField{
+			name:  string(fType),
+			index: index,
+			value: value,
+		})
+	}
+
+	// addExtension adds the fields held in an object's extension block.
+	addExtension := func(o *qdmrObject) {
+		ext := qdmrMap(o.get(qdmrExtension))
+		var keys []string
+		for k := range ext {
+			keys = append(keys, qdmrString(k))
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			v := ext[key]
+			if list, ok := v.([]interface{}); ok {
+				for i, elem := range list {
+					addField(FieldType(qdmrFieldName(key)), i, qdmrString(elem))
+				}
+				continue
+			}
+			addField(FieldType(qdmrFieldName(key)), 0, qdmrString(v))
+		}
+	}
+
+	// endRecord reports the keys of the current object that were not used.
+	endRecord := func() {
+		if obj == nil {
+			return
+		}
+		addExtension(obj)
+		var keys []string
+		for k, v := range obj.m {
+			key := qdmrString(k)
+			if objKeys[key] || qdmrString(v) == "default" || v == nil {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			report(obj.path, "%s is not supported", key)
+		}
+		obj = nil
+	}
+
+	addRecord := func(rType RecordType, o *qdmrObject) {
+		endRecord()
+		pRec = &parsedRecord{
+			name:  string(rType),
+			index: counts[rType],
+		}
+		counts[rType]++
+		pRecs = append(pRecs, pRec)
+		obj = o
+		objKeys = map[string]bool{
+			"id":          true,
+			"name":        true,
+			qdmrExtension: true,
+		}
+		if rType != RtGPSSystems {
+			addField(FtCiName, 0, names[rType][o.str("id")])
+		}
+	}
+
+	has := func(key string) bool {
+		objKeys[key] = true
+		v := obj.get(key)
+		return v != nil && qdmrString(v) != "default"
+	}
+
+	value := func(key string, fType FieldType) {
+		if !has(key) {
+			return
+		}
+		str, ok := fromQdmr(fType, obj.get(key))
+		if !ok {
+			report(obj.path, "%s: unsupported value %s", key, obj.str(key))
+			return
+		}
+		addField(fType, 0, str)
+	}
+
+	number := func(key string, fType FieldType, zero string) {
+		if !has(key) {
+			return
+		}
+		str := obj.str(key)
+		if str == "0" && zero != "" {
+			str = zero
+		}
+		addField(fType, 0, str)
+	}
+
+	ref := func(key string, fType FieldType, rType RecordType, none string) {
+		if !has(key) {
+			addField(fType, 0, none)
+			return
+		}
+		name, ok := names[rType][obj.str(key)]
+		if !ok {
+			report(obj.path, "%s: %s not found", key, obj.str(key))
+			name = none
+		}
+		addField(fType, 0, name)
+	}
+
+	refs := func(key string, fType FieldType, rType RecordType) {
+		if !has(key) {
+			return
+		}
+		for i, v := range qdmrList(obj.get(key)) {
+			name, ok := names[rType][qdmrString(v)]
+			if !ok {
+				report(obj.path, "%s: %s not found", key, qdmrString(v))
+				continue
+			}
+			addField(fType, i, name)
+		}
+	}
+
+	tone := func(key string, fType FieldType) {
+		if !has(key) {
+			addField(fType, 0, "None")
+			return
+		}
+		t := qdmrMap(obj.get(key))
+		switch {
+		case t["ctcss"] != nil:
+			freq, err := strconv.ParseFloat(qdmrString(t["ctcss"]), 64)
+			if err != nil {
+				report(obj.path, "%s: bad ctcss frequency", key)
+				return
+			}
+			addField(fType, 0, fmt.Sprintf("%.1f", freq))
+		case t["dcs"] != nil:
+			code, err := strconv.Atoi(qdmrString(t["dcs"]))
+			if err != nil {
+				report(obj.path, "%s: bad dcs code", key)
+				return
+			}
+			polarity := "N"
+			if code < 0 {
+				code = -code
+				polarity = "I"
+			}
+			addField(fType, 0, fmt.Sprintf("D%03d%s", code, polarity))
+		default:
+			addField(fType, 0, "None")
+		}
+	}
+
+	for _, o := range contacts {
+		addRecord(RtContacts, o)
+		value("type", FtDcCallType)
+		number("number", FtDcCallID, "")
+		value("ring", FtDcCallReceiveTone)
+	}
+
+	for _, o := range groupLists {
+		addRecord(RtGroupLists, o)
+		refs("contacts", FtGlContact, RtContacts)
+	}
+
+	for _, o := range channels {
+		addRecord(RtChannels_md380, o)
+		if has("rxFrequency") {
+			addField(FtCiRxFrequency, 0, o.str("rxFrequency"))
+		}
+		if has("txFrequency") {
+			rx, _ := strconv.ParseFloat(o.str("rxFrequency"), 64)
+			tx, err := strconv.ParseFloat(o.str("txFrequency"), 64)
+			if err == nil {
+				addField(FtCiTxFrequencyOffset, 0, frequencyToSignedString(qdmrFrequency(tx-rx)))
+			}
+		}
+		value("rxOnly", FtCiRxOnly)
+		value("admit", FtCiAdmitCriteria)
+		value("power", FtCiPower)
+		number("timeout", FtCiTot, "Infinite")
+		ref("scanList", FtCiScanList_md380, RtScanLists_md380, "None")
+		if digital[o] {
+			addField(FtCiChannelMode, 0, "Digital")
+			number("colorCode", FtCiColorCode, "")
+			value("timeSlot", FtCiRepeaterSlot)
+			ref("groupList", FtCiGroupList, RtGroupLists, "None")
+			ref("contact", FtCiContactName, RtContacts, "None")
+			ref("aprs", FtCiGPSSystem, RtGPSSystems, "None")
+		} else {
+			addField(FtCiChannelMode, 0, "Analog")
+			number("squelch", FtCiSquelch, "")
+			tone("rxTone", FtCiCtcssDecode)
+			tone("txTone", FtCiCtcssEncode)
+			value("bandwidth", FtCiBandwidth)
+		}
+	}
+
+	for _, o := range zones {
+		addRecord(RtZones_md380, o)
+		refs("A", FtZiChannel_md380, RtChannels_md380)
+		refs("B", FtZiChannelB_uv380, RtChannels_md380)
+	}
+
+	for _, o := range scanLists {
+		addRecord(RtScanLists_md380, o)
+		for _, ch := range []struct {
+			key   string
+			fType FieldType
+			none  string
+		}{
+			{"primary", FtSlPriorityChannel1_md380, "None"},
+			{"secondary", FtSlPriorityChannel2_md380, "None"},
+			{"revert", FtSlTxDesignatedChannel_md380, "Last Active Channel"},
+		} {
+			if o.str(ch.key) == "selected" {
+				objKeys[ch.key] = true
+				addField(ch.fType, 0, "Selected")
+				continue
+			}
+			ref(ch.key, ch.fType, RtChannels_md380, ch.none)
+		}
+		refs("channels", FtSlChannel_md380, RtChannels_md380)
+	}
+
+	for _, o := range gpsSystems {
+		addRecord(RtGPSSystems, o)
+		ref("destination", FtGpDestinationID, RtContacts, "None")
+		number("period", FtGpGPSDefaultReportInterval, "Off")
+		ref("revert", FtGpGPSRevertChannel, RtChannels_md380, "Current Channel")
+	}
+	endRecord()
+
+	deferValues := false
+	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
+	if _, warning := err.(Warning); warning {
+		for _, msg := range strings.Split(err.Error(), "\n") {
+			if msg != "" {
+				unsupported = append(unsupported, msg)
+			}
+		}
+		err = nil
+	}
+	if err != nil {
+		return unsupported, err
+	}
+
+	importRefs := cp.importRefs(records)
+
+	err = cp.storeParsedRecords(records)
+	if err != nil {
+		return unsupported, err
+	}
+	cp.AddMissingFields()
+
+	unsupported = append(unsupported, cp.restoreImportRefs(importRefs)...)
+
+	if gs := cp.record(RtGeneralSettings_md380); gs != nil {
+		set := func(path string, fType FieldType, value string) {
+			f := gs.Field(fType)
+			if f == nil {
+				report(path, "not supported")
+				return
+			}
+			err := f.SetString(value)
+			if err != nil {
+				report(path, "%s", err.Error())
+			}
+		}
+
+		for i, o := range radioIDs {
+			if i >= len(qdmrRadioIDs) {
+				report(o.path, "not supported")
+				continue
+			}
+			set(o.path, qdmrRadioIDs[i], o.str("number"))
+			if i == 0 && o.str("name") != "" {
+				set(o.path+".name", FtGsRadioName, o.str("name"))
+			}
+		}
+		for i, fType := range []FieldType{FtGsIntroScreenLine1, FtGsIntroScreenLine2} {
+			key := fmt.Sprintf("introLine%d", i+1)
+			if settings.get(key) != nil {
+				set("settings."+key, fType, settings.str(key))
+			}
+		}
+		ext := qdmrMap(settings.get(qdmrExtension))
+		for k, v := range ext {
+			key := qdmrString(k)
+			fType, err := cp.nameToFt(gs.rType, qdmrFieldName(key))
+			if err != nil {
+				report("settings."+qdmrExtension+"."+key, "%s", err.Error())
+				continue
+			}
+			set("settings."+qdmrExtension+"."+key, fType, qdmrString(v))
+		}
+	}
+
+	return unsupported, nil
+}
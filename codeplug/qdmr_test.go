@@ -0,0 +1,118 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestQdmrRoundTrip(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		data, err := yaml.Marshal(cp.qdmrDocument())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		known := make(map[RecordType]bool)
+		for _, rType := range qdmrRecordTypes {
+			known[rType] = true
+		}
+		var others []RecordType
+		for _, rType := range cp.RecordTypes() {
+			if !known[rType] {
+				others = append(others, rType)
+			}
+		}
+		before := recordStrings(cp, others...)
+		imported := recordStrings(cp, qdmrRecordTypes...)
+
+		unsupported, err := cp.ImportQdmr(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, msg := range unsupported {
+			t.Log("import:", msg)
+		}
+
+		checkValid(t, cp)
+
+		if !reflect.DeepEqual(imported, recordStrings(cp, qdmrRecordTypes...)) {
+			t.Error("imported records differ from those exported")
+		}
+
+		after := recordStrings(cp, others...)
+		if !reflect.DeepEqual(before, after) {
+			for i := range before {
+				if i < len(after) && before[i] != after[i] {
+					t.Errorf("record changed:\n%s\n%s", before[i], after[i])
+				}
+			}
+			if len(before) != len(after) {
+				t.Errorf("%d records, want %d", len(after), len(before))
+			}
+		}
+	})
+}
+
+func TestQdmrImportRemovesReferences(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+
+	var calls []*Field
+	for _, r := range cp.records(RtOneTouch) {
+		f := r.Field(FtOtCall)
+		if f != nil && cp.FindRecordByName(RtContacts, f.String()) != nil {
+			calls = append(calls, f)
+		}
+	}
+	if len(calls) == 0 {
+		t.Skip("no one touch calls")
+	}
+
+	text := "contacts:\n" +
+		"- dmr: {id: cont1, name: Other, type: GroupCall, number: 99999}\n"
+	unsupported, err := cp.ImportQdmr(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkValid(t, cp)
+
+	reported := 0
+	for _, msg := range unsupported {
+		if strings.HasPrefix(msg, calls[0].FullTypeName()) &&
+			strings.Contains(msg, "reference changed to Other") {
+			reported++
+		}
+	}
+	if reported == 0 {
+		t.Errorf("removed one touch calls not reported: %q", unsupported)
+	}
+}
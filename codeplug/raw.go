@@ -0,0 +1,563 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// rawRecordName is the name of the pseudo-record holding a codeplug's
+// raw values in text and JSON files.
+const rawRecordName = "Raw"
+
+// A rawValue holds bits of a codeplug's bytes that are accessed
+// directly, rather than through a field definition.  The bits begin
+// bitOffset bits past offset, numbering from the most significant bit
+// of each byte, as in a fieldInfo.  Their value is right-aligned in
+// bytes.  The offset of a codeplug's raw value is relative to the start
+// of the codeplug's bytes and that of a record's raw value is relative
+// to the start of the record.
+type rawValue struct {
+	offset    int
+	bitOffset int
+	bitSize   int
+	bytes     []byte
+}
+
+// newRawValue returns a raw value whose bits lie within the first size
+// bytes of a codeplug or record.
+func newRawValue(offset int, bitOffset int, bitSize int, size int) (*rawValue, error) {
+	if offset < 0 || bitOffset < 0 || bitSize <= 0 {
+		return nil, fmt.Errorf("bad raw offset %d, bitOffset %d, bitSize %d",
+			offset, bitOffset, bitSize)
+	}
+
+	rv := &rawValue{
+		offset:    offset + bitOffset/8,
+		bitOffset: bitOffset % 8,
+		bitSize:   bitSize,
+	}
+
+	if rv.offset+rv.size() > size {
+		return nil, fmt.Errorf("raw offset %d, bitOffset %d, bitSize %d exceeds size %d",
+			offset, bitOffset, bitSize, size)
+	}
+
+	return rv, nil
+}
+
+// size returns the number of bytes containing the raw value's bits.
+func (rv *rawValue) size() int {
+	return (rv.bitOffset + rv.bitSize + 7) / 8
+}
+
+// load sets the raw value from bytes, which begin at the raw value's
+// offset.
+func (rv *rawValue) load(bytes []byte) {
+	rv.bytes = make([]byte, (rv.bitSize+7)/8)
+	shift := len(rv.bytes)*8 - rv.bitSize
+	for i := 0; i < rv.bitSize; i++ {
+		src := rv.bitOffset + i
+		if bytes[src/8]&(0x80>>uint(src%8)) != 0 {
+			dst := shift + i
+			rv.bytes[dst/8] |= 0x80 >> uint(dst%8)
+		}
+	}
+}
+
+// store inserts the raw value into bytes, which begin at the raw
+// value's offset.
+func (rv *rawValue) store(bytes []byte) {
+	shift := len(rv.bytes)*8 - rv.bitSize
+	for i := 0; i < rv.bitSize; i++ {
+		src := shift + i
+		dst := rv.bitOffset + i
+		mask := byte(0x80 >> uint(dst%8))
+		if rv.bytes[src/8]&(0x80>>uint(src%8)) != 0 {
+			bytes[dst/8] |= mask
+		} else {
+			bytes[dst/8] &^= mask
+		}
+	}
+}
+
+// setBytes sets the raw value to value, which is right-aligned in
+// bytes.
+func (rv *rawValue) setBytes(value []byte) error {
+	if len(value) != (rv.bitSize+7)/8 {
+		return fmt.Errorf("raw value %x is not %d bytes", value, (rv.bitSize+7)/8)
+	}
+
+	shift := uint(len(value)*8 - rv.bitSize)
+	if shift != 0 && value[0]>>(8-shift) != 0 {
+		return fmt.Errorf("raw value %x is wider than %d bits", value, rv.bitSize)
+	}
+
+	rv.bytes = append([]byte(nil), value...)
+
+	return nil
+}
+
+// setUint64 sets the raw value to value.
+func (rv *rawValue) setUint64(value uint64) error {
+	if rv.bitSize > 64 {
+		return fmt.Errorf("raw bitSize %d is wider than 64 bits", rv.bitSize)
+	}
+
+	bytes := make([]byte, (rv.bitSize+7)/8)
+	for i := len(bytes) - 1; i >= 0; i-- {
+		bytes[i] = byte(value)
+		value >>= 8
+	}
+	if value != 0 {
+		return fmt.Errorf("raw value is wider than %d bits", rv.bitSize)
+	}
+
+	return rv.setBytes(bytes)
+}
+
+// uint64 returns the raw value as an unsigned integer.
+func (rv *rawValue) uint64() (uint64, error) {
+	if rv.bitSize > 64 {
+		return 0, fmt.Errorf("raw bitSize %d is wider than 64 bits", rv.bitSize)
+	}
+
+	var value uint64
+	for _, b := range rv.bytes {
+		value = value<<8 | uint64(b)
+	}
+
+	return value, nil
+}
+
+// copy returns a copy of the raw value that shares none of its storage.
+func (rv *rawValue) copy() *rawValue {
+	c := *rv
+	c.bytes = append([]byte(nil), rv.bytes...)
+
+	return &c
+}
+
+// sameBits returns true if the two raw values hold the same bits.
+func (rv *rawValue) sameBits(orv *rawValue) bool {
+	return rv.offset == orv.offset &&
+		rv.bitOffset == orv.bitOffset &&
+		rv.bitSize == orv.bitSize
+}
+
+// setRawValue replaces the raw value holding the same bits as rv in
+// raws, or appends rv to raws.  Later raw values are stored after, and
+// so take precedence over, earlier ones.
+func setRawValue(raws *[]*rawValue, rv *rawValue) {
+	for i, orv := range *raws {
+		if orv.sameBits(rv) {
+			(*raws)[i] = rv
+			return
+		}
+	}
+
+	*raws = append(*raws, rv)
+}
+
+// fieldBits returns, for each of the codeplug's bytes, a mask of the
+// bits contained in a field definition.
+func (cp *Codeplug) fieldBits() []byte {
+	if cp.cachedFieldBits != nil {
+		return cp.cachedFieldBits
+	}
+
+	bits := make([]byte, len(cp.bytes))
+	for _, ri := range cp.codeplugInfo.RecordInfos {
+		for rIndex := 0; rIndex < ri.max; rIndex++ {
+			for _, fi := range ri.fieldInfos {
+				mask := fi.byteMask()
+				for fIndex := 0; fIndex < fi.max; fIndex++ {
+					offset := fi.rdtOffset(ri, rIndex, fIndex)
+					end := offset + fi.size()
+					if end > len(bits) {
+						continue
+					}
+					for i := offset; i < end; i++ {
+						bits[i] |= mask
+					}
+				}
+			}
+		}
+	}
+	cp.cachedFieldBits = bits
+
+	return bits
+}
+
+// checkRawValue returns an error if the raw value, at offset within
+// the codeplug's bytes, holds any bits contained in a field definition.
+// The field's value would replace those bits when the codeplug is
+// stored.
+func (cp *Codeplug) checkRawValue(rv *rawValue, offset int) error {
+	bits := make([]byte, rv.size())
+	for i := 0; i < rv.bitSize; i++ {
+		bit := rv.bitOffset + i
+		bits[bit/8] |= 0x80 >> uint(bit%8)
+	}
+
+	fieldBits := cp.fieldBits()
+	for i, mask := range bits {
+		if fieldBits[offset+i]&mask != 0 {
+			return fmt.Errorf("raw bits at offset 0x%x are part of a field", offset+i)
+		}
+	}
+
+	return nil
+}
+
+// currentBytes returns a copy of the codeplug's bytes holding its
+// current (modified) state.
+func (cp *Codeplug) currentBytes() ([]byte, error) {
+	bytes := make([]byte, len(cp.bytes))
+	copy(bytes, cp.bytes)
+	saveBytes := cp.bytes
+	cp.bytes = bytes
+	err := cp.store()
+	cp.bytes = saveBytes
+
+	return bytes, err
+}
+
+// rawValue returns the raw value of the given bits of the codeplug's
+// current state.
+func (cp *Codeplug) rawValue(offset int, bitOffset int, bitSize int) (*rawValue, error) {
+	rv, err := newRawValue(offset, bitOffset, bitSize, len(cp.bytes))
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := cp.currentBytes()
+	if err != nil {
+		return nil, err
+	}
+	rv.load(bytes[rv.offset:])
+
+	return rv, nil
+}
+
+// setRawValue sets the given bits of the codeplug to the raw value
+// set by setValue.
+func (cp *Codeplug) setRawValue(offset int, bitOffset int, bitSize int, setValue func(*rawValue) error) error {
+	rv, err := newRawValue(offset, bitOffset, bitSize, len(cp.bytes))
+	if err != nil {
+		return err
+	}
+
+	err = cp.checkRawValue(rv, rv.offset)
+	if err != nil {
+		return err
+	}
+
+	err = setValue(rv)
+	if err != nil {
+		return err
+	}
+
+	setRawValue(&cp.raws, rv)
+
+	cp.record(RtBasicInformation_md380).RawChange().Complete()
+
+	return nil
+}
+
+// RawBytes returns size bytes of the codeplug's current state,
+// beginning at offset within its rdt bytes.
+func (cp *Codeplug) RawBytes(offset int, size int) ([]byte, error) {
+	rv, err := cp.rawValue(offset, 0, size*8)
+	if err != nil {
+		return nil, err
+	}
+
+	return rv.bytes, nil
+}
+
+// SetRawBytes sets the codeplug's bytes beginning at offset within its
+// rdt bytes.  Bytes that are part of a field definition can only be
+// changed through the field.
+//
+// The bytes are saved in rdt and bin files like any others, but which
+// bytes were set is not: text and JSON files include Raw records only
+// for values set since the codeplug was loaded.  To carry a value
+// through an rdt file into a text or JSON export, set it again after
+// reopening the file, for example to the value returned by RawBytes.
+func (cp *Codeplug) SetRawBytes(offset int, bytes []byte) error {
+	return cp.setRawValue(offset, 0, len(bytes)*8, func(rv *rawValue) error {
+		return rv.setBytes(bytes)
+	})
+}
+
+// RawBits returns the value of bitSize bits of the codeplug's current
+// state, beginning bitOffset bits past offset within its rdt bytes.
+// Bits are numbered from the most significant bit of each byte.
+func (cp *Codeplug) RawBits(offset int, bitOffset int, bitSize int) (uint64, error) {
+	rv, err := cp.rawValue(offset, bitOffset, bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return rv.uint64()
+}
+
+// SetRawBits sets bitSize bits of the codeplug, beginning bitOffset bits
+// past offset within its rdt bytes, to value.  Bits that are part of a
+// field definition can only be changed through the field.  As with
+// SetRawBytes, the value is exported as a Raw record only if it was set
+// since the codeplug was loaded.
+func (cp *Codeplug) SetRawBits(offset int, bitOffset int, bitSize int, value uint64) error {
+	return cp.setRawValue(offset, bitOffset, bitSize, func(rv *rawValue) error {
+		return rv.setUint64(value)
+	})
+}
+
+// rdtOffset returns the offset within the codeplug's bytes of the
+// record's bytes.
+func (r *Record) rdtOffset() int {
+	return r.offset + r.rIndex*r.size
+}
+
+// rawValue returns the raw value of the given bits of the record's
+// current state.
+func (r *Record) rawValue(offset int, bitOffset int, bitSize int) (*rawValue, error) {
+	rv, err := newRawValue(offset, bitOffset, bitSize, r.size)
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := r.codeplug.currentBytes()
+	if err != nil {
+		return nil, err
+	}
+	rv.load(bytes[r.rdtOffset()+rv.offset:])
+
+	return rv, nil
+}
+
+// setRawValue sets the given bits of the record to the raw value set
+// by setValue.
+func (r *Record) setRawValue(offset int, bitOffset int, bitSize int, setValue func(*rawValue) error) error {
+	rv, err := newRawValue(offset, bitOffset, bitSize, r.size)
+	if err != nil {
+		return err
+	}
+
+	err = r.codeplug.checkRawValue(rv, r.rdtOffset()+rv.offset)
+	if err != nil {
+		return err
+	}
+
+	err = setValue(rv)
+	if err != nil {
+		return err
+	}
+
+	setRawValue(&r.raws, rv)
+
+	r.RawChange().Complete()
+
+	return nil
+}
+
+// RawBytes returns size bytes of the record's current state, beginning
+// at offset within the record.
+func (r *Record) RawBytes(offset int, size int) ([]byte, error) {
+	rv, err := r.rawValue(offset, 0, size*8)
+	if err != nil {
+		return nil, err
+	}
+
+	return rv.bytes, nil
+}
+
+// SetRawBytes sets the record's bytes beginning at offset within the
+// record.  Bytes that are part of a field definition can only be
+// changed through the field.  As with Codeplug.SetRawBytes, the value
+// is exported as a Raw record only if it was set since the codeplug
+// was loaded.
+func (r *Record) SetRawBytes(offset int, bytes []byte) error {
+	return r.setRawValue(offset, 0, len(bytes)*8, func(rv *rawValue) error {
+		return rv.setBytes(bytes)
+	})
+}
+
+// RawBits returns the value of bitSize bits of the record's current
+// state, beginning bitOffset bits past offset within the record.
+// Bits are numbered from the most significant bit of each byte.
+func (r *Record) RawBits(offset int, bitOffset int, bitSize int) (uint64, error) {
+	rv, err := r.rawValue(offset, bitOffset, bitSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return rv.uint64()
+}
+
+// SetRawBits sets bitSize bits of the record, beginning bitOffset bits
+// past offset within the record, to value.  Bits that are part of a
+// field definition can only be changed through the field.  As with the
+// record's SetRawBytes, a value set before the codeplug was loaded is
+// not exported.
+func (r *Record) SetRawBits(offset int, bitOffset int, bitSize int, value uint64) error {
+	return r.setRawValue(offset, bitOffset, bitSize, func(rv *rawValue) error {
+		return rv.setUint64(value)
+	})
+}
+
+// storeRaws inserts the record's raw values into cp.bytes.
+func (r *Record) storeRaws() {
+	bytes := r.codeplug.bytes[r.rdtOffset():]
+	for _, rv := range r.raws {
+		rv.store(bytes[rv.offset:])
+	}
+}
+
+// allRaws returns the codeplug's raw values and those of its records,
+// with offsets relative to the start of the codeplug's bytes.  Only
+// values set since the codeplug was loaded are known.
+func (cp *Codeplug) allRaws() []*rawValue {
+	var raws []*rawValue
+	for _, rType := range cp.RecordTypes() {
+		for _, r := range cp.records(rType) {
+			for _, rv := range r.raws {
+				crv := *rv
+				crv.offset += r.rdtOffset()
+				raws = append(raws, &crv)
+			}
+		}
+	}
+
+	return append(raws, cp.raws...)
+}
+
+// printRaw writes the raw value to w as a Raw pseudo-record.
+func printRaw(w io.Writer, rv *rawValue) {
+	fmt.Fprintf(w, "%s:\n", rawRecordName)
+	fmt.Fprintf(w, "\tOffset: 0x%x\n", rv.offset)
+	fmt.Fprintf(w, "\tBitOffset: %d\n", rv.bitOffset)
+	fmt.Fprintf(w, "\tBitSize: %d\n", rv.bitSize)
+	fmt.Fprintf(w, "\tValue: %x\n", rv.bytes)
+}
+
+// printOneLineRaw writes the raw value to w as a Raw pseudo-record on
+// a single line.
+func printOneLineRaw(w io.Writer, rv *rawValue) {
+	fmt.Fprintf(w, "%s:", rawRecordName)
+	fmt.Fprintf(w, "\tOffset: 0x%x", rv.offset)
+	fmt.Fprintf(w, "\tBitOffset: %d", rv.bitOffset)
+	fmt.Fprintf(w, "\tBitSize: %d", rv.bitSize)
+	fmt.Fprintf(w, "\tValue: %x", rv.bytes)
+	fmt.Fprintln(w)
+}
+
+// rawFieldMap returns the raw value's pseudo-fields as a JSON object.
+func rawFieldMap(rv *rawValue) map[string]interface{} {
+	return map[string]interface{}{
+		"Offset":    fmt.Sprintf("0x%x", rv.offset),
+		"BitOffset": strconv.Itoa(rv.bitOffset),
+		"BitSize":   strconv.Itoa(rv.bitSize),
+		"Value":     hex.EncodeToString(rv.bytes),
+	}
+}
+
+// parseRawRecords removes the Raw pseudo-records from pRecs and returns
+// the remaining records and the raw values of the pseudo-records.
+func (cp *Codeplug) parseRawRecords(pRecs []*parsedRecord) ([]*parsedRecord, []*rawValue, error) {
+	var records []*parsedRecord
+	var raws []*rawValue
+
+	for _, pr := range pRecs {
+		if pr.err != nil || pr.name != rawRecordName {
+			records = append(records, pr)
+			continue
+		}
+
+		rv, err := cp.parseRawRecord(pr)
+		if err != nil {
+			if pr.pos != nil {
+				err = fmt.Errorf("line %d:%d: %s", pr.pos.line+1, pr.pos.column+1, err.Error())
+			}
+			return nil, nil, err
+		}
+
+		setRawValue(&raws, rv)
+	}
+
+	return records, raws, nil
+}
+
+// parseRawRecord returns the raw value described by a Raw pseudo-record.
+func (cp *Codeplug) parseRawRecord(pr *parsedRecord) (*rawValue, error) {
+	values := make(map[string]string)
+	for _, pf := range pr.pFields {
+		if pf.err != nil {
+			return nil, pf.err
+		}
+
+		switch pf.name {
+		case "Offset", "BitOffset", "BitSize", "Value":
+		default:
+			return nil, fmt.Errorf("%s: unknown field type: %s", rawRecordName, pf.name)
+		}
+
+		values[pf.name] = pf.value
+	}
+
+	ints := make(map[string]int)
+	for _, name := range []string{"Offset", "BitOffset", "BitSize"} {
+		i, err := strconv.ParseInt(values[name], 0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: bad value '%s'", rawRecordName, name, values[name])
+		}
+		ints[name] = int(i)
+	}
+
+	rv, err := newRawValue(ints["Offset"], ints["BitOffset"], ints["BitSize"], len(cp.bytes))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", rawRecordName, err.Error())
+	}
+
+	err = cp.checkRawValue(rv, rv.offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", rawRecordName, err.Error())
+	}
+
+	bytes, err := hex.DecodeString(values["Value"])
+	if err == nil {
+		err = rv.setBytes(bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s.Value: bad value '%s'", rawRecordName, values["Value"])
+	}
+
+	return rv, nil
+}
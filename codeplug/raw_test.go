@@ -0,0 +1,232 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// unusedByte returns the offset of a codeplug byte that no field uses
+// and the record containing it.
+func unusedByte(t *testing.T, cp *Codeplug) (int, *Record) {
+	t.Helper()
+
+	bits := cp.fieldBits()
+	for _, rType := range cp.RecordTypes() {
+		for _, r := range cp.Records(rType) {
+			for i := 0; i < r.size; i++ {
+				if bits[r.rdtOffset()+i] == 0 {
+					return r.rdtOffset() + i, r
+				}
+			}
+		}
+	}
+
+	t.Fatal("no unused byte")
+	return 0, nil
+}
+
+func TestRawRoundTrip(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	offset, _ := unusedByte(t, cp)
+
+	err := cp.SetRawBits(offset, 2, 4, 0xa)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []struct {
+		name  string
+		write func(cp *Codeplug, b *strings.Builder) error
+		read  func(cp *Codeplug, s string) error
+	}{
+		{"text",
+			func(cp *Codeplug, b *strings.Builder) error { return cp.WriteText(b) },
+			func(cp *Codeplug, s string) error { return cp.ImportText(strings.NewReader(s)) }},
+		{"json",
+			func(cp *Codeplug, b *strings.Builder) error { return cp.WriteJSON(b) },
+			func(cp *Codeplug, s string) error { return cp.importJSON(strings.NewReader(s)) }},
+	} {
+		t.Run(format.name, func(t *testing.T) {
+			var b strings.Builder
+			err := format.write(cp, &b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ncp := newTestCodeplug(t, "MD-380")
+			err = format.read(ncp, b.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value, err := ncp.RawBits(offset, 2, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value != 0xa {
+				t.Errorf("got raw value %#x, want 0xa", value)
+			}
+		})
+	}
+}
+
+func TestRawFieldBits(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	r := cp.Records(RtBasicInformation_md380)[0]
+	f := r.Field(FtBiModel)
+
+	err := cp.SetRawBytes(r.rdtOffset()+f.bitOffset/8, []byte{0})
+	if err == nil {
+		t.Error("raw change of a field's bits succeeded")
+	}
+}
+
+func TestImportTextBadRecordSkipsRaws(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	offset, _ := unusedByte(t, cp)
+
+	before, err := cp.RawBits(offset, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := fmt.Sprintf("Raw:\n\tOffset: 0x%x\n\tBitOffset: 0\n\tBitSize: 8\n\tValue: %02x\n"+
+		"Bogus:\n\tName: x\n", offset, ^byte(before))
+	err = cp.ImportText(strings.NewReader(text))
+	if err == nil {
+		t.Fatal("import of a bad record succeeded")
+	}
+
+	after, err := cp.RawBits(offset, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after != before {
+		t.Errorf("failed import changed raw byte from %#x to %#x", before, after)
+	}
+}
+
+func TestRecordCopyRaws(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	offset, r := unusedByte(t, cp)
+	offset -= r.rdtOffset()
+
+	err := r.SetRawBits(offset, 0, 8, 0x12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := r.Copy()
+	if len(c.raws) != len(r.raws) {
+		t.Fatalf("copy has %d raw values, want %d", len(c.raws), len(r.raws))
+	}
+	for i, rv := range c.raws {
+		if rv == r.raws[i] || &rv.bytes[0] == &r.raws[i].bytes[0] {
+			t.Errorf("copy shares raw value %d with the original", i)
+		}
+	}
+
+	err = c.SetRawBits(offset, 0, 8, 0x34)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := r.RawBits(offset, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 0x12 {
+		t.Errorf("changing the copy changed the original's raw value to %#x", value)
+	}
+}
+
+func TestRawReopen(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	offset, _ := unusedByte(t, cp)
+
+	err := cp.SetRawBits(offset, 0, 8, 0x5a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rdt bytes.Buffer
+	err = cp.WriteRdt(&rdt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	freqRange := AllFrequencyRanges()[cp.Type()][0]
+	ncp, err := ReadCodeplug(FileTypeRdt, cp.Type(), freqRange, bytes.NewReader(rdt.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bytes are saved, but not which of them were set.
+	value, err := ncp.RawBits(offset, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 0x5a {
+		t.Errorf("got raw value %#x after reopening, want 0x5a", value)
+	}
+
+	var text strings.Builder
+	err = ncp.WriteText(&text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(text.String(), rawRecordName+":") {
+		t.Error("reopened codeplug exported a Raw record")
+	}
+
+	// Setting the value again exports it.
+	err = ncp.SetRawBits(offset, 0, 8, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text.Reset()
+	err = ncp.WriteText(&text)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	icp := newTestCodeplug(t, "MD-380")
+	err = icp.ImportText(strings.NewReader(text.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err = icp.RawBits(offset, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 0x5a {
+		t.Errorf("got raw value %#x after import, want 0x5a", value)
+	}
+}
@@ -30,8 +30,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-
-	l "github.com/dalefarnsworth/codeplug/debug"
 )
 
 // A Record represents a record within a Codeplug.
@@ -39,6 +37,7 @@ type Record struct {
 	*rDesc
 	fDesc  *map[FieldType]*fDesc
 	rIndex int
+	raws   []*rawValue
 }
 
 // An rDesc contains a record type's dynamic information.
@@ -47,6 +46,7 @@ type rDesc struct {
 	codeplug        *Codeplug
 	records         []*Record
 	cachedListNames *[]string
+	index           int
 }
 
 // A recordInfo contains a record type's static information.
@@ -59,7 +59,6 @@ type recordInfo struct {
 	delDesc       *delDesc
 	fieldInfos    []*fieldInfo
 	nameFieldType FieldType
-	index         int
 	namePrefix    string
 	names         []string
 }
@@ -127,15 +126,15 @@ func (r *Record) NewField(fType FieldType) *Field {
 	fd := (*r.fDesc)[fType]
 	f := new(Field)
 	if fd == nil {
-		for _, fi := range r.rDesc.fieldInfos {
+		for i, fi := range r.rDesc.fieldInfos {
 			if fi.fType == fType {
-				fd = &fDesc{fi, r, make([]*Field, 0)}
+				fd = &fDesc{fi, r, make([]*Field, 0), i}
 				break
 			}
 		}
 		if fd == nil {
 			// bad field type
-			fd = &fDesc{r.rDesc.fieldInfos[0], r, make([]*Field, 0)}
+			fd = &fDesc{r.rDesc.fieldInfos[0], r, make([]*Field, 0), 0}
 		}
 		fd.record = r
 		fd.fields = make([]*Field, 0)
@@ -166,18 +165,9 @@ func (r *Record) addField(f *Field) error {
 func (r *Record) load() {
 	ri := r.rDesc.recordInfo
 
-	for i := range ri.fieldInfos {
-		fi := ri.fieldInfos[i]
-		fi.index = i
-		if fi.max == 0 {
-			fi.max = 1
-		}
-		fi.recordInfo = ri
-		fd := &fDesc{fieldInfo: fi}
+	for i, fi := range ri.fieldInfos {
+		fd := &fDesc{fieldInfo: fi, index: i}
 		(*r.fDesc)[fi.fType] = fd
-		if fi.valueType == VtName || fi.valueType == VtContactName {
-			ri.nameFieldType = fi.fType
-		}
 		fd.record = r
 	}
 
@@ -199,16 +189,6 @@ func (r *Record) load() {
 
 				f.load()
 
-				span := f.span
-				if span != nil {
-					if span.scale == 0 {
-						span.scale = 1
-					}
-					if span.interval == 0 {
-						span.interval = 1
-					}
-				}
-
 				fields[length] = f
 				length++
 			}
@@ -237,16 +217,24 @@ func (r *Record) valid() error {
 }
 
 // stores stores all all fields of the record into the given byte slice.
-func (r *Record) store() {
+func (r *Record) store() error {
 	for _, fd := range *r.fDesc {
 		for fIndex := 0; fIndex < fd.max; fIndex++ {
+			var err error
 			if fIndex < len(fd.fields) {
-				fd.fields[fIndex].store()
+				err = fd.fields[fIndex].store()
 			} else {
-				fd.deleteField(r, fIndex)
+				err = fd.deleteField(r, fIndex)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %s", r.rType, err.Error())
 			}
 		}
 	}
+
+	r.storeRaws()
+
+	return nil
 }
 
 // FieldTypes return all valid FieldTypes for the record.
@@ -257,7 +245,7 @@ func (r *Record) FieldTypes() []FieldType {
 	indexes := make([]int, 0, len(fds))
 
 	for fType, fd := range fds {
-		index := fd.fieldInfo.index
+		index := fd.index
 		indexes = append(indexes, index)
 		indexedStrs[index] = string(fType)
 	}
@@ -433,7 +421,7 @@ func (r *Record) makeNameUnique() error {
 	}
 	n64, err := strconv.ParseInt(suffix, 10, 32)
 	if err != nil {
-		l.Fatal("trailing digits not numeric")
+		return fmt.Errorf("%s: bad numeric suffix: %s", name, err.Error())
 	}
 	n := int(n64)
 
@@ -536,11 +524,10 @@ func (rd *rDesc) recordIsDeleted(cp *Codeplug, rIndex int) bool {
 }
 
 // deleteRecord marks the record at rIndex as deleted.
-func (rd *rDesc) deleteRecord(cp *Codeplug, rIndex int) {
+func (rd *rDesc) deleteRecord(cp *Codeplug, rIndex int) error {
 	dd := rd.delDesc
 	if dd == nil {
-		l.Fatal("can't delete record type:", rd.rType)
-		return
+		return fmt.Errorf("%s records can't be deleted", rd.rType)
 	}
 
 	offset := rd.offset + rIndex*rd.size + int(dd.offset)
@@ -548,13 +535,17 @@ func (rd *rDesc) deleteRecord(cp *Codeplug, rIndex int) {
 	for i := 0; i < int(dd.size); i++ {
 		cp.bytes[offset+i] = dd.value
 	}
+
+	return nil
 }
 
 func (r *Record) NewFieldWithValue(fType FieldType, index int, str string) (*Field, error) {
 	f := r.NewField(fType)
 	f.fIndex = index
 
-	if f.mustDeferValue(str) {
+	// A field whose default value is already deferred, waiting for
+	// a sibling, defers str in its place.
+	if f.isDeferredValue() || f.mustDeferValue(str) {
 		f.deferValue(str)
 		return f, nil
 	}
@@ -574,15 +565,18 @@ func (r *Record) newFieldWithDeferredValue(fType FieldType, index int, str strin
 	return f
 }
 
-func (r *Record) MoveField(dIndex int, f *Field) {
+func (r *Record) MoveField(dIndex int, f *Field) error {
 	sIndex := f.fIndex
-	r.RemoveField(f)
+	err := r.RemoveField(f)
+	if err != nil {
+		return err
+	}
 	if sIndex < dIndex {
 		dIndex--
 	}
 
 	f.fIndex = dIndex
-	r.InsertField(f)
+	return r.InsertField(f)
 }
 
 func (r *Record) InsertField(f *Field) error {
@@ -600,7 +594,9 @@ func (r *Record) InsertField(f *Field) error {
 	return nil
 }
 
-func (r *Record) RemoveField(f *Field) {
+// RemoveField removes the given field from the record.  An error is
+// returned if the field isn't in the record.
+func (r *Record) RemoveField(f *Field) error {
 	fType := f.fType
 	index := -1
 	fields := r.Fields(fType)
@@ -611,7 +607,7 @@ func (r *Record) RemoveField(f *Field) {
 		}
 	}
 	if index < 0 {
-		l.Fatal("RemoveField: bad field")
+		return fmt.Errorf("%s: field not in record", f.FullTypeName())
 	}
 
 	deleteField(&fields, index)
@@ -620,6 +616,8 @@ func (r *Record) RemoveField(f *Field) {
 		f.fIndex = i
 	}
 	(*r.fDesc)[fType].fields = fields
+
+	return nil
 }
 
 func (or *Record) Copy() *Record {
@@ -637,6 +635,9 @@ func (or *Record) Copy() *Record {
 			r.addField(f)
 		}
 	}
+	for _, rv := range or.raws {
+		r.raws = append(r.raws, rv.copy())
+	}
 
 	return r
 }
@@ -746,7 +747,7 @@ func fieldRefFields(cp *Codeplug, fieldRefs []fieldRef) []*Field {
 
 func (r *Record) fieldRefs() []fieldRef {
 	fieldRefs := make([]fieldRef, 0)
-	for _, fieldRef := range rTypeFieldRefs[r.rType] {
+	for _, fieldRef := range fieldRefsTo(r.rType) {
 		if r.codeplug.HasRecordType(fieldRef.rType) {
 			fieldRefs = append(fieldRefs, fieldRef)
 		}
@@ -771,8 +772,10 @@ func RecordsRemoved(change *Change) {
 			if f.max > 1 {
 				r := f.record
 				change := r.RemoveFieldsChange([]*Field{f})
-				r.RemoveField(f)
-				change.Complete()
+				err := r.RemoveField(f)
+				if err == nil {
+					change.Complete()
+				}
 				continue
 			}
 
@@ -0,0 +1,689 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Repeater describes one entry of a repeater directory.
+type Repeater struct {
+	Callsign    string
+	City        string
+	County      string
+	State       string
+	RxFrequency float64
+	TxFrequency float64
+	Tone        string // CTCSS/DCS transmitted to the repeater
+	ToneSquelch string // CTCSS/DCS transmitted by the repeater
+	ColorCode   int
+	Bandwidth   string // analog bandwidth in kHz
+	Analog      bool
+	Digital     bool
+	Latitude    float64
+	Longitude   float64
+	HasLocation bool
+}
+
+// repeaterKeys maps the normalized column names used by repeater
+// directory exports to the Repeater attribute they hold.
+var repeaterKeys = map[string]string{
+	"callsign":     "callsign",
+	"call":         "callsign",
+	"nearestcity":  "city",
+	"city":         "city",
+	"location":     "city",
+	"county":       "county",
+	"state":        "state",
+	"frequency":    "rx",
+	"outputfreq":   "rx",
+	"output":       "rx",
+	"downlink":     "rx",
+	"inputfreq":    "tx",
+	"input":        "tx",
+	"uplink":       "tx",
+	"offset":       "offset",
+	"pl":           "tone",
+	"tone":         "tone",
+	"uplinktone":   "tone",
+	"accesstone":   "tone",
+	"ctcss":        "tone",
+	"tsq":          "toneSquelch",
+	"downlinktone": "toneSquelch",
+	"dmrcolorcode": "colorCode",
+	"colorcode":    "colorCode",
+	"cc":           "colorCode",
+	"fmbandwidth":  "bandwidth",
+	"bandwidth":    "bandwidth",
+	"mode":         "mode",
+	"fmanalog":     "analog",
+	"dmr":          "digital",
+	"lat":          "latitude",
+	"latitude":     "latitude",
+	"long":         "longitude",
+	"lon":          "longitude",
+	"longitude":    "longitude",
+}
+
+// ReadRepeaters reads a repeater directory export, either as CSV with
+// a header line or as JSON, a list of objects optionally wrapped in a
+// RepeaterBook-style {"results": [...]} object.
+func ReadRepeaters(reader io.Reader) ([]*Repeater, error) {
+	rows, err := readTable(reader, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	repeaters := make([]*Repeater, 0, len(rows))
+	for i, row := range rows {
+		rptr, err := newRepeater(row)
+		if err != nil {
+			return nil, fmt.Errorf("repeater %d: %s", i+1, err.Error())
+		}
+		repeaters = append(repeaters, rptr)
+	}
+
+	return repeaters, nil
+}
+
+// repeaterYes returns whether a directory's yes/no value is true.
+func repeaterYes(s string) bool {
+	switch strings.ToLower(s) {
+	case "yes", "y", "true", "1", "on":
+		return true
+	}
+
+	return false
+}
+
+// repeaterTone returns a CTCSS/DCS value in codeplug form.
+func repeaterTone(s string) string {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", "none", "off", "csq", "0", "0.0":
+		return "None"
+	}
+
+	if s[0] == 'D' || s[0] == 'd' {
+		s = strings.ToUpper(s)
+		if len(s) == 4 {
+			s += "N"
+		}
+		return s
+	}
+
+	tone, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+
+	return fmt.Sprintf("%.1f", tone)
+}
+
+func newRepeater(row map[string]string) (*Repeater, error) {
+	values := make(map[string]string)
+	for key, value := range row {
+		if attr, ok := repeaterKeys[key]; ok && value != "" {
+			values[attr] = value
+		}
+	}
+
+	rptr := &Repeater{
+		Callsign:    values["callsign"],
+		City:        values["city"],
+		County:      values["county"],
+		State:       values["state"],
+		Tone:        repeaterTone(values["tone"]),
+		ToneSquelch: repeaterTone(values["toneSquelch"]),
+	}
+
+	var err error
+	rptr.RxFrequency, err = strconv.ParseFloat(values["rx"], 64)
+	if err != nil {
+		return nil, fmt.Errorf("bad frequency: %q", values["rx"])
+	}
+
+	rptr.TxFrequency = rptr.RxFrequency
+	switch {
+	case values["tx"] != "":
+		rptr.TxFrequency, err = strconv.ParseFloat(values["tx"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad input frequency: %q", values["tx"])
+		}
+
+	case values["offset"] != "":
+		offset, err := strconv.ParseFloat(values["offset"], 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad offset: %q", values["offset"])
+		}
+		rptr.TxFrequency = rptr.RxFrequency + offset
+	}
+
+	if values["colorCode"] != "" {
+		rptr.ColorCode, err = strconv.Atoi(values["colorCode"])
+		if err != nil {
+			return nil, fmt.Errorf("bad color code: %q", values["colorCode"])
+		}
+	}
+
+	rptr.Analog = repeaterYes(values["analog"])
+	rptr.Digital = repeaterYes(values["digital"])
+	for _, mode := range strings.FieldsFunc(strings.ToLower(values["mode"]), func(r rune) bool {
+		return r == '/' || r == ',' || r == ' ' || r == '+'
+	}) {
+		switch mode {
+		case "fm", "analog", "nfm":
+			rptr.Analog = true
+		case "dmr", "digital":
+			rptr.Digital = true
+		}
+	}
+	if !rptr.Analog && !rptr.Digital {
+		if values["colorCode"] != "" {
+			rptr.Digital = true
+		} else {
+			rptr.Analog = true
+		}
+	}
+
+	rptr.Bandwidth = "25"
+	if strings.HasPrefix(values["bandwidth"], "12.5") {
+		rptr.Bandwidth = "12.5"
+	}
+
+	if values["latitude"] != "" && values["longitude"] != "" {
+		lat, latErr := strconv.ParseFloat(values["latitude"], 64)
+		lon, lonErr := strconv.ParseFloat(values["longitude"], 64)
+		if latErr == nil && lonErr == nil {
+			rptr.Latitude = lat
+			rptr.Longitude = lon
+			rptr.HasLocation = true
+		}
+	}
+
+	return rptr, nil
+}
+
+// String returns a short description of the repeater.
+func (rptr *Repeater) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s",
+		frequencyToString(rptr.RxFrequency), rptr.Callsign, rptr.City))
+}
+
+// Distance returns the great circle distance in kilometers between
+// the repeater and the given coordinate.
+func (rptr *Repeater) Distance(latitude float64, longitude float64) float64 {
+	const earthRadius = 6371.0
+
+	radians := func(deg float64) float64 {
+		return deg * math.Pi / 180
+	}
+
+	lat1 := radians(rptr.Latitude)
+	lat2 := radians(latitude)
+	dLat := lat2 - lat1
+	dLon := radians(longitude - rptr.Longitude)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// RepeaterOptions controls the import of a repeater directory.
+type RepeaterOptions struct {
+	// Only repeaters within MaxDistance kilometers of Latitude and
+	// Longitude are imported.  A MaxDistance of zero imports
+	// repeaters regardless of their location.
+	Latitude    float64
+	Longitude   float64
+	MaxDistance float64
+
+	// The repeaters are collected into a zone per county rather
+	// than per city.
+	ZoneByCounty bool
+}
+
+// RepeatersResult reports the outcome of ImportRepeaters.
+type RepeatersResult struct {
+	Channels []*Record
+	Zones    []*Record
+	Skipped  []string
+}
+
+// nameLength returns the maximum length of the names of records of
+// the given type.
+func (cp *Codeplug) nameLength(rType RecordType) int {
+	for _, fi := range cp.rDesc[rType].fieldInfos {
+		if fi.valueType == VtName || fi.valueType == VtContactName {
+			return fi.size() / 2
+		}
+	}
+
+	return 0
+}
+
+// truncateName returns name shortened to at most maxLen characters.
+func truncateName(name string, maxLen int) string {
+	name = strings.TrimSpace(name)
+	runes := []rune(name)
+	if len(runes) > maxLen {
+		name = strings.TrimSpace(string(runes[:maxLen]))
+	}
+
+	return name
+}
+
+// ImportRepeaters creates a channel for each of the given repeaters,
+// digital, analog or both as the repeater supports, and collects the
+// channels into a zone per city or county.  Repeaters outside the
+// codeplug's frequency ranges or beyond the maximum distance are not
+// imported, and are described in the result's Skipped field, as are
+// repeaters whose channel fields can't be set.  A repeater with neither
+// a callsign nor a city is named by its frequency.  If an error stops
+// the import, the channels already added are still zoned and reported.
+func (cp *Codeplug) ImportRepeaters(repeaters []*Repeater, opts RepeaterOptions) (*RepeatersResult, error) {
+	result := new(RepeatersResult)
+
+	skip := func(rptr *Repeater, format string, v ...interface{}) {
+		msg := rptr.String() + ": " + fmt.Sprintf(format, v...)
+		result.Skipped = append(result.Skipped, msg)
+	}
+
+	channelNameLen := cp.nameLength(RtChannels_md380)
+
+	var zoneNames []string
+	zoneChannels := make(map[string][]*Record)
+
+	defer func() {
+		var records []*Record
+		records = append(records, result.Channels...)
+		records = append(records, result.Zones...)
+		cp.completeInsertRecordsChanges(records)
+	}()
+
+	var err error
+repeaters:
+	for _, rptr := range repeaters {
+		if cp.frequencyValid(rptr.RxFrequency) != nil ||
+			cp.frequencyValid(rptr.TxFrequency) != nil {
+			skip(rptr, "frequency is out of range")
+			continue
+		}
+
+		if opts.MaxDistance > 0 {
+			if !rptr.HasLocation {
+				skip(rptr, "location is unknown")
+				continue
+			}
+			distance := rptr.Distance(opts.Latitude, opts.Longitude)
+			if distance > opts.MaxDistance {
+				skip(rptr, "%.1f km away", distance)
+				continue
+			}
+		}
+
+		var modes []string
+		if rptr.Digital {
+			modes = append(modes, "Digital")
+		}
+		if rptr.Analog {
+			modes = append(modes, "Analog")
+		}
+
+		for _, mode := range modes {
+			if len(cp.records(RtChannels_md380)) >= cp.MaxRecords(RtChannels_md380) {
+				skip(rptr, "channels are full")
+				break
+			}
+
+			name := strings.TrimSpace(rptr.Callsign + " " + rptr.City)
+			if name == "" {
+				name = frequencyToString(rptr.RxFrequency)
+			}
+			if len(modes) > 1 {
+				name = abbreviate(name, channelNameLen-2) + " " + mode[:1]
+			}
+			name = abbreviate(name, channelNameLen)
+
+			var r *Record
+			r, err = cp.newRepeaterChannel(rptr, mode, name)
+			if err != nil {
+				skip(rptr, "%s channel: %s", mode, err.Error())
+				err = nil
+				continue
+			}
+
+			err = cp.AppendRecord(r)
+			if err != nil {
+				err = fmt.Errorf("%s: %s", rptr.String(), err.Error())
+				break repeaters
+			}
+			result.Channels = append(result.Channels, r)
+
+			zoneName := rptr.City
+			if opts.ZoneByCounty {
+				zoneName = rptr.County
+			}
+			if zoneName == "" {
+				zoneName = "Repeaters"
+			}
+			if zoneChannels[zoneName] == nil {
+				zoneNames = append(zoneNames, zoneName)
+			}
+			zoneChannels[zoneName] = append(zoneChannels[zoneName], r)
+		}
+	}
+
+	zones, zoneErr := cp.addZones(zoneNames, zoneChannels)
+	result.Zones = zones
+	if err == nil {
+		err = zoneErr
+	}
+
+	return result, err
+}
+
+// newRepeaterChannel returns a new channel record for the repeater.
+func (cp *Codeplug) newRepeaterChannel(rptr *Repeater, mode string, name string) (*Record, error) {
+	r := cp.NewRecord(RtChannels_md380)
+
+	var err error
+	set := func(fType FieldType, value string) {
+		f := r.Field(fType)
+		if err != nil || f == nil {
+			return
+		}
+		err = f.setString(value)
+		if err != nil {
+			err = fmt.Errorf("%s: %s", fType, err.Error())
+		}
+	}
+
+	set(FtCiName, name)
+	set(FtCiChannelMode, mode)
+	set(FtCiRxFrequency, frequencyToString(rptr.RxFrequency))
+	set(FtCiTxFrequencyOffset, frequencyToSignedString(rptr.TxFrequency-rptr.RxFrequency))
+	if mode == "Digital" {
+		set(FtCiColorCode, strconv.Itoa(rptr.ColorCode))
+	} else {
+		set(FtCiBandwidth, rptr.Bandwidth)
+		set(FtCiCtcssEncode, rptr.Tone)
+		set(FtCiCtcssDecode, rptr.ToneSquelch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// addZones appends a zone for each of the given names, containing the
+// channels listed for that name.  Channels that don't fit into one
+// zone continue in further zones of the same name.
+func (cp *Codeplug) addZones(names []string, channels map[string][]*Record) ([]*Record, error) {
+	var zones []*Record
+
+	zoneNameLen := cp.nameLength(RtZones_md380)
+
+	for _, name := range names {
+		members := channels[name]
+		for part := 1; len(members) > 0; part++ {
+			if len(cp.records(RtZones_md380)) >= cp.MaxRecords(RtZones_md380) {
+				return zones, fmt.Errorf("%s: zones are full", name)
+			}
+
+			r := cp.NewRecord(RtZones_md380)
+			fType := FtZiChannel_md380
+			if !r.HasFieldType(fType) {
+				fType = FtZiChannelA_uv380
+			}
+
+			zoneName := name
+			if part > 1 {
+				suffix := " " + strconv.Itoa(part)
+				zoneName = abbreviate(name, zoneNameLen-len(suffix)) + suffix
+			}
+			err := r.NameField().setString(abbreviate(zoneName, zoneNameLen))
+			if err != nil {
+				return zones, err
+			}
+
+			err = cp.AppendRecord(r)
+			if err != nil {
+				return zones, err
+			}
+
+			max := r.MaxFields(fType)
+			for i := 0; i < max && len(members) > 0; i++ {
+				f, err := r.NewFieldWithValue(fType, i, members[0].Name())
+				if err == nil {
+					err = r.addField(f)
+				}
+				if err != nil {
+					return zones, err
+				}
+				members = members[1:]
+			}
+			zones = append(zones, r)
+		}
+	}
+
+	return zones, nil
+}
+
+// channelName returns a name of at most maxLen characters for a
+// talkgroup's channel at a site.  The talkgroup's name takes priority,
+// but at least a few characters are left for the site's name.
+func channelName(talkgroup string, site string, maxLen int) string {
+	const minSiteLen = 3
+
+	siteLen := maxLen - utf8.RuneCountInString(talkgroup) - 1
+	if siteLen < minSiteLen {
+		siteLen = minSiteLen
+	}
+
+	return abbreviate(talkgroup, maxLen-siteLen-1) + " " + abbreviate(site, siteLen)
+}
+
+// GeneratedChannels reports the outcome of GenerateChannels.
+type GeneratedChannels struct {
+	Contacts  []*Record
+	GroupList *Record
+	Channels  []*Record
+	Zones     []*Record
+	ScanList  *Record
+	Skipped   []string
+}
+
+// GenerateChannels creates a digital channel on the repeater for each
+// of the given talkgroups, with the talkgroup's group call contact,
+// time slot and the repeater's color code.  Contacts are reused when
+// the codeplug has one for the talkgroup's ID.  A group list of the
+// talkgroups, a zone and a scan list of the channels are created
+// with the given name.  Names are abbreviated to fit the codeplug.
+func (cp *Codeplug) GenerateChannels(name string, rptr *Repeater, talkgroups []*Talkgroup) (*GeneratedChannels, error) {
+	result := new(GeneratedChannels)
+
+	skip := func(format string, v ...interface{}) {
+		result.Skipped = append(result.Skipped, fmt.Sprintf(format, v...))
+	}
+
+	if cp.frequencyValid(rptr.RxFrequency) != nil ||
+		cp.frequencyValid(rptr.TxFrequency) != nil {
+		return nil, fmt.Errorf("%s: frequency is out of range", rptr.String())
+	}
+
+	var added []*Record
+	defer func() {
+		cp.completeInsertRecordsChanges(added)
+	}()
+
+	newList := func(rType RecordType) (*Record, error) {
+		if !cp.HasRecordType(rType) {
+			return nil, nil
+		}
+		if len(cp.records(rType)) >= cp.MaxRecords(rType) {
+			skip("%s: %s are full", name, rType)
+			return nil, nil
+		}
+
+		r := cp.NewRecord(rType)
+		err := r.NameField().setString(abbreviate(name, cp.nameLength(rType)))
+		if err == nil {
+			err = cp.AppendRecord(r)
+		}
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, r)
+
+		return r, nil
+	}
+
+	addMember := func(r *Record, fType FieldType, member *Record) error {
+		if r == nil {
+			return nil
+		}
+		if len(r.Fields(fType)) >= r.MaxFields(fType) {
+			skip("%s: %s %s is full", member.Name(), r.rType, r.Name())
+			return nil
+		}
+
+		f, err := r.NewFieldWithValue(fType, len(r.Fields(fType)), member.Name())
+		if err == nil {
+			err = r.addField(f)
+		}
+
+		return err
+	}
+
+	contacts := make([]*Record, len(talkgroups))
+	for i, tg := range talkgroups {
+		r, isNew, err := cp.groupContact(tg)
+		if err != nil {
+			return result, err
+		}
+		if r == nil {
+			skip("%d %s: contacts are full", tg.ID, tg.Name)
+			continue
+		}
+		if isNew {
+			result.Contacts = append(result.Contacts, r)
+			added = append(added, r)
+		}
+		contacts[i] = r
+	}
+
+	groupList, err := newList(RtGroupLists)
+	if err != nil {
+		return result, err
+	}
+	result.GroupList = groupList
+
+	for _, r := range contacts {
+		if r == nil {
+			continue
+		}
+		err := addMember(groupList, FtGlContact, r)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	scanList, err := newList(RtScanLists_md380)
+	if err != nil {
+		return result, err
+	}
+	result.ScanList = scanList
+
+	channelNameLen := cp.nameLength(RtChannels_md380)
+
+	for i, tg := range talkgroups {
+		contact := contacts[i]
+		if contact == nil {
+			continue
+		}
+		if len(cp.records(RtChannels_md380)) >= cp.MaxRecords(RtChannels_md380) {
+			skip("%d %s: channels are full", tg.ID, tg.Name)
+			continue
+		}
+
+		slot := tg.Slot
+		if slot == 0 {
+			slot = 1
+		}
+
+		r, err := cp.newRepeaterChannel(rptr, "Digital", channelName(tg.Name, name, channelNameLen))
+		if err != nil {
+			return result, err
+		}
+
+		set := func(fType FieldType, value string) {
+			f := r.Field(fType)
+			if err != nil || f == nil {
+				return
+			}
+			err = f.setString(value)
+			if err != nil {
+				err = fmt.Errorf("%s: %s: %s", r.Name(), fType, err.Error())
+			}
+		}
+
+		set(FtCiRepeaterSlot, strconv.Itoa(slot))
+		set(FtCiContactName, contact.Name())
+		if groupList != nil {
+			set(FtCiGroupList, groupList.Name())
+		}
+		if scanList != nil {
+			set(FtCiScanList_md380, scanList.Name())
+		}
+		if err == nil {
+			err = cp.AppendRecord(r)
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Channels = append(result.Channels, r)
+		added = append(added, r)
+
+		err = addMember(scanList, FtSlChannel_md380, r)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	zones, err := cp.addZones([]string{name}, map[string][]*Record{name: result.Channels})
+	result.Zones = zones
+	added = append(added, zones...)
+
+	return result, err
+}
@@ -0,0 +1,216 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadRepeaters(t *testing.T) {
+	want := []*Repeater{
+		{
+			Callsign:    "W7ABC",
+			City:        "Mesa",
+			County:      "Maricopa",
+			State:       "AZ",
+			RxFrequency: 146.94,
+			TxFrequency: 146.34,
+			Tone:        "162.2",
+			ToneSquelch: "None",
+			Bandwidth:   "25",
+			Analog:      true,
+			Latitude:    33.4,
+			Longitude:   -111.8,
+			HasLocation: true,
+		},
+		{
+			Callsign:    "W7DEF",
+			City:        "Tempe",
+			State:       "AZ",
+			RxFrequency: 440.5,
+			TxFrequency: 445.5,
+			Tone:        "D023N",
+			ToneSquelch: "None",
+			ColorCode:   1,
+			Bandwidth:   "12.5",
+			Analog:      true,
+			Digital:     true,
+		},
+	}
+
+	csv := "Call,Nearest City,County,State,Frequency,Offset,PL,Lat,Long\n" +
+		"W7ABC,Mesa,Maricopa,AZ,146.94,-0.6,162.2,33.4,-111.8\n" +
+		"W7DEF,Tempe,,AZ,440.5,5,D023,,\n"
+	json := `{"results": [
+		{"Callsign": "W7ABC", "Nearest City": "Mesa", "County": "Maricopa",
+		 "State": "AZ", "Frequency": "146.94", "Input Freq": "146.34",
+		 "PL": "162.2", "Lat": "33.4", "Long": "-111.8"},
+		{"Callsign": "W7DEF", "Nearest City": "Tempe", "State": "AZ",
+		 "Frequency": "440.5", "Input Freq": "445.5", "PL": "D023",
+		 "Mode": "FM/DMR", "DMR Color Code": "1", "FM Bandwidth": "12.5 kHz"}
+	]}`
+
+	for _, test := range []struct {
+		name  string
+		input string
+	}{
+		{"csv", csv},
+		{"json", json},
+	} {
+		repeaters, err := ReadRepeaters(strings.NewReader(test.input))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err.Error())
+			continue
+		}
+		if test.name == "csv" {
+			// The CSV doesn't have mode, color code and bandwidth columns.
+			want[1].ColorCode = 0
+			want[1].Bandwidth = "25"
+			want[1].Digital = false
+		}
+		if !reflect.DeepEqual(repeaters, want) {
+			for i := range repeaters {
+				t.Errorf("%s: got %+v", test.name, repeaters[i])
+			}
+		}
+		want[1].ColorCode = 1
+		want[1].Bandwidth = "12.5"
+		want[1].Digital = true
+	}
+
+	_, err := ReadRepeaters(strings.NewReader("Call,Frequency\nW7ABC,x\n"))
+	if err == nil || !strings.Contains(err.Error(), "repeater 1: bad frequency") {
+		t.Errorf("bad frequency: got error %v", err)
+	}
+}
+
+func TestImportRepeaters(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	freq := cp.lowFrequency + 1
+	channels := len(cp.Records(RtChannels_md380))
+	zones := len(cp.Records(RtZones_md380))
+
+	repeaters := []*Repeater{
+		{Callsign: "W7ABC", City: "Mesa", RxFrequency: freq, TxFrequency: freq + 0.6,
+			Tone: "None", ToneSquelch: "None", Bandwidth: "25", Analog: true, Digital: true,
+			ColorCode: 1, Latitude: 33.4, Longitude: -111.8, HasLocation: true},
+		{Callsign: "W7DEF", City: "Mesa", RxFrequency: freq + 0.1, TxFrequency: freq + 0.1,
+			Tone: "100.0", ToneSquelch: "None", Bandwidth: "12.5", Analog: true,
+			Latitude: 33.5, Longitude: -111.9, HasLocation: true},
+		{Callsign: "W7GHI", City: "Tucson", RxFrequency: freq, TxFrequency: freq,
+			Tone: "None", ToneSquelch: "None", Bandwidth: "25", Analog: true,
+			Latitude: 32.2, Longitude: -110.9, HasLocation: true},
+		{Callsign: "W7JKL", City: "Mesa", RxFrequency: 1, TxFrequency: 1,
+			Tone: "None", ToneSquelch: "None", Bandwidth: "25", Analog: true},
+		{Callsign: "W7MNO", City: "Mesa", RxFrequency: freq, TxFrequency: freq,
+			Tone: "None", ToneSquelch: "None", Bandwidth: "25", Analog: true},
+	}
+	opts := RepeaterOptions{Latitude: 33.4, Longitude: -111.8, MaxDistance: 50}
+
+	result, err := cp.ImportRepeaters(repeaters, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, r := range result.Channels {
+		names = append(names, r.Name())
+	}
+	if want := []string{"W7ABC Mesa D", "W7ABC Mesa A", "W7DEF Mesa"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got channels %q, want %q", names, want)
+	}
+	if len(result.Skipped) != 3 {
+		t.Errorf("got skipped %q, want 3", result.Skipped)
+	}
+	if len(result.Zones) != 1 || result.Zones[0].Name() != "Mesa" {
+		t.Fatalf("got zones %v", result.Zones)
+	}
+	if got := len(result.Zones[0].Fields(FtZiChannel_md380)); got != 3 {
+		t.Errorf("zone has %d channels, want 3", got)
+	}
+
+	if len(cp.Records(RtChannels_md380)) != channels+3 || len(cp.Records(RtZones_md380)) != zones+1 {
+		t.Error("records not added to the codeplug")
+	}
+
+	r := result.Channels[0]
+	if r.Field(FtCiChannelMode).String() != "Digital" || r.Field(FtCiColorCode).String() != "1" {
+		t.Errorf("digital channel: %s %s", r.Field(FtCiChannelMode), r.Field(FtCiColorCode))
+	}
+	r = result.Channels[2]
+	if r.Field(FtCiCtcssEncode).String() != "100.0" || r.Field(FtCiBandwidth).String() != "12.5" {
+		t.Errorf("analog channel: %s %s", r.Field(FtCiCtcssEncode), r.Field(FtCiBandwidth))
+	}
+	checkValid(t, cp)
+}
+
+func TestImportRepeatersIncomplete(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	freq := cp.lowFrequency + 1
+
+	var inserted []RecordType
+	cp.SubscribeChanges(ChangeFilter{ChangeTypes: []ChangeType{InsertRecordsChange}},
+		func(change *Change) {
+			inserted = append(inserted, change.RecordType())
+		})
+
+	repeaters := []*Repeater{
+		{Callsign: "W7ABC", City: "Mesa", RxFrequency: freq, TxFrequency: freq,
+			Tone: "None", ToneSquelch: "None", Bandwidth: "25", Analog: true},
+		{RxFrequency: freq + 0.1, TxFrequency: freq + 0.1,
+			Tone: "None", ToneSquelch: "None", Bandwidth: "25", Analog: true},
+		{Callsign: "W7DEF", City: "Mesa", RxFrequency: freq, TxFrequency: freq,
+			Tone: "99.9", ToneSquelch: "None", Bandwidth: "25", Analog: true},
+	}
+
+	result, err := cp.ImportRepeaters(repeaters, RepeaterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, r := range result.Channels {
+		names = append(names, r.Name())
+	}
+	want := []string{"W7ABC Mesa", frequencyToString(freq + 0.1)}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("got channels %q, want %q", names, want)
+	}
+	if len(result.Skipped) != 1 || !strings.HasPrefix(result.Skipped[0], repeaters[2].String()) {
+		t.Errorf("got skipped %q, want W7DEF", result.Skipped)
+	}
+	if len(result.Zones) != 2 {
+		t.Errorf("got %d zones, want 2", len(result.Zones))
+	}
+
+	wantTypes := []RecordType{RtChannels_md380, RtZones_md380}
+	if !reflect.DeepEqual(inserted, wantTypes) {
+		t.Errorf("got insert changes of %v, want %v", inserted, wantTypes)
+	}
+	checkValid(t, cp)
+}
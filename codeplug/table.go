@@ -0,0 +1,200 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Directories of repeaters and talkgroups are published as tables,
+// either CSV files with a header line or JSON lists of objects.  They
+// are read into rows mapping normalized column names to values.
+
+// normalizeColumnName returns a column name reduced to lower case
+// letters and digits.
+func normalizeColumnName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// readTable reads a CSV or JSON table.  A JSON list may be wrapped in
+// a {"results": [...]} object.  If columns is given, a CSV file whose
+// first line holds a number has no header line and its columns are
+// those given, and a JSON object of strings maps the first of those
+// columns to the second, in order of the keys.
+func readTable(reader io.Reader, columns []string) ([]map[string]string, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return readTableJSON(trimmed, columns)
+	}
+
+	return readTableCSV(data, columns)
+}
+
+func readTableJSON(data []byte, columns []string) ([]map[string]string, error) {
+	var objects []map[string]interface{}
+	if data[0] == '{' {
+		var wrapper map[string]json.RawMessage
+		err := json.Unmarshal(data, &wrapper)
+		if err != nil {
+			return nil, err
+		}
+
+		results, ok := wrapper["results"]
+		if !ok {
+			return readTableJSONMap(data, columns)
+		}
+		err = json.Unmarshal(results, &objects)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err := json.Unmarshal(data, &objects)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows := make([]map[string]string, len(objects))
+	for i, obj := range objects {
+		row := make(map[string]string)
+		for key, value := range obj {
+			if value == nil {
+				continue
+			}
+			row[normalizeColumnName(key)] = strings.TrimSpace(fmt.Sprint(value))
+		}
+		rows[i] = row
+	}
+
+	return rows, nil
+}
+
+func readTableJSONMap(data []byte, columns []string) ([]map[string]string, error) {
+	if len(columns) < 2 {
+		return nil, fmt.Errorf("missing \"results\" list")
+	}
+
+	var m map[string]string
+	err := json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	rows := make([]map[string]string, 0, len(m))
+	for _, key := range keys {
+		rows = append(rows, map[string]string{
+			columns[0]: strings.TrimSpace(key),
+			columns[1]: strings.TrimSpace(m[key]),
+		})
+	}
+
+	return rows, nil
+}
+
+func readTableCSV(data []byte, columns []string) ([]map[string]string, error) {
+	r := csv.NewReader(bufio.NewReader(bytes.NewReader(data)))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no header line")
+	}
+
+	header := lines[0]
+	if columns != nil && isNumeric(strings.TrimSpace(header[0])) {
+		header = columns
+	} else {
+		for i, name := range header {
+			header[i] = normalizeColumnName(name)
+		}
+		lines = lines[1:]
+	}
+
+	rows := make([]map[string]string, 0, len(lines))
+	for _, line := range lines {
+		row := make(map[string]string)
+		for i, value := range line {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// isNumeric returns whether s is a non-empty string of decimal digits.
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,336 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Talkgroup describes one entry of a network's talkgroup list.
+type Talkgroup struct {
+	ID   int
+	Name string
+	Slot int // repeater time slot, or 0 if unspecified
+	Tags []string
+}
+
+// talkgroupKeys maps the normalized column names used by talkgroup
+// lists to the Talkgroup attribute they hold.
+var talkgroupKeys = map[string]string{
+	"id":            "id",
+	"tg":            "id",
+	"tgid":          "id",
+	"talkgroup":     "id",
+	"talkgroupid":   "id",
+	"callid":        "id",
+	"number":        "id",
+	"name":          "name",
+	"talkgroupname": "name",
+	"tgname":        "name",
+	"slot":          "slot",
+	"ts":            "slot",
+	"timeslot":      "slot",
+	"tags":          "tags",
+	"tag":           "tags",
+	"grouplist":     "tags",
+	"grouplists":    "tags",
+}
+
+// ReadTalkgroups reads a talkgroup list, either as CSV or as JSON.  A
+// CSV file without a header line holds ID, name, slot and tags
+// columns.  Tags are separated by semicolons or vertical bars.  A
+// JSON file is a list of objects, or a single object mapping IDs to
+// names.
+func ReadTalkgroups(reader io.Reader) ([]*Talkgroup, error) {
+	rows, err := readTable(reader, []string{"id", "name", "slot", "tags"})
+	if err != nil {
+		return nil, err
+	}
+
+	talkgroups := make([]*Talkgroup, 0, len(rows))
+	for i, row := range rows {
+		tg, err := newTalkgroup(row)
+		if err != nil {
+			return nil, fmt.Errorf("talkgroup %d: %s", i+1, err.Error())
+		}
+		talkgroups = append(talkgroups, tg)
+	}
+
+	return talkgroups, nil
+}
+
+func newTalkgroup(row map[string]string) (*Talkgroup, error) {
+	values := make(map[string]string)
+	for key, value := range row {
+		if attr, ok := talkgroupKeys[key]; ok && value != "" {
+			values[attr] = value
+		}
+	}
+
+	tg := &Talkgroup{
+		Name: values["name"],
+	}
+
+	var err error
+	tg.ID, err = strconv.Atoi(values["id"])
+	if err != nil || tg.ID <= 0 {
+		return nil, fmt.Errorf("bad ID: %q", values["id"])
+	}
+
+	if tg.Name == "" {
+		tg.Name = strconv.Itoa(tg.ID)
+	}
+
+	if values["slot"] != "" {
+		slot := strings.TrimPrefix(strings.ToUpper(values["slot"]), "TS")
+		tg.Slot, err = strconv.Atoi(slot)
+		if err != nil || tg.Slot < 1 || tg.Slot > 2 {
+			return nil, fmt.Errorf("bad slot: %q", values["slot"])
+		}
+	}
+
+	for _, tag := range strings.FieldsFunc(values["tags"], func(r rune) bool {
+		return r == ';' || r == '|'
+	}) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tg.Tags = append(tg.Tags, tag)
+		}
+	}
+
+	return tg, nil
+}
+
+// abbreviate returns name shortened to at most maxLen characters.
+// Vowels are removed from the name's words, last word first, then the
+// spaces between words, before the name is finally truncated.
+func abbreviate(name string, maxLen int) string {
+	words := strings.Fields(name)
+	length := func() int {
+		return utf8.RuneCountInString(strings.Join(words, " "))
+	}
+
+	for i := len(words) - 1; i >= 0 && length() > maxLen; i-- {
+		runes := []rune(words[i])
+		word := runes[:1]
+		for _, r := range runes[1:] {
+			if !strings.ContainsRune("aeiou", r) {
+				word = append(word, r)
+			}
+		}
+		words[i] = string(word)
+	}
+
+	name = strings.Join(words, " ")
+	if length() > maxLen {
+		name = strings.Join(words, "")
+	}
+
+	return truncateName(name, maxLen)
+}
+
+// groupContact returns the group call contact for the talkgroup,
+// creating and appending it to the codeplug if there is none.  It
+// returns a nil record if the codeplug's contacts are full.
+func (cp *Codeplug) groupContact(tg *Talkgroup) (r *Record, added bool, err error) {
+	r = cp.FindContactByCallID("Group", tg.ID)
+	if r != nil {
+		return r, false, nil
+	}
+
+	if len(cp.records(RtContacts)) >= cp.MaxRecords(RtContacts) {
+		return nil, false, nil
+	}
+
+	r = cp.NewRecord(RtContacts)
+
+	err = r.Field(FtDcCallType).setString("Group")
+	if err == nil {
+		err = r.Field(FtDcCallID).setString(strconv.Itoa(tg.ID))
+	}
+	if err == nil {
+		err = setContactName(r, abbreviate(tg.Name, cp.nameLength(RtContacts)))
+	}
+	if err == nil {
+		err = cp.AppendRecord(r)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %s", tg.Name, err.Error())
+	}
+
+	return r, true, nil
+}
+
+// TalkgroupsResult reports the outcome of ImportTalkgroups.
+type TalkgroupsResult struct {
+	Added      []*Record
+	Updated    []*Record
+	Unchanged  []*Record
+	GroupLists []*Record
+	Skipped    []string
+}
+
+// ImportTalkgroups creates a group call contact for each of the given
+// talkgroups.  A talkgroup whose ID already has a contact is left
+// unchanged, unless update is set, in which case the contact is renamed
+// to the talkgroup's name.  For each tag of the talkgroups, a group
+// list of that name is created, or extended if it exists, with the
+// contacts of the talkgroups having the tag.  Anything that would
+// exceed the codeplug's limits is described in the result's Skipped
+// field.
+func (cp *Codeplug) ImportTalkgroups(talkgroups []*Talkgroup, update bool) (*TalkgroupsResult, error) {
+	result := new(TalkgroupsResult)
+
+	if !cp.HasRecordType(RtContacts) {
+		return nil, fmt.Errorf("codeplug has no contacts")
+	}
+
+	skip := func(format string, v ...interface{}) {
+		result.Skipped = append(result.Skipped, fmt.Sprintf(format, v...))
+	}
+
+	maxNameLen := cp.nameLength(RtContacts)
+
+	var tags []string
+	tagged := make(map[string][]*Record)
+	var renames []*Change
+
+	for _, tg := range talkgroups {
+		name := abbreviate(tg.Name, maxNameLen)
+
+		r := cp.FindContactByCallID("Group", tg.ID)
+		switch {
+		case r == nil:
+			var err error
+			r, _, err = cp.groupContact(tg)
+			if err != nil {
+				return result, err
+			}
+			if r == nil {
+				skip("%d %s: contacts are full", tg.ID, tg.Name)
+				continue
+			}
+			result.Added = append(result.Added, r)
+
+		case update && removeSuffix(r.NameField(), r.Name()) != name:
+			change, err := renameContact(r, name)
+			if err != nil {
+				return result, fmt.Errorf("%s: %s", name, err.Error())
+			}
+			if change != nil {
+				renames = append(renames, change)
+			}
+			result.Updated = append(result.Updated, r)
+
+		default:
+			result.Unchanged = append(result.Unchanged, r)
+		}
+
+		for _, tag := range tg.Tags {
+			if tagged[tag] == nil {
+				tags = append(tags, tag)
+			}
+			tagged[tag] = append(tagged[tag], r)
+		}
+	}
+
+	var inserted []*Record
+	inserted = append(inserted, result.Added...)
+
+	var err error
+	if len(tags) > 0 && !cp.HasRecordType(RtGroupLists) {
+		skip("codeplug has no group lists")
+		tags = nil
+	}
+
+	for _, tag := range tags {
+		name := abbreviate(tag, cp.nameLength(RtGroupLists))
+		r := cp.FindRecordByName(RtGroupLists, name)
+		if r == nil {
+			if len(cp.records(RtGroupLists)) >= cp.MaxRecords(RtGroupLists) {
+				skip("%s: group lists are full", tag)
+				continue
+			}
+
+			r = cp.NewRecord(RtGroupLists)
+			err = r.NameField().setString(name)
+			if err == nil {
+				err = cp.AppendRecord(r)
+			}
+			if err != nil {
+				break
+			}
+			inserted = append(inserted, r)
+		} else {
+			result.Updated = append(result.Updated, r)
+		}
+		result.GroupLists = append(result.GroupLists, r)
+
+		members := make(map[string]bool)
+		for _, f := range r.Fields(FtGlContact) {
+			members[f.String()] = true
+		}
+
+		for _, contact := range tagged[tag] {
+			if members[contact.Name()] {
+				continue
+			}
+			if len(r.Fields(FtGlContact)) >= r.MaxFields(FtGlContact) {
+				skip("%s: group list %s is full", contact.Name(), r.Name())
+				continue
+			}
+
+			var f *Field
+			f, err = r.NewFieldWithValue(FtGlContact, len(r.Fields(FtGlContact)), contact.Name())
+			if err == nil {
+				err = r.addField(f)
+			}
+			if err != nil {
+				break
+			}
+			members[contact.Name()] = true
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	cp.completeInsertRecordsChanges(inserted)
+
+	if len(result.Updated) > 0 {
+		change := cp.RecordsFieldChange(result.Updated)
+		for _, rename := range renames {
+			change.AddChange(rename)
+		}
+		change.Complete()
+	}
+
+	return result, err
+}
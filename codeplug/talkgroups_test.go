@@ -0,0 +1,224 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadTalkgroups(t *testing.T) {
+	want := []*Talkgroup{
+		{ID: 91, Name: "Worldwide", Slot: 1, Tags: []string{"World", "Big"}},
+		{ID: 3100, Name: "USA", Slot: 2},
+		{ID: 31004, Name: "31004"},
+	}
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"csv", "TG,Name,TS,Tags\n91,Worldwide,TS1,World;Big\n3100,USA,2,\n31004,,,\n"},
+		{"csv without header", "91,Worldwide,1,World|Big\n3100,USA,2\n31004\n"},
+		{"json", `[{"id": 91, "name": "Worldwide", "slot": 1, "tags": "World;Big"},
+			{"id": 3100, "name": "USA", "slot": "TS2"}, {"id": "31004"}]`},
+	}
+
+	for _, test := range tests {
+		talkgroups, err := ReadTalkgroups(strings.NewReader(test.input))
+		if err != nil {
+			t.Errorf("%s: %s", test.name, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(talkgroups, want) {
+			for _, tg := range talkgroups {
+				t.Errorf("%s: got %+v", test.name, tg)
+			}
+		}
+	}
+
+	talkgroups, err := ReadTalkgroups(strings.NewReader(`{"3100": "USA", "91": "Worldwide"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*Talkgroup{{ID: 91, Name: "Worldwide"}, {ID: 3100, Name: "USA"}}; !reflect.DeepEqual(talkgroups, want) {
+		t.Errorf("json map: got %+v %+v", talkgroups[0], talkgroups[1])
+	}
+
+	for _, input := range []string{"x,USA\n", "91,World,3\n"} {
+		_, err := ReadTalkgroups(strings.NewReader("TG,Name,TS\n" + input))
+		if err == nil || !strings.HasPrefix(err.Error(), "talkgroup 1: bad") {
+			t.Errorf("%q: got error %v", input, err)
+		}
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxLen int
+		want   string
+	}{
+		{"Mesa Arizona", 16, "Mesa Arizona"},
+		{"Mesa Arizona", 10, "Mesa Arzn"},
+		{"Mesa Arizona", 8, "Ms Arzn"},
+		{"Mesa Arizona", 6, "MsArzn"},
+		{"Mesa Arizona", 4, "MsAr"},
+		{"Zürich Öst", 8, "ZürchÖst"},
+	}
+
+	for _, test := range tests {
+		got := abbreviate(test.name, test.maxLen)
+		if got != test.want {
+			t.Errorf("abbreviate(%q, %d): got %q, want %q", test.name, test.maxLen, got, test.want)
+		}
+	}
+}
+
+func TestChannelName(t *testing.T) {
+	tests := []struct {
+		talkgroup string
+		site      string
+		want      string
+	}{
+		{"USA", "Mesa", "USA Mesa"},
+		{"Worldwide", "Phoenix Metro", "Worldwide PhnxMt"},
+		{"Arizona Statewide", "Mesa", "Arzn Sttwd Ms"},
+	}
+
+	for _, test := range tests {
+		got := channelName(test.talkgroup, test.site, 16)
+		if got != test.want {
+			t.Errorf("channelName(%q, %q): got %q, want %q", test.talkgroup, test.site, got, test.want)
+		}
+	}
+}
+
+func TestGenerateChannels(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	freq := cp.lowFrequency + 1
+	rptr := &Repeater{Callsign: "W7ABC", City: "Mesa", RxFrequency: freq, TxFrequency: freq + 0.6,
+		ColorCode: 3, Digital: true}
+	talkgroups := []*Talkgroup{
+		{ID: 91, Name: "Worldwide", Slot: 1},
+		{ID: 3100, Name: "USA", Slot: 2},
+		{ID: 3104, Name: "Arizona"},
+	}
+
+	result, err := cp.GenerateChannels("Mesa", rptr, talkgroups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Skipped) != 0 {
+		t.Errorf("skipped %q", result.Skipped)
+	}
+	if len(result.Contacts) != 3 || len(result.Channels) != 3 || len(result.Zones) != 1 {
+		t.Fatalf("%d contacts, %d channels, %d zones", len(result.Contacts), len(result.Channels), len(result.Zones))
+	}
+	if result.GroupList == nil || len(result.GroupList.Fields(FtGlContact)) != 3 {
+		t.Error("group list doesn't hold the talkgroups' contacts")
+	}
+	if result.ScanList == nil || len(result.ScanList.Fields(FtSlChannel_md380)) != 3 {
+		t.Error("scan list doesn't hold the channels")
+	}
+
+	for i, r := range result.Channels {
+		contact := result.Contacts[i]
+		slot := []string{"1", "2", "1"}[i]
+		if r.Field(FtCiContactName).String() != contact.Name() ||
+			r.Field(FtCiRepeaterSlot).String() != slot ||
+			r.Field(FtCiColorCode).String() != "3" ||
+			r.Field(FtCiGroupList).String() != "Mesa" ||
+			r.Field(FtCiScanList_md380).String() != "Mesa" {
+			t.Errorf("channel %s: contact %s, slot %s, color code %s, group list %s, scan list %s",
+				r.Name(), r.Field(FtCiContactName), r.Field(FtCiRepeaterSlot),
+				r.Field(FtCiColorCode), r.Field(FtCiGroupList), r.Field(FtCiScanList_md380))
+		}
+	}
+	checkValid(t, cp)
+
+	// A second site reuses the talkgroups' contacts.
+	result, err = cp.GenerateChannels("Tempe", rptr, talkgroups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Contacts) != 0 || len(result.Channels) != 3 {
+		t.Errorf("second site: %d contacts, %d channels", len(result.Contacts), len(result.Channels))
+	}
+	checkValid(t, cp)
+}
+
+func TestImportTalkgroups(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	talkgroups := []*Talkgroup{
+		{ID: 91, Name: "Worldwide", Tags: []string{"BM"}},
+		{ID: 3100, Name: "USA", Tags: []string{"BM", "US"}},
+	}
+
+	result, err := cp.ImportTalkgroups(talkgroups, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 2 || len(result.Updated) != 0 || len(result.Skipped) != 0 {
+		t.Fatalf("first import: %d added, %d updated, skipped %q",
+			len(result.Added), len(result.Updated), result.Skipped)
+	}
+
+	var names []string
+	for _, r := range result.GroupLists {
+		names = append(names, r.Name())
+		if want := map[string]int{"BM": 2, "US": 1}[r.Name()]; len(r.Fields(FtGlContact)) != want {
+			t.Errorf("group list %s has %d contacts, want %d", r.Name(), len(r.Fields(FtGlContact)), want)
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"BM", "US"}) {
+		t.Errorf("got group lists %q", names)
+	}
+	checkValid(t, cp)
+
+	talkgroups[1].Name = "United States"
+	result, err = cp.ImportTalkgroups(talkgroups, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 0 || len(result.Unchanged) != 2 {
+		t.Errorf("second import: %d added, %d unchanged", len(result.Added), len(result.Unchanged))
+	}
+	if len(cp.FindRecordByName(RtGroupLists, "BM").Fields(FtGlContact)) != 2 {
+		t.Error("second import added duplicate group list members")
+	}
+
+	result, err = cp.ImportTalkgroups(talkgroups, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := cp.FindContactByCallID("Group", 3100)
+	if baseContactName(r) != "United States" {
+		t.Errorf("updated contact name: %s", r.Name())
+	}
+	checkValid(t, cp)
+}
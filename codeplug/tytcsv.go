@@ -0,0 +1,359 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// TYT's CPS exports and imports its contacts and channels as CSV
+// files, one record per row, with a column per field.  The columns
+// present depend on the radio model.
+
+// A tytColumn names the CSV column holding a field.
+type tytColumn struct {
+	name  string
+	fType FieldType
+}
+
+const (
+	tytNumber      = "No."
+	tytRxFrequency = "Rx Frequency(MHz)"
+	tytTxFrequency = "Tx Frequency(MHz)"
+)
+
+var tytContactColumns = []tytColumn{
+	{"Contact Name", FtDcName},
+	{"Call Type", FtDcCallType},
+	{"Call ID", FtDcCallID},
+	{"Call Receive Tone", FtDcCallReceiveTone},
+}
+
+// tytChannelColumns lists the channel columns following the name and
+// the receive and transmit frequencies.
+var tytChannelColumns = []tytColumn{
+	{"Channel Mode", FtCiChannelMode},
+	{"Bandwidth", FtCiBandwidth},
+	{"Scan List", FtCiScanList_md380},
+	{"Squelch", FtCiSquelch},
+	{"Rx Ref Frequency", FtCiRxRefFrequency},
+	{"Tx Ref Frequency", FtCiTxRefFrequency},
+	{"TOT[s]", FtCiTot},
+	{"TOT Rekey Delay[s]", FtCiTotRekeyDelay},
+	{"Power", FtCiPower},
+	{"Admit Criteria", FtCiAdmitCriteria},
+	{"In Call Criteria", FtCiInCallCriteria},
+	{"Autoscan", FtCiAutoscan},
+	{"Rx Only", FtCiRxOnly},
+	{"Lone Worker", FtCiLoneWorker},
+	{"VOX", FtCiVox},
+	{"Allow Talkaround", FtCiAllowTalkaround},
+	{"Talkaround", FtCiTalkaround},
+	{"Allow Interrupt", FtCiAllowInterrupt},
+	{"Send GPS Info", FtCiSendGPSInfo},
+	{"Receive GPS Info", FtCiReceiveGPSInfo},
+	{"Private Call Confirmed", FtCiPrivateCallConfirmed},
+	{"Emergency Alarm Ack", FtCiEmergencyAlarmAck},
+	{"Data Call Confirmed", FtCiDataCallConfirmed},
+	{"Compressed UDP Data Header", FtCiCompressedUdpDataHeader},
+	{"DCDM Switch", FtCiDCDMSwitch},
+	{"Leader/MS", FtCiLeaderMS},
+	{"Emergency System", FtCiEmergencySystem},
+	{"Contact Name", FtCiContactName},
+	{"Group List", FtCiGroupList},
+	{"Color Code", FtCiColorCode},
+	{"Repeater Slot", FtCiRepeaterSlot},
+	{"Privacy", FtCiPrivacy},
+	{"Privacy No.", FtCiPrivacyNumber},
+	{"GPS System", FtCiGPSSystem},
+	{"CTCSS/DCS Decode", FtCiCtcssDecode},
+	{"CTCSS/DCS Encode", FtCiCtcssEncode},
+	{"QT Reverse", FtCiQtReverse},
+	{"Reverse Burst/Turn off code", FtCiReverseBurst},
+	{"Turn off Freq", FtCiDQTTurnoffFreq},
+	{"Rx Signaling System", FtCiRxSignallingSystem},
+	{"Tx Signaling System", FtCiTxSignallingSystem},
+	{"Display PTT ID", FtCiDisplayPTTID},
+	{"Decode 1", FtCiDecode1},
+	{"Decode 2", FtCiDecode2},
+	{"Decode 3", FtCiDecode3},
+	{"Decode 4", FtCiDecode4},
+	{"Decode 5", FtCiDecode5},
+	{"Decode 6", FtCiDecode6},
+	{"Decode 7", FtCiDecode7},
+	{"Decode 8", FtCiDecode8},
+}
+
+// tytColumns returns the columns for the codeplug's records of the
+// given type.
+func (cp *Codeplug) tytColumns(rType RecordType) []tytColumn {
+	columns := tytContactColumns
+	if rType == RtChannels_md380 {
+		columns = append([]tytColumn{{"Channel Name", FtCiName}}, tytChannelColumns...)
+	}
+
+	r := cp.newRecord(rType, 0)
+	var present []tytColumn
+	for _, col := range columns {
+		if r.HasFieldType(col.fType) {
+			present = append(present, col)
+		}
+	}
+
+	return present
+}
+
+// ExportTytCSV writes the codeplug's contacts or channels, as given by
+// rType, to a CSV file in the layout of TYT's CPS for the codeplug's
+// model.
+func (cp *Codeplug) ExportTytCSV(filename string, rType RecordType) (err error) {
+	switch rType {
+	case RtContacts, RtChannels_md380:
+	default:
+		return fmt.Errorf("%s cannot be exported to CSV", rType)
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	columns := cp.tytColumns(rType)
+
+	header := []string{tytNumber}
+	for i, col := range columns {
+		header = append(header, col.name)
+		if i == 0 && rType == RtChannels_md380 {
+			header = append(header, tytRxFrequency, tytTxFrequency)
+		}
+	}
+
+	w := csv.NewWriter(file)
+	w.UseCRLF = true
+	err = w.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for i, r := range cp.records(rType) {
+		row := []string{strconv.Itoa(i + 1)}
+		for j, col := range columns {
+			row = append(row, removeSuffix(r.Field(col.fType), r.Field(col.fType).String()))
+			if j == 0 && rType == RtChannels_md380 {
+				rx, tx := tytFrequencies(r)
+				row = append(row, rx, tx)
+			}
+		}
+		err = w.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+
+	return w.Error()
+}
+
+// tytFrequencies returns a channel's receive and transmit frequencies.
+func tytFrequencies(r *Record) (rx string, tx string) {
+	rxFreq, _ := strconv.ParseFloat(r.Field(FtCiRxFrequency).String(), 64)
+	offset, _ := strconv.ParseFloat(r.Field(FtCiTxFrequencyOffset).String(), 64)
+
+	return frequencyToString(rxFreq), frequencyToString(rxFreq + offset)
+}
+
+// ImportTytCSV replaces the codeplug's contacts or channels with those
+// of a CSV file exported by TYT's CPS.  The file's type is determined
+// by its columns.  References from other records to contacts or
+// channels that are not in the file are removed.  A description of
+// each of the file's columns and values that could not be imported,
+// and of each removed reference, is returned.
+func (cp *Codeplug) ImportTytCSV(reader io.Reader) (unsupported []string, err error) {
+	report := func(line int, format string, v ...interface{}) {
+		msg := fmt.Sprintf(format, v...)
+		if line > 0 {
+			msg = fmt.Sprintf("line %d: %s", line, msg)
+		}
+		unsupported = append(unsupported, msg)
+	}
+
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	lines, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no header line")
+	}
+
+	header := make([]string, len(lines[0]))
+	columnIndex := make(map[string]int)
+	for i, name := range lines[0] {
+		header[i] = normalizeColumnName(name)
+		columnIndex[header[i]] = i
+	}
+
+	rType := RtContacts
+	if _, ok := columnIndex[normalizeColumnName(tytRxFrequency)]; ok {
+		rType = RtChannels_md380
+	}
+	columns := cp.tytColumns(rType)
+
+	fTypes := make(map[string]FieldType)
+	for _, col := range columns {
+		fTypes[normalizeColumnName(col.name)] = col.fType
+	}
+
+	for i, name := range lines[0] {
+		switch header[i] {
+		case normalizeColumnName(tytNumber),
+			normalizeColumnName(tytRxFrequency),
+			normalizeColumnName(tytTxFrequency):
+			continue
+		}
+		if _, ok := fTypes[header[i]]; !ok {
+			report(0, "%s: not supported", strings.TrimSpace(name))
+		}
+	}
+
+	// References to existing contacts are by their names without
+	// any suffix.
+	contactNames := make(map[string]string)
+	for _, c := range cp.records(RtContacts) {
+		contactNames[removeSuffix(c.NameField(), c.Name())] = c.Name()
+	}
+
+	var pRecs []*parsedRecord
+	used := make(map[string]bool)
+	for n, line := range lines[1:] {
+		pos := &position{line: n + 1}
+		value := func(name string) string {
+			i, ok := columnIndex[normalizeColumnName(name)]
+			if !ok || i >= len(line) {
+				return ""
+			}
+			return strings.TrimSpace(line[i])
+		}
+
+		pRec := &parsedRecord{
+			name:  string(rType),
+			index: len(pRecs),
+			pos:   pos,
+		}
+		addField := func(fType FieldType, value string) {
+			pRec.pFields = append(pRec.pFields, &parsedField{
+				name:  string(fType),
+				value: value,
+				pos:   pos,
+			})
+		}
+
+		for i, col := range columns {
+			str := value(col.name)
+			if _, ok := columnIndex[normalizeColumnName(col.name)]; !ok {
+				continue
+			}
+			switch {
+			case i == 0:
+				// The first column holds the record's name.  A
+				// contact keeps the name by which other records
+				// refer to it.
+				var name string
+				if rType == RtContacts {
+					name = cp.importContactName(str, value("Call ID"), used)
+				} else {
+					name = cp.importName(rType, str, used)
+				}
+				if name != str && (rType != RtContacts || cp.uniqueContactNames) {
+					report(n+2, "duplicate name %s renamed to %s", str, name)
+				}
+				str = name
+
+			case col.fType == FtCiContactName:
+				if name, ok := contactNames[str]; ok {
+					str = name
+				}
+			}
+			addField(col.fType, str)
+		}
+
+		if rType == RtChannels_md380 {
+			rx := value(tytRxFrequency)
+			addField(FtCiRxFrequency, rx)
+			if tx := value(tytTxFrequency); tx != "" {
+				addField(FtCiTxFrequencyOffset, dmrconfigOffset(rx, tx))
+			}
+		}
+
+		pRecs = append(pRecs, pRec)
+	}
+
+	if len(pRecs) == 0 {
+		return unsupported, fmt.Errorf("no %s found", rType)
+	}
+
+	deferValues := false
+	records, _, err := cp.parsedFileToRecs(pRecs, deferValues)
+	if _, warning := err.(Warning); warning {
+		for _, msg := range strings.Split(err.Error(), "\n") {
+			if msg != "" {
+				unsupported = append(unsupported, msg)
+			}
+		}
+		err = nil
+	}
+	if err != nil {
+		return unsupported, err
+	}
+
+	refs := cp.importRefs(records)
+
+	err = cp.storeParsedRecords(records)
+	if err != nil {
+		return unsupported, err
+	}
+	cp.AddMissingFields()
+
+	unsupported = append(unsupported, cp.restoreImportRefs(refs)...)
+
+	return unsupported, nil
+}
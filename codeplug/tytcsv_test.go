@@ -0,0 +1,133 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTytCSVRoundTrip(t *testing.T) {
+	forEachType(t, func(t *testing.T, cp *Codeplug) {
+		dir, removeDir := tempDir(t)
+		defer removeDir()
+
+		rTypes := []RecordType{RtContacts, RtChannels_md380}
+		others := []RecordType{RtZones_md380, RtScanLists_md380, RtGroupLists, RtOneTouch, RtNumberKey}
+		before := recordStrings(cp, append(rTypes, others...)...)
+
+		for _, rType := range rTypes {
+			err := cp.ExportTytCSV(filepath.Join(dir, string(rType)+".csv"), rType)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, rType := range rTypes {
+			file, err := os.Open(filepath.Join(dir, string(rType)+".csv"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			unsupported, err := cp.ImportTytCSV(file)
+			file.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, msg := range unsupported {
+				t.Log("import:", msg)
+			}
+		}
+
+		checkValid(t, cp)
+
+		after := recordStrings(cp, append(rTypes, others...)...)
+		if !reflect.DeepEqual(before, after) {
+			t.Errorf("records changed:\n%s\n%s", strings.Join(before, ""), strings.Join(after, ""))
+		}
+	})
+}
+
+func TestTytCSVImportRemovesReferences(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	contact := cp.Records(RtContacts)[0]
+	gl := cp.Records(RtGroupLists)[0]
+	if len(gl.Fields(FtGlContact)) != 1 || gl.Fields(FtGlContact)[0].String() != contact.Name() {
+		t.Fatalf("group list %s doesn't hold contact %s", gl.Name(), contact.Name())
+	}
+
+	text := "No.,Contact Name,Call Type,Call ID,Call Receive Tone\n" +
+		"1,Other,Group,99,No\n"
+	unsupported, err := cp.ImportTytCSV(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkValid(t, cp)
+
+	if len(gl.Fields(FtGlContact)) != 0 {
+		t.Errorf("group list %s still holds %s", gl.Name(), gl.Fields(FtGlContact)[0])
+	}
+
+	var removed, changed bool
+	for _, msg := range unsupported {
+		if strings.HasPrefix(msg, "RX Group Lists") && strings.HasSuffix(msg, "reference removed") {
+			removed = true
+		}
+		if strings.HasPrefix(msg, "One Touch") && strings.HasSuffix(msg, "reference changed to Other") {
+			changed = true
+		}
+	}
+	if !removed || !changed {
+		t.Errorf("references not reported: %q", unsupported)
+	}
+}
+
+func TestTytCSVKeepsContactNames(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	contact := cp.Records(RtContacts)[0]
+	name := contact.Name()
+
+	text := "No.,Contact Name,Call Type,Call ID,Call Receive Tone\n" +
+		"1,Renamed," + contact.Field(FtDcCallType).String() + "," +
+		contact.Field(FtDcCallID).String() + ",No\n"
+	_, err := cp.ImportTytCSV(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkValid(t, cp)
+
+	got := cp.Records(RtContacts)[0].Name()
+	if got != "Renamed"+name[len(baseContactName(contact)):] {
+		t.Errorf("contact renamed to %s, want the suffix of %s kept", got, name)
+	}
+	if f := cp.Records(RtGroupLists)[0].Fields(FtGlContact)[0]; f.String() != got {
+		t.Errorf("group list member %s, want %s", f.String(), got)
+	}
+}
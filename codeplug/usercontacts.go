@@ -0,0 +1,210 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dalefarnsworth/codeplug/userdb"
+)
+
+// UserContactsResult reports the outcome of AddUserContacts.
+type UserContactsResult struct {
+	Added     []*Record
+	Updated   []*Record
+	Unchanged []*Record
+	Skipped   []*userdb.User
+}
+
+// NewRecord returns a new record of the given type with each of its
+// fields set to its default value.  The record is not inserted into
+// the codeplug.
+func (cp *Codeplug) NewRecord(rType RecordType) *Record {
+	r := cp.newRecord(rType, len(cp.records(rType)))
+	for _, fType := range r.AllFieldTypes() {
+		f := r.NewField(fType)
+		if f.MaxFields() > 1 {
+			continue
+		}
+		r.addField(f)
+	}
+
+	return r
+}
+
+// FindContactByCallID returns the contact record with the given call
+// type and call ID, or nil if there is none.
+func (cp *Codeplug) FindContactByCallID(callType string, id int) *Record {
+	idStr := strconv.Itoa(id)
+	for _, r := range cp.records(RtContacts) {
+		if r.Field(FtDcCallType).String() != callType {
+			continue
+		}
+		if r.Field(FtDcCallID).String() == idStr {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// userContactName returns a contact name for u of at most maxLen
+// characters.  The callsign is followed by as much of the user's name
+// as will fit: the full name, or just the first name.
+func userContactName(u *userdb.User, maxLen int) string {
+	call := strings.TrimSpace(u.Callsign)
+	if call == "" {
+		call = strconv.Itoa(u.ID)
+	}
+
+	firstName := u.Nick
+	if firstName == "" {
+		firstName = strings.SplitN(strings.TrimSpace(u.Name), " ", 2)[0]
+	}
+
+	candidates := []string{
+		strings.TrimSpace(call + " " + strings.TrimSpace(u.Name)),
+		strings.TrimSpace(call + " " + firstName),
+		call,
+	}
+
+	for _, name := range candidates {
+		if utf8.RuneCountInString(name) <= maxLen {
+			return name
+		}
+	}
+
+	return string([]rune(call)[:maxLen])
+}
+
+// setContactName sets the name of the contact record r, making it
+// unique among the contacts when required by the codeplug.
+func setContactName(r *Record, name string) error {
+	f := r.Field(FtDcName)
+	if removeSuffix(f, f.String()) == name {
+		return nil
+	}
+
+	return f.setString(AddSuffix(f, name))
+}
+
+// renameContact renames the contact record r, as setContactName does,
+// and changes the fields referring to the contact to its new name.  It
+// returns the change of the contact's name, or nil if the name is
+// unchanged.
+func renameContact(r *Record, name string) (*Change, error) {
+	f := r.Field(FtDcName)
+	previousString := f.String()
+
+	err := setContactName(r, name)
+	if err != nil || f.String() == previousString {
+		return nil, err
+	}
+
+	change := f.Change(previousString)
+	NameFieldChanged(change)
+
+	return change, nil
+}
+
+// AddUserContacts creates a private call contact for each of the
+// given users, named by the user's callsign and name.  A contact that
+// already exists for a user's ID is updated instead of duplicated.
+// Users that would exceed the codeplug's maximum number of contacts
+// are returned in the result's Skipped field.
+func (cp *Codeplug) AddUserContacts(users []*userdb.User) (*UserContactsResult, error) {
+	result := new(UserContactsResult)
+	var renames []*Change
+
+	if !cp.HasRecordType(RtContacts) {
+		return nil, fmt.Errorf("codeplug has no contacts")
+	}
+
+	maxNameLen := cp.nameLength(RtContacts)
+
+	for _, u := range users {
+		name := userContactName(u, maxNameLen)
+
+		r := cp.FindContactByCallID("Private", u.ID)
+		if r != nil {
+			if removeSuffix(r.Field(FtDcName), r.Name()) == name {
+				result.Unchanged = append(result.Unchanged, r)
+				continue
+			}
+
+			change, err := renameContact(r, name)
+			if err != nil {
+				return result, fmt.Errorf("%s: %s", name, err.Error())
+			}
+			if change != nil {
+				renames = append(renames, change)
+			}
+			result.Updated = append(result.Updated, r)
+			continue
+		}
+
+		if len(cp.records(RtContacts)) >= cp.MaxRecords(RtContacts) {
+			result.Skipped = append(result.Skipped, u)
+			continue
+		}
+
+		r = cp.NewRecord(RtContacts)
+
+		err := r.Field(FtDcCallType).setString("Private")
+		if err == nil {
+			err = r.Field(FtDcCallID).setString(strconv.Itoa(u.ID))
+		}
+		if err == nil {
+			err = setContactName(r, name)
+		}
+		if err != nil {
+			return result, fmt.Errorf("%s: %s", name, err.Error())
+		}
+
+		err = cp.AppendRecord(r)
+		if err != nil {
+			return result, err
+		}
+		result.Added = append(result.Added, r)
+	}
+
+	if len(result.Added) > 0 {
+		cp.InsertRecordsChange(result.Added).Complete()
+	}
+
+	if len(result.Updated) > 0 {
+		change := cp.RecordsFieldChange(result.Updated)
+		for _, rename := range renames {
+			change.AddChange(rename)
+		}
+		change.Complete()
+	}
+
+	return result, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dalefarnsworth/codeplug/userdb"
+)
+
+// baseContactName returns the name of contact r without any suffix added
+// to make it unique.
+func baseContactName(r *Record) string {
+	return removeSuffix(r.Field(FtDcName), r.Name())
+}
+
+func TestUserContactName(t *testing.T) {
+	u := &userdb.User{ID: 1000001, Callsign: "N0CALL", Name: "Joseph Smith"}
+	tests := []struct {
+		u      *userdb.User
+		maxLen int
+		want   string
+	}{
+		{u, 16, "N0CALL Joseph"},
+		{u, 19, "N0CALL Joseph Smith"},
+		{u, 12, "N0CALL"},
+		{u, 4, "N0CA"},
+		{&userdb.User{ID: 1000001, Callsign: "N0CALL", Name: "Joseph Smith", Nick: "Joe"}, 16, "N0CALL Joe"},
+		{&userdb.User{ID: 1000002, Name: "Zoë"}, 16, "1000002 Zoë"},
+	}
+
+	for _, test := range tests {
+		got := userContactName(test.u, test.maxLen)
+		if got != test.want {
+			t.Errorf("%+v, %d: got %q, want %q", test.u, test.maxLen, got, test.want)
+		}
+	}
+}
+
+func TestAddUserContacts(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	users := []*userdb.User{
+		{ID: 1000001, Callsign: "N0CALL", Name: "Joe Smith"},
+		{ID: 1000002, Callsign: "N1CALL", Name: "Ann Jones"},
+	}
+
+	result, err := cp.AddUserContacts(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 2 || len(result.Updated) != 0 || len(result.Unchanged) != 0 {
+		t.Fatalf("first add: %d added, %d updated, %d unchanged",
+			len(result.Added), len(result.Updated), len(result.Unchanged))
+	}
+
+	r := cp.FindContactByCallID("Private", 1000002)
+	if r == nil || baseContactName(r) != "N1CALL Ann Jones" {
+		t.Fatalf("contact 1000002: %v", r)
+	}
+
+	gl := cp.Records(RtGroupLists)[0]
+	f, err := gl.NewFieldWithValue(FtGlContact, len(gl.Fields(FtGlContact)), r.Name())
+	if err == nil {
+		err = gl.addField(f)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	users[1].Name = "Anne Jones"
+	result, err = cp.AddUserContacts(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 0 || len(result.Updated) != 1 || len(result.Unchanged) != 1 {
+		t.Fatalf("second add: %d added, %d updated, %d unchanged",
+			len(result.Added), len(result.Updated), len(result.Unchanged))
+	}
+	if baseContactName(r) != "N1CALL Anne" {
+		t.Errorf("updated contact name: %s", r.Name())
+	}
+	if f.String() != r.Name() {
+		t.Errorf("group list member %s wasn't renamed to %s", f.String(), r.Name())
+	}
+	checkValid(t, cp)
+}
+
+func TestAddUserContactsFull(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	free := cp.MaxRecords(RtContacts) - len(cp.Records(RtContacts))
+
+	var users []*userdb.User
+	for i := 0; i < free+2; i++ {
+		users = append(users, &userdb.User{ID: 1000000 + i, Callsign: "N" + strconv.Itoa(i)})
+	}
+
+	result, err := cp.AddUserContacts(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != free || len(result.Skipped) != 2 {
+		t.Errorf("%d added, %d skipped, want %d added, 2 skipped",
+			len(result.Added), len(result.Skipped), free)
+	}
+}
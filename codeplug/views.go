@@ -0,0 +1,221 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"fmt"
+)
+
+// The typed views generated from codeplugs.json read and write their
+// records through the helpers in this file.  A view of a record lacking
+// the requested field reads as the zero value and refuses writes.
+
+// viewField returns the first field of the given type in r or an error
+// if there is none.
+func viewField(r *Record, fType FieldType) (*Field, error) {
+	f := r.Field(fType)
+	if f == nil {
+		return nil, fmt.Errorf("%s record has no %s field", r.rType, fType)
+	}
+
+	return f, nil
+}
+
+// viewString returns the string value of the first field of the given
+// type in r.
+func viewString(r *Record, fType FieldType) string {
+	f := r.Field(fType)
+	if f == nil {
+		return ""
+	}
+
+	return f.String()
+}
+
+// viewSetString sets the value of the first field of the given type
+// in r from a string.
+func viewSetString(r *Record, fType FieldType, s string) error {
+	f, err := viewField(r, fType)
+	if err != nil {
+		return err
+	}
+
+	return f.SetString(s)
+}
+
+// viewBool returns true if the first field of the given type in r is on.
+func viewBool(r *Record, fType FieldType) bool {
+	f := r.Field(fType)
+	if f == nil {
+		return false
+	}
+
+	on, _ := f.Bool()
+	return on
+}
+
+// viewSetBool turns the first field of the given type in r on or off.
+func viewSetBool(r *Record, fType FieldType, on bool) error {
+	f, err := viewField(r, fType)
+	if err != nil {
+		return err
+	}
+
+	return f.SetBool(on)
+}
+
+// viewFloat returns the value of the first field of the given type in r
+// as a floating point number, or 0 if it has no numeric value.
+func viewFloat(r *Record, fType FieldType) float64 {
+	f := r.Field(fType)
+	if f == nil {
+		return 0
+	}
+
+	value, _ := f.Float64()
+	return value
+}
+
+// viewSetFloat sets the value of the first field of the given type in r
+// from a floating point number.
+func viewSetFloat(r *Record, fType FieldType, value float64) error {
+	f, err := viewField(r, fType)
+	if err != nil {
+		return err
+	}
+
+	return f.SetFloat64(value)
+}
+
+// viewInt returns the value of the first field of the given type in r
+// as an integer.  A span's minimum string, such as "None" or
+// "Infinite", is returned as 0.
+func viewInt(r *Record, fType FieldType) int {
+	f := r.Field(fType)
+	if f == nil {
+		return 0
+	}
+
+	i, _ := f.Int()
+	return i
+}
+
+// viewSetInt sets the value of the first field of the given type in r
+// from an integer.
+func viewSetInt(r *Record, fType FieldType, i int) error {
+	f, err := viewField(r, fType)
+	if err != nil {
+		return err
+	}
+
+	return f.SetInt(i)
+}
+
+// viewRecord returns the record referenced by the first field of the
+// given type in r, or nil if it references no record.
+func viewRecord(r *Record, fType FieldType) *Record {
+	f := r.Field(fType)
+	if f == nil {
+		return nil
+	}
+
+	ref, _ := f.RecordRef()
+	return ref
+}
+
+// viewSetRecord makes the first field of the given type in r reference
+// ref.  If ref is nil, the field is set to its first indexed string.
+func viewSetRecord(r *Record, fType FieldType, ref *Record) error {
+	f, err := viewField(r, fType)
+	if err != nil {
+		return err
+	}
+
+	return f.SetRecordRef(ref)
+}
+
+// viewRecords returns the records referenced by the fields of the given
+// type in r.
+func viewRecords(r *Record, fType FieldType) []*Record {
+	fields := r.Fields(fType)
+	records := make([]*Record, 0, len(fields))
+	for _, f := range fields {
+		ref, _ := f.RecordRef()
+		if ref != nil {
+			records = append(records, ref)
+		}
+	}
+
+	return records
+}
+
+// viewSetRecords replaces the fields of the given type in r with fields
+// referencing refs.
+func viewSetRecords(r *Record, fType FieldType, refs []*Record) error {
+	fd := (*r.fDesc)[fType]
+	if fd == nil {
+		return fmt.Errorf("%s record has no %s field", r.rType, fType)
+	}
+
+	if len(refs) > fd.max {
+		return fmt.Errorf("too many fields: %s", string(fType))
+	}
+
+	fields := make([]*Field, len(refs))
+	for i, ref := range refs {
+		if ref == nil {
+			return fmt.Errorf("%s: nil record reference", fType)
+		}
+
+		f, err := r.NewFieldWithValue(fType, i, ref.Name())
+		if err != nil {
+			return err
+		}
+		fields[i] = f
+	}
+
+	oldFields := append([]*Field(nil), r.Fields(fType)...)
+	if len(oldFields) > 0 {
+		change := r.RemoveFieldsChange(oldFields)
+		for _, f := range oldFields {
+			err := r.RemoveField(f)
+			if err != nil {
+				return err
+			}
+		}
+		change.Complete()
+	}
+
+	if len(fields) > 0 {
+		change := r.InsertFieldsChange(fields)
+		for _, f := range fields {
+			r.InsertField(f)
+		}
+		change.Complete()
+	}
+
+	return nil
+}
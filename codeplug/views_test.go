@@ -0,0 +1,202 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"fmt"
+	"testing"
+)
+
+// insertTestRecord inserts a new record of the given type, with the
+// given name, into the codeplug.
+func insertTestRecord(t *testing.T, cp *Codeplug, rType RecordType, name string) *Record {
+	t.Helper()
+
+	r := cp.NewRecord(rType)
+	err := r.NameField().SetString(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cp.InsertRecord(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestChannelView(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	ch := NewChannel_md380(cp.Records(RtChannels_md380)[0])
+
+	freq := cp.lowFrequency + 1
+	err := ch.SetRxFrequency(freq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.RxFrequency() != freq {
+		t.Errorf("got rx frequency %g, want %g", ch.RxFrequency(), freq)
+	}
+	want := frequencyToString(freq)
+	if got := ch.Record().Field(FtCiRxFrequency).String(); got != want {
+		t.Errorf("rx frequency field is %s, want %s", got, want)
+	}
+
+	err = ch.SetPower(CiPowerLow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Power() != CiPowerLow {
+		t.Errorf("got power %s, want %s", ch.Power(), CiPowerLow)
+	}
+	err = ch.SetPower(CiPower("Bogus"))
+	if err == nil {
+		t.Error("setting an invalid power succeeded")
+	}
+	if ch.Power() != CiPowerLow {
+		t.Errorf("failed set changed power to %s", ch.Power())
+	}
+
+	for _, on := range []bool{true, false} {
+		err = ch.SetRxOnly(on)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ch.RxOnly() != on {
+			t.Errorf("got rx only %t, want %t", ch.RxOnly(), on)
+		}
+	}
+
+	err = ch.SetTot(60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Tot() != 60 {
+		t.Errorf("got tot %d, want 60", ch.Tot())
+	}
+
+	checkValid(t, cp)
+}
+
+func TestViewMissingField(t *testing.T) {
+	if NewChannel_md380(nil) != nil {
+		t.Error("view of a nil record is not nil")
+	}
+
+	cp := newTestCodeplug(t, "MD-380")
+	ch := NewChannel_md380(cp.Records(RtContacts)[0])
+
+	if ch.RxFrequency() != 0 {
+		t.Errorf("got rx frequency %g from a contact", ch.RxFrequency())
+	}
+	if ch.ScanList() != nil {
+		t.Error("got a scan list from a contact")
+	}
+	err := ch.SetRxFrequency(cp.lowFrequency + 1)
+	if err == nil {
+		t.Error("setting a contact's rx frequency succeeded")
+	}
+	err = ch.SetScanList(nil)
+	if err == nil {
+		t.Error("setting a contact's scan list succeeded")
+	}
+}
+
+func TestViewRecordRef(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	ch := NewChannel_md380(cp.Records(RtChannels_md380)[0])
+	sl := NewScanList_md380(insertTestRecord(t, cp, RtScanLists_md380, "Scan"))
+
+	err := ch.SetScanList(sl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.ScanList() == nil || ch.ScanList().Record() != sl.Record() {
+		t.Errorf("scan list is %v, want %s", ch.ScanList(), sl.Name())
+	}
+
+	err = ch.SetScanList(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.ScanList() != nil {
+		t.Errorf("scan list is %s after clearing", ch.ScanList().Name())
+	}
+
+	checkValid(t, cp)
+}
+
+func TestViewRecordList(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	gl := NewGroupList(insertTestRecord(t, cp, RtGroupLists, "Group"))
+
+	var contacts []*Contact
+	for i := 0; i < 3; i++ {
+		c := NewContact(insertTestRecord(t, cp, RtContacts, fmt.Sprintf("Contact %d", i)))
+		err := c.SetCallID(1000 + i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contacts = append(contacts, c)
+	}
+
+	for _, refs := range [][]*Contact{contacts, contacts[1:], nil} {
+		err := gl.SetContact(refs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := gl.Contact()
+		if len(got) != len(refs) {
+			t.Fatalf("got %d contacts, want %d", len(got), len(refs))
+		}
+		for i, c := range got {
+			if c.Record() != refs[i].Record() {
+				t.Errorf("contact %d is %s, want %s", i, c.Name(), refs[i].Name())
+			}
+		}
+	}
+
+	err := gl.SetContact([]*Contact{contacts[0], nil})
+	if err == nil {
+		t.Error("setting a nil contact succeeded")
+	}
+
+	max := gl.Record().MaxFields(FtGlContact)
+	var tooMany []*Contact
+	for len(tooMany) <= max {
+		tooMany = append(tooMany, contacts[0])
+	}
+	err = gl.SetContact(tooMany)
+	if err == nil {
+		t.Errorf("setting %d contacts succeeded", len(tooMany))
+	}
+
+	checkValid(t, cp)
+}
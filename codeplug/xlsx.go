@@ -0,0 +1,246 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/tealeg/xlsx"
+)
+
+// The cells of an exported spreadsheet are restricted to the values
+// the codeplug accepts.  Each field column gets a data-validation list
+// whose values are stored in hidden lookup sheets, one per referenced
+// record type (e.g. "_Contacts") plus "_Values" for all other lists.
+// Hidden sheets are ignored when the spreadsheet is imported.
+
+// xlsxValuesSheet is the name of the hidden sheet holding value lists
+// that don't name records.
+const xlsxValuesSheet = "_Values"
+
+// xlsxLookup is a hidden sheet of value lists, one list per column.
+type xlsxLookup struct {
+	name    string
+	columns [][]string
+}
+
+// xlsxLookups collects the lookup sheets of a spreadsheet being exported.
+type xlsxLookups struct {
+	sheets  []*xlsxLookup
+	columns map[string]int
+}
+
+// lookupSheetName returns the name of the hidden lookup sheet for
+// the values of fields of the given value type.
+func lookupSheetName(vType ValueType, listRecordType RecordType) string {
+	switch vType {
+	case VtListIndex, VtGpsListIndex, VtDerefListIndex,
+		VtContactListIndex, VtNkContactListIndex:
+		return "_" + string(listRecordType)
+	}
+
+	return xlsxValuesSheet
+}
+
+// xlsxFieldStrings returns the valid values of fields of the given type
+// in the given records. It returns nil if the values are not restricted
+// to a list.
+func xlsxFieldStrings(records []*Record, fType FieldType) []string {
+	var f *Field
+	for _, r := range records {
+		f = r.Field(fType)
+		if f != nil {
+			break
+		}
+	}
+	if f == nil {
+		f = records[0].NewField(fType)
+	}
+
+	switch f.valueType {
+	case VtListIndex, VtGpsListIndex, VtDerefListIndex,
+		VtContactListIndex, VtNkContactListIndex,
+		VtCtcssDcs, VtIStrings, VtBandwidth,
+		VtIndexedStrings, VtRadioButton, VtCallType, VtSpanList:
+		return f.Strings()
+
+	case VtPrivacyNumber:
+		// The valid numbers depend on the sibling privacy type,
+		// so allow all of them.
+		return *f.strings
+
+	case VtSpan:
+		return f.SpanStrings()
+	}
+
+	return nil
+}
+
+// add stores strs in the named lookup sheet, unless an identical list
+// is already there, and returns the column holding the list.
+func (lookups *xlsxLookups) add(sheetName string, strs []string) int {
+	key := sheetName + "\n" + strings.Join(strs, "\n")
+	if column, ok := lookups.columns[key]; ok {
+		return column
+	}
+
+	var lookup *xlsxLookup
+	for _, l := range lookups.sheets {
+		if l.name == sheetName {
+			lookup = l
+			break
+		}
+	}
+	if lookup == nil {
+		lookup = &xlsxLookup{name: sheetName}
+		lookups.sheets = append(lookups.sheets, lookup)
+	}
+
+	column := len(lookup.columns)
+	lookup.columns = append(lookup.columns, strs)
+	lookups.columns[key] = column
+
+	return column
+}
+
+// addValidations adds a frozen header to sheet and restricts the values
+// of each field column to the values valid for the field.  Each header
+// cell has a validation of type "none", which allows any value but
+// shows the field's full name as an input message when selected.
+func (lookups *xlsxLookups) addValidations(sheet *xlsx.Sheet, records []*Record) error {
+	sheet.SheetViews = []xlsx.SheetView{{
+		Pane: &xlsx.Pane{
+			YSplit:      1,
+			TopLeftCell: "A2",
+			ActivePane:  "bottomLeft",
+			State:       "frozen",
+		},
+	}}
+
+	r := records[0]
+	maxRecords := r.MaxRecords()
+	errorTitle := "Invalid value"
+	column := 0
+	for _, fType := range r.FieldTypes() {
+		fi := (*r.fDesc)[fType].fieldInfo
+		strs := xlsxFieldStrings(records, fType)
+		title := string(fType)
+		prompt := fi.typeName
+
+		for i := 0; i < fi.max; i++ {
+			hdr := xlsx.NewXlsxCellDataValidation(true)
+			hdr.Type = "none"
+			hdr.SetInput(&title, &prompt)
+			sheet.Col(column).SetDataValidation(hdr, 0, 0)
+
+			if strs != nil {
+				sheetName := lookupSheetName(fi.valueType, fi.listRecordType)
+				x := lookups.add(sheetName, strs)
+				dv := xlsx.NewXlsxCellDataValidation(true)
+				err := dv.SetInFileList(sheetName, x, 0, x, len(strs)-1)
+				if err != nil {
+					return err
+				}
+				msg := prompt + " must be one of the listed values"
+				dv.SetError(xlsx.StyleStop, &errorTitle, &msg)
+				sheet.Col(column).SetDataValidation(dv, 1, maxRecords)
+			}
+			column++
+		}
+	}
+
+	return nil
+}
+
+// addSheets adds the hidden lookup sheets to file.
+func (lookups *xlsxLookups) addSheets(file *xlsx.File) error {
+	for _, lookup := range lookups.sheets {
+		sheet, err := file.AddSheet(lookup.name)
+		if err != nil {
+			return err
+		}
+		sheet.Hidden = true
+
+		for x, strs := range lookup.columns {
+			for y, str := range strs {
+				sheet.Cell(y, x).Value = str
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeXLSXFile writes file to w.  The xlsx package marks every sheet
+// as visible when writing, so the state of hidden sheets is set in the
+// workbook here.
+func writeXLSXFile(w io.Writer, file *xlsx.File) error {
+	parts, err := file.MarshallParts()
+	if err != nil {
+		return err
+	}
+
+	const workbookPart = "xl/workbook.xml"
+	workbook := parts[workbookPart]
+	for _, sheet := range file.Sheets {
+		if !sheet.Hidden {
+			continue
+		}
+
+		start := strings.Index(workbook, `<sheet name="`+sheet.Name+`"`)
+		if start < 0 {
+			return fmt.Errorf("sheet %s missing from workbook", sheet.Name)
+		}
+		end := start + strings.Index(workbook[start:], ">")
+		elem := strings.Replace(workbook[start:end], `state="visible"`, `state="hidden"`, 1)
+		workbook = workbook[:start] + elem + workbook[end:]
+	}
+	parts[workbookPart] = workbook
+
+	var names []string
+	for name := range parts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		pw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(pw, parts[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
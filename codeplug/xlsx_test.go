@@ -0,0 +1,188 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Codeplug.
+//
+// Codeplug is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Codeplug is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Codeplug.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+// Package codeplug implements access to MD380-style codeplug files.
+// It can read/update/write both .rdt files and .bin files.
+package codeplug
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tealeg/xlsx"
+)
+
+// writeTestXLSX returns the spreadsheet written for cp.
+func writeTestXLSX(t *testing.T, cp *Codeplug) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := cp.WriteXLSX(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// lookupValues returns the values of the lookup list referenced by a
+// list validation's formula, such as "'_Values'!$A$1:$A$3".
+func lookupValues(t *testing.T, sheets map[string]*xlsx.Sheet, formula string) []string {
+	t.Helper()
+
+	parts := strings.SplitN(formula, "!", 2)
+	if len(parts) != 2 {
+		t.Fatalf("bad list formula %s", formula)
+	}
+	sheet := sheets[strings.Trim(parts[0], "'")]
+	if sheet == nil {
+		t.Fatalf("%s: no such sheet", parts[0])
+	}
+
+	cells := strings.Split(strings.Replace(parts[1], "$", "", -1), ":")
+	x1, y1, err := xlsx.GetCoordsFromCellIDString(cells[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, y2, err := xlsx.GetCoordsFromCellIDString(cells[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var values []string
+	for y := y1; y <= y2; y++ {
+		values = append(values, sheet.Cell(y, x1).String())
+	}
+
+	return values
+}
+
+func TestWriteXLSX(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	file, err := xlsx.OpenBinary(writeTestXLSX(t, cp))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sheets := make(map[string]*xlsx.Sheet)
+	for _, sheet := range file.Sheets {
+		sheets[sheet.Name] = sheet
+		hidden := strings.HasPrefix(sheet.Name, "_")
+		if sheet.Hidden != hidden {
+			t.Errorf("sheet %s: hidden is %t", sheet.Name, sheet.Hidden)
+		}
+	}
+	for _, name := range []string{xlsxValuesSheet, "_" + string(RtContacts)} {
+		if sheets[name] == nil {
+			t.Errorf("no %s sheet", name)
+		}
+	}
+
+	sheet := sheets[string(RtChannels_md380)]
+	if sheet == nil {
+		t.Fatalf("no %s sheet", RtChannels_md380)
+	}
+	if len(sheet.SheetViews) == 0 || sheet.SheetViews[0].Pane == nil ||
+		sheet.SheetViews[0].Pane.State != "frozen" || sheet.SheetViews[0].Pane.YSplit != 1 {
+		t.Error("header is not frozen")
+	}
+
+	r := cp.Records(RtChannels_md380)[0]
+	tests := []struct {
+		fType FieldType
+		sheet string
+	}{
+		{FtCiPower, xlsxValuesSheet},
+		{FtCiContactName, "_" + string(RtContacts)},
+	}
+	for _, test := range tests {
+		column := -1
+		for i, cell := range sheet.Rows[0].Cells {
+			if cell.String() == string(test.fType) {
+				column = i
+				break
+			}
+		}
+		if column < 0 {
+			t.Errorf("no %s column", test.fType)
+			continue
+		}
+
+		hdr := sheet.Cell(0, column).DataValidation
+		if hdr == nil || hdr.Type != "none" || hdr.Prompt == nil ||
+			*hdr.Prompt != r.Field(test.fType).TypeName() {
+			t.Errorf("%s: header validation is %+v", test.fType, hdr)
+		}
+
+		formula := ""
+		for _, dv := range sheet.Col(column).DataValidation {
+			if dv.Type == "list" {
+				formula = dv.Formula1
+			}
+		}
+		if formula == "" {
+			t.Errorf("%s: no list validation", test.fType)
+			continue
+		}
+		if !strings.HasPrefix(formula, "'"+test.sheet+"'!") {
+			t.Errorf("%s: list is %s, want one in %s", test.fType, formula, test.sheet)
+			continue
+		}
+		want := r.Field(test.fType).Strings()
+		got := lookupValues(t, sheets, formula)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got list %q, want %q", test.fType, got, want)
+		}
+	}
+}
+
+func TestXLSXRoundTrip(t *testing.T) {
+	cp := newTestCodeplug(t, "MD-380")
+	data := writeTestXLSX(t, cp)
+
+	for _, pRec := range cp.parseXLSXFile(bytes.NewReader(data)) {
+		if pRec.err != nil {
+			t.Fatal(pRec.err)
+		}
+		if strings.HasPrefix(pRec.name, "_") {
+			t.Fatalf("hidden sheet %s was imported", pRec.name)
+		}
+	}
+
+	freqRange := AllFrequencyRanges()[cp.Type()][0]
+	ncp, err := ReadCodeplug(FileTypeXLSX, cp.Type(), freqRange, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkValid(t, ncp)
+
+	before := sortedLines(withoutSuffixes(recordStrings(cp, cp.RecordTypes()...)))
+	after := sortedLines(withoutSuffixes(recordStrings(ncp, ncp.RecordTypes()...)))
+	if !reflect.DeepEqual(before, after) {
+		t.Error("records changed")
+	}
+}
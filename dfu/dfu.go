@@ -38,7 +38,6 @@ import (
 	"strings"
 	"time"
 
-	l "github.com/dalefarnsworth/codeplug/debug"
 	"github.com/dalefarnsworth/codeplug/stdfu"
 	"github.com/dalefarnsworth/codeplug/userdb"
 )
@@ -1244,12 +1243,12 @@ func (dfu *Dfu) WriteCodeplug(data []byte) error {
 func (dfu *Dfu) WriteUsers(filename string) error {
 	fileInfo, err := os.Stat(filename)
 	if err != nil {
-		l.Fatalf("os.Stat: %s", err.Error())
+		return wrapError("WriteUsers", err)
 	}
 
 	file, err := os.Open(filename)
 	if err != nil {
-		l.Fatalf("WriteUsers: %s", err.Error())
+		return wrapError("WriteUsers", err)
 	}
 	defer file.Close()
 
@@ -1293,7 +1292,7 @@ const (
 	countryField = 6
 )
 
-func ParseUV380Users(reader io.Reader) [][]string {
+func ParseUV380Users(reader io.Reader) ([][]string, error) {
 	users := make([][]string, 0)
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
@@ -1306,10 +1305,10 @@ func ParseUV380Users(reader io.Reader) [][]string {
 		users = append(users, fields)
 	}
 	if err := scanner.Err(); err != nil {
-		l.Fatalf("ParseUsers: %s", err.Error())
+		return nil, wrapError("ParseUsers", err)
 	}
 
-	return users
+	return users, nil
 }
 
 func FilterUsers(users [][]string, countryMap map[string]bool) [][]string {
@@ -1332,6 +1331,49 @@ func FilterUsers(users [][]string, countryMap map[string]bool) [][]string {
 	return filteredUsers
 }
 
+// SelectUV380Users returns the users chosen by policy to fit within
+// MaxUV380Users, along with a report of the users dropped.
+func SelectUV380Users(users [][]string, policy *userdb.SelectionPolicy) ([][]string, *userdb.SelectionReport) {
+	dbUsers := make([]*userdb.User, 0, len(users))
+	fieldsByUser := make(map[*userdb.User][]string)
+	invalid := 0
+	for _, fields := range users {
+		if len(fields) != 7 {
+			invalid++
+			continue
+		}
+
+		id, err := strconv.ParseInt(fields[idField], 10, 64)
+		if err != nil {
+			invalid++
+			continue
+		}
+
+		u := &userdb.User{
+			ID:       int(id),
+			Callsign: fields[callField],
+			Name:     fields[nameField],
+			City:     fields[cityField],
+			State:    fields[stateField],
+			Nick:     fields[nickField],
+			Country:  fields[countryField],
+		}
+		dbUsers = append(dbUsers, u)
+		fieldsByUser[u] = fields
+	}
+
+	selected, report := policy.Select(dbUsers, MaxUV380Users)
+	report.Total += invalid
+	report.Invalid = invalid
+
+	selectedUsers := make([][]string, len(selected))
+	for i, u := range selected {
+		selectedUsers[i] = fieldsByUser[u]
+	}
+
+	return selectedUsers, report
+}
+
 func uv380UserImage(userSlice [][]string) []byte {
 	users := make([]uv380User, 0)
 	for _, fields := range userSlice {
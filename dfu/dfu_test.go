@@ -0,0 +1,46 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of Dfu.
+//
+// Dfu is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// Dfu is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Dfu.  If not, see <http://www.gnu.org/licenses/>.
+
+package dfu
+
+import (
+	"testing"
+
+	"github.com/dalefarnsworth/codeplug/userdb"
+)
+
+func TestSelectUV380UsersInvalid(t *testing.T) {
+	users := [][]string{
+		{"1000001", "N0CALL", "Joe", "Mesa", "Arizona", "", "United States"},
+		{"1000002", "N1CALL", "Ann"},
+		{"bad", "N2CALL", "Bob", "Mesa", "Arizona", "", "United States"},
+	}
+
+	selected, report := SelectUV380Users(users, &userdb.SelectionPolicy{})
+	if len(selected) != 1 || selected[0][0] != "1000001" {
+		t.Errorf("selected %v", selected)
+	}
+	if report.Invalid != 2 || report.Total != 3 || report.Selected != 1 {
+		t.Errorf("report %+v", report)
+	}
+}
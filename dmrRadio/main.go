@@ -32,6 +32,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/dalefarnsworth/codeplug/codeplug"
 	"github.com/dalefarnsworth/codeplug/debug"
@@ -51,17 +52,32 @@ func usage() {
 	errorf("\twriteFirmware <firmwareFile>\n")
 	errorf("\treadMD380Users <usersFile>\n")
 	errorf("\twriteMD380Users <usersFile>\n")
-	errorf("\twriteMD2017Users <usersFile>\n")
-	errorf("\twriteUV380Users <usersFile>\n")
+	errorf("\twriteMD2017Users [-policy <policyFile>] <usersFile>\n")
+	errorf("\twriteUV380Users [-policy <policyFile>] <usersFile>\n")
 	errorf("\treadSPIFlash <filename>\n")
-	errorf("\tgetUsers <usersFile>\n")
-	errorf("\tgetInputUsers <usersFile>\n")
+	errorf("\tgetUsers [-offline] [-cache <cacheDir>] [-rules <rulesFile> [-dryrun]] [-format <format>] <usersFile>\n")
+	errorf("\tgetInputUsers [-offline] [-cache <cacheDir>] [-rules <rulesFile> [-dryrun]] [-format <format>] <usersFile>\n")
 	errorf("\tcodeplugToText <codeplugFile> <textFile>\n")
 	errorf("\ttextToCodeplug <textFile> <codeplugFile>\n")
 	errorf("\tcodeplugToJSON <codeplugFile> <jsonFile>\n")
 	errorf("\tjsonToCodeplug <jsonFile> <codeplugFile>\n")
 	errorf("\tcodeplugToXLSX <codeplugFile> <xlsxFile>\n")
 	errorf("\txlsxToCodeplug <xlsxFile> <codeplugFile>\n")
+	errorf("\tcodeplugToCSV <codeplugFile> <directory>\n")
+	errorf("\tcsvToCodeplug <directory> <codeplugFile>\n")
+	errorf("\tcodeplugToDmrconfig <codeplugFile> <dmrconfigFile>\n")
+	errorf("\tdmrconfigToCodeplug -model <model> -freq <freqRange> <dmrconfigFile> <codeplugFile>\n")
+	errorf("\tcodeplugToQdmr <codeplugFile> <qdmrFile>\n")
+	errorf("\tqdmrToCodeplug -model <model> -freq <freqRange> <qdmrFile> <codeplugFile>\n")
+	errorf("\tcodeplugToAnytone <codeplugFile> <directory>\n")
+	errorf("\tcodeplugToOpenGD77 <codeplugFile> <directory>\n")
+	errorf("\tcodeplugToTytCSV -type <contacts|channels> <codeplugFile> <csvFile>\n")
+	errorf("\ttytCSVToCodeplug <csvFile> <codeplugFile>\n")
+	errorf("\taddContacts [-users <usersFile>] [-offline] [-cache <cacheDir>] <codeplugFile> <ID|callsign>...\n")
+	errorf("\timportRepeaters [-lat <latitude> -lon <longitude> -distance <km>] [-county] <codeplugFile> <repeaterFile>\n")
+	errorf("\tgenerateChannels -name <name> -rx <MHz> -tx <MHz> [-cc <colorCode>] <codeplugFile> <talkgroupFile>\n")
+	errorf("\timportTalkgroups [-update] <codeplugFile> <talkgroupFile>\n")
+	errorf("\tbinDiff [-context <n>] <codeplugFile> <codeplugFile>\n")
 	errorf("\tversion\n")
 	errorf("Use '%s <subCommand> -h' for subCommand help\n", os.Args[0])
 	os.Exit(1)
@@ -129,6 +145,37 @@ func progressCallback(aPrefixes []string) func(cur int) error {
 	}
 }
 
+// modelFreqUsage prints the valid model names and frequency ranges.
+func modelFreqUsage() {
+	errorf("modelName must be chosen from the following list,\n")
+	errorf("and freqRange must be one of its associated values.\n")
+	types, freqs := allTypesFrequencyRanges()
+	for _, typ := range types {
+		errorf("\t%s\n", typ)
+		for _, freq := range freqs[typ] {
+			errorf("\t\t%s\n", "\""+freq+"\"")
+		}
+	}
+}
+
+// checkModelFreq calls flags.Usage unless typ is a valid model name
+// and freq is one of its frequency ranges.
+func checkModelFreq(flags *flag.FlagSet, typ string, freq string) {
+	typeFreqs := codeplug.AllFrequencyRanges()
+	if typeFreqs[typ] == nil {
+		errorf("bad modelName\n\n")
+		flags.Usage()
+	}
+	freqMap := make(map[string]bool)
+	for _, freq := range typeFreqs[typ] {
+		freqMap[freq] = true
+	}
+	if !freqMap[freq] {
+		errorf("bad freqRange\n\n")
+		flags.Usage()
+	}
+}
+
 func readCodeplug() error {
 	var typ string
 	var freq string
@@ -140,37 +187,16 @@ func readCodeplug() error {
 	flags.Usage = func() {
 		errorf("Usage: %s %s -model <modelName> -freq <freqRange> codePlugFilename\n", os.Args[0], os.Args[1])
 		flags.PrintDefaults()
-		errorf("modelName must be chosen from the following list,\n")
-		errorf("and freqRange must be one of its associated values.\n")
-		types, freqs := allTypesFrequencyRanges()
-		for _, typ := range types {
-			errorf("\t%s\n", typ)
-			for _, freq := range freqs[typ] {
-				errorf("\t\t%s\n", "\""+freq+"\"")
-			}
-		}
+		modelFreqUsage()
 		os.Exit(1)
 	}
 
-	typeFreqs := codeplug.AllFrequencyRanges()
-
 	flags.Parse(os.Args[2:])
 	args := flags.Args()
 	if len(args) != 1 {
 		flags.Usage()
 	}
-	if typeFreqs[typ] == nil {
-		errorf("bad modelName\n\n")
-		flags.Usage()
-	}
-	freqMap := make(map[string]bool)
-	for _, freq := range typeFreqs[typ] {
-		freqMap[freq] = true
-	}
-	if !freqMap[freq] {
-		errorf("bad freqRange\n\n")
-		flags.Usage()
-	}
+	checkModelFreq(flags, typ, freq)
 	filename := args[0]
 
 	cp, err := codeplug.NewCodeplug(codeplug.FileTypeNew, "")
@@ -330,31 +356,57 @@ func writeMD380Users() error {
 }
 
 func writeMD2017Users() error {
-	filename := usersFilename()
+	return writeExpandedUsers()
+}
 
-	prefixes := []string{
-		"Erasing flash memory",
-		"Writing users",
+func writeUV380Users() error {
+	return writeExpandedUsers()
+}
+
+func writeExpandedUsers() error {
+	var policyFilename string
+
+	flags := flag.NewFlagSet("writeUsers", flag.ExitOnError)
+	flags.StringVar(&policyFilename, "policy", "", "<selection policy file>")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s [-policy <policyFilename>] <usersFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
 	}
 
-	df, err := dfu.New(progressCallback(prefixes))
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 1 {
+		flags.Usage()
+	}
+	filename := args[0]
+
+	var policy *userdb.SelectionPolicy
+	if policyFilename != "" {
+		var err error
+		policy, err = userdb.LoadSelectionPolicy(policyFilename)
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Open(filename)
 	if err != nil {
 		return err
 	}
-	defer df.Close()
+	defer file.Close()
 
-	var file *os.File
-	file, err = os.Open(filename)
+	users, err := dfu.ParseUV380Users(file)
 	if err != nil {
 		return err
 	}
 
-	users := dfu.ParseUV380Users(file)
-	return df.WriteUV380Users(users)
-}
-
-func writeUV380Users() error {
-	filename := usersFilename()
+	if policy != nil && len(users) > dfu.MaxUV380Users {
+		var report *userdb.SelectionReport
+		users, report = dfu.SelectUV380Users(users, policy)
+		fmt.Print(report.String())
+	}
 
 	prefixes := []string{
 		"Erasing flash memory",
@@ -367,63 +419,99 @@ func writeUV380Users() error {
 	}
 	defer df.Close()
 
-	var file *os.File
-	file, err = os.Open(filename)
-	if err != nil {
-		return err
-	}
-
-	users := dfu.ParseUV380Users(file)
-
 	return df.WriteUV380Users(users)
 }
 
 func getUsers() error {
+	return writeUsersDB(userdb.New())
+}
+
+func getInputUsers() error {
+	return writeUsersDB(userdb.Input())
+}
+
+func writeUsersDB(db *userdb.UsersDB) error {
+	var offline bool
+	var dryRun bool
+	var cacheDir string
+	var rulesFilename string
+	var formatName string
+
 	flags := flag.NewFlagSet("getUsers", flag.ExitOnError)
+	flags.BoolVar(&offline, "offline", false, "build the users file only from cached data")
+	flags.StringVar(&cacheDir, "cache", userdb.DefaultCacheDir(), "<cache directory>")
+	flags.StringVar(&rulesFilename, "rules", "", "<normalization rules file>")
+	flags.BoolVar(&dryRun, "dryrun", false, "report the users changed by each rule instead of writing usersFilename")
+	flags.StringVar(&formatName, "format", "md380tools", "<users file format>: "+strings.Join(userdb.FormatNames(), ", "))
 
 	flags.Usage = func() {
-		errorf("Usage: %s %s <usersFilename>\n", os.Args[0], os.Args[1])
+		errorf("Usage: %s %s [-offline] [-cache <cacheDir>] [-rules <rulesFilename> [-dryrun]] [-format <format>] <usersFilename>\n", os.Args[0], os.Args[1])
 		flags.PrintDefaults()
 		os.Exit(1)
 	}
 
 	flags.Parse(os.Args[2:])
 	args := flags.Args()
-	if len(args) != 1 {
+	if dryRun && rulesFilename == "" {
+		flags.Usage()
+	}
+	if len(args) != 1 && !(dryRun && len(args) == 0) {
 		flags.Usage()
 	}
-	filename := args[0]
 
 	prefixes := []string{
 		"Retrieving Users file",
 	}
 
-	db := userdb.New()
-	return db.WriteMD380ToolsFile(filename, progressCallback(prefixes))
-}
-
-func getInputUsers() error {
-	flags := flag.NewFlagSet("getUsers", flag.ExitOnError)
+	db.SetCacheDir(cacheDir)
+	db.SetOffline(offline)
 
-	flags.Usage = func() {
-		errorf("Usage: %s %s <usersFilename>\n", os.Args[0], os.Args[1])
-		flags.PrintDefaults()
-		os.Exit(1)
+	if rulesFilename != "" {
+		rules, err := userdb.LoadRules(rulesFilename)
+		if err != nil {
+			return err
+		}
+		db.SetRules(rules)
 	}
 
-	flags.Parse(os.Args[2:])
-	args := flags.Args()
-	if len(args) != 1 {
-		flags.Usage()
+	if dryRun {
+		ruleChanges, err := db.DryRun()
+		if err != nil {
+			return err
+		}
+		for _, rc := range ruleChanges {
+			fmt.Printf("%s: %d changes\n", rc.Rule, len(rc.Changes))
+			for _, c := range rc.Changes {
+				fmt.Printf("\t%d %s %s: %q -> %q\n",
+					c.ID, c.Callsign, c.Field, c.Old, c.New)
+			}
+		}
+		return nil
 	}
+
 	filename := args[0]
 
-	prefixes := []string{
-		"Retrieving Users file",
+	err := db.WriteFile(filename, formatName, progressCallback(prefixes))
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+
+	for _, status := range db.SourceStatuses() {
+		if status.CacheErr != nil {
+			errorf("%s: cache not updated: %s\n", status.URL, status.CacheErr.Error())
+		}
+		if !status.FromCache {
+			continue
+		}
+		age := status.Age().Truncate(time.Minute)
+		errorf("%s: using cached data, age %s\n", status.URL, age)
+		if status.Err != nil {
+			errorf("\t%s\n", status.Err.Error())
+		}
 	}
 
-	db := userdb.Input()
-	return db.WriteMD380ToolsFile(filename, progressCallback(prefixes))
+	return nil
 }
 
 func writeFirmware() error {
@@ -613,6 +701,708 @@ func codeplugToXLSX() error {
 	return cp.ExportXLSX(xlsxFilename)
 }
 
+func csvToCodeplug() error {
+	flags := flag.NewFlagSet("csvToCodeplug", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <directory> <codeplugFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	dir := args[0]
+	codeplugFilename := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeCSV, dir)
+	if err != nil {
+		return err
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func codeplugToCSV() error {
+	flags := flag.NewFlagSet("codeplugToCSV", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <codeplugFilename> <directory>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	dir := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	return cp.ExportCSV(dir)
+}
+
+func dmrconfigToCodeplug() error {
+	var typ string
+	var freq string
+
+	flags := flag.NewFlagSet("dmrconfigToCodeplug", flag.ExitOnError)
+	flags.StringVar(&typ, "model", "", "<model name>")
+	flags.StringVar(&freq, "freq", "", "<frequency range>")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s -model <modelName> -freq <freqRange> <dmrconfigFilename> <codeplugFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		modelFreqUsage()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	checkModelFreq(flags, typ, freq)
+	dmrconfigFilename := args[0]
+	codeplugFilename := args[1]
+
+	cp, err := codeplug.NewCodeplug(codeplug.FileTypeNew, "")
+	if err != nil {
+		return err
+	}
+
+	err = cp.Load(typ, freq)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(dmrconfigFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	unsupported, err := cp.ImportDmrconfig(file)
+	for _, msg := range unsupported {
+		errorf("%s: %s\n", dmrconfigFilename, msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func codeplugToDmrconfig() error {
+	flags := flag.NewFlagSet("codeplugToDmrconfig", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <codeplugFilename> <dmrconfigFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	dmrconfigFilename := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	unsupported, err := cp.ExportDmrconfig(dmrconfigFilename)
+	for _, msg := range unsupported {
+		errorf("%s: %s\n", codeplugFilename, msg)
+	}
+
+	return err
+}
+
+func qdmrToCodeplug() error {
+	var typ string
+	var freq string
+
+	flags := flag.NewFlagSet("qdmrToCodeplug", flag.ExitOnError)
+	flags.StringVar(&typ, "model", "", "<model name>")
+	flags.StringVar(&freq, "freq", "", "<frequency range>")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s -model <modelName> -freq <freqRange> <qdmrFilename> <codeplugFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		modelFreqUsage()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	checkModelFreq(flags, typ, freq)
+	qdmrFilename := args[0]
+	codeplugFilename := args[1]
+
+	cp, err := codeplug.NewCodeplug(codeplug.FileTypeNew, "")
+	if err != nil {
+		return err
+	}
+
+	err = cp.Load(typ, freq)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(qdmrFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	unsupported, err := cp.ImportQdmr(file)
+	for _, msg := range unsupported {
+		errorf("%s: %s\n", qdmrFilename, msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func codeplugToQdmr() error {
+	flags := flag.NewFlagSet("codeplugToQdmr", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <codeplugFilename> <qdmrFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	qdmrFilename := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	return cp.ExportQdmr(qdmrFilename)
+}
+
+func codeplugToAnytone() error {
+	flags := flag.NewFlagSet("codeplugToAnytone", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <codeplugFilename> <directory>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	dir := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	unsupported, err := cp.ExportAnytone(dir)
+	for _, msg := range unsupported {
+		errorf("%s: %s\n", codeplugFilename, msg)
+	}
+
+	return err
+}
+
+func codeplugToOpenGD77() error {
+	flags := flag.NewFlagSet("codeplugToOpenGD77", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <codeplugFilename> <directory>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	dir := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	unsupported, err := cp.ExportOpenGD77(dir)
+	for _, msg := range unsupported {
+		errorf("%s: %s\n", codeplugFilename, msg)
+	}
+
+	return err
+}
+
+func codeplugToTytCSV() error {
+	var typ string
+
+	flags := flag.NewFlagSet("codeplugToTytCSV", flag.ExitOnError)
+	flags.StringVar(&typ, "type", "", "<contacts|channels> to export")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s -type <contacts|channels> <codeplugFilename> <csvFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	csvFilename := args[1]
+
+	var rType codeplug.RecordType
+	switch strings.ToLower(typ) {
+	case "contacts":
+		rType = codeplug.RtContacts
+	case "channels":
+		rType = codeplug.RtChannels_md380
+	default:
+		flags.Usage()
+	}
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	return cp.ExportTytCSV(csvFilename, rType)
+}
+
+func tytCSVToCodeplug() error {
+	flags := flag.NewFlagSet("tytCSVToCodeplug", flag.ExitOnError)
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s <csvFilename> <codeplugFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	csvFilename := args[0]
+	codeplugFilename := args[1]
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(csvFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	unsupported, err := cp.ImportTytCSV(file)
+	for _, msg := range unsupported {
+		errorf("%s: %s\n", csvFilename, msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func addContacts() error {
+	var offline bool
+	var cacheDir string
+	var usersFilename string
+
+	flags := flag.NewFlagSet("addContacts", flag.ExitOnError)
+	flags.StringVar(&usersFilename, "users", "", "<users file> to use instead of the user database")
+	flags.BoolVar(&offline, "offline", false, "use only cached user database data")
+	flags.StringVar(&cacheDir, "cache", userdb.DefaultCacheDir(), "<cache directory>")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s [-users <usersFilename>] [-offline] [-cache <cacheDir>] <codeplugFilename> <ID|callsign>...\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) < 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	keys := args[1:]
+
+	var users []*userdb.User
+	if usersFilename != "" {
+		file, err := os.Open(usersFilename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		users, err = userdb.ReadUsers(file)
+		if err != nil {
+			return err
+		}
+	} else {
+		db := userdb.New()
+		db.SetCacheDir(cacheDir)
+		db.SetOffline(offline)
+
+		var err error
+		users, err = db.Users()
+		if err != nil {
+			return err
+		}
+	}
+
+	found, notFound := userdb.FindUsers(users, keys)
+	for _, key := range notFound {
+		errorf("%s: not found in user database\n", key)
+	}
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	result, err := cp.AddUserContacts(found)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range result.Added {
+		fmt.Printf("added %s\n", r.Name())
+	}
+	for _, r := range result.Updated {
+		fmt.Printf("updated %s\n", r.Name())
+	}
+	for _, r := range result.Unchanged {
+		fmt.Printf("unchanged %s\n", r.Name())
+	}
+	for _, u := range result.Skipped {
+		errorf("%d %s: skipped, contacts are full\n", u.ID, u.Callsign)
+	}
+
+	if len(result.Added) == 0 && len(result.Updated) == 0 {
+		return nil
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func importRepeaters() error {
+	var opts codeplug.RepeaterOptions
+
+	flags := flag.NewFlagSet("importRepeaters", flag.ExitOnError)
+	flags.Float64Var(&opts.Latitude, "lat", 0, "<latitude> of the center of the area")
+	flags.Float64Var(&opts.Longitude, "lon", 0, "<longitude> of the center of the area")
+	flags.Float64Var(&opts.MaxDistance, "distance", 0, "import only repeaters within <km> of the center")
+	flags.BoolVar(&opts.ZoneByCounty, "county", false, "create a zone per county instead of per city")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s [-lat <latitude> -lon <longitude> -distance <km>] [-county] <codeplugFilename> <repeaterFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	repeaterFilename := args[1]
+
+	file, err := os.Open(repeaterFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	repeaters, err := codeplug.ReadRepeaters(file)
+	if err != nil {
+		return fmt.Errorf("%s: %s", repeaterFilename, err.Error())
+	}
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	result, err := cp.ImportRepeaters(repeaters, opts)
+	for _, msg := range result.Skipped {
+		errorf("%s: skipped, %s\n", repeaterFilename, msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, r := range result.Channels {
+		fmt.Printf("added channel %s\n", r.Name())
+	}
+	for _, r := range result.Zones {
+		fmt.Printf("added zone %s\n", r.Name())
+	}
+
+	if len(result.Channels) == 0 {
+		return nil
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func generateChannels() error {
+	var name string
+	var rptr codeplug.Repeater
+
+	flags := flag.NewFlagSet("generateChannels", flag.ExitOnError)
+	flags.StringVar(&name, "name", "", "<name> of the repeater's zone, scan list and group list")
+	flags.Float64Var(&rptr.RxFrequency, "rx", 0, "repeater output frequency in <MHz>")
+	flags.Float64Var(&rptr.TxFrequency, "tx", 0, "repeater input frequency in <MHz>")
+	flags.IntVar(&rptr.ColorCode, "cc", 1, "repeater <colorCode>")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s -name <name> -rx <MHz> -tx <MHz> [-cc <colorCode>] <codeplugFilename> <talkgroupFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 || name == "" || rptr.RxFrequency == 0 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	talkgroupFilename := args[1]
+
+	if rptr.TxFrequency == 0 {
+		rptr.TxFrequency = rptr.RxFrequency
+	}
+	rptr.Digital = true
+
+	file, err := os.Open(talkgroupFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	talkgroups, err := codeplug.ReadTalkgroups(file)
+	if err != nil {
+		return fmt.Errorf("%s: %s", talkgroupFilename, err.Error())
+	}
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	result, err := cp.GenerateChannels(name, &rptr, talkgroups)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range result.Skipped {
+		errorf("%s: skipped, %s\n", talkgroupFilename, msg)
+	}
+	for _, r := range result.Contacts {
+		fmt.Printf("added contact %s\n", r.Name())
+	}
+	for _, r := range result.Channels {
+		fmt.Printf("added channel %s\n", r.Name())
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func importTalkgroups() error {
+	var update bool
+
+	flags := flag.NewFlagSet("importTalkgroups", flag.ExitOnError)
+	flags.BoolVar(&update, "update", false, "rename existing contacts to the talkgroups' names")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s [-update] <codeplugFilename> <talkgroupFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 {
+		flags.Usage()
+	}
+	codeplugFilename := args[0]
+	talkgroupFilename := args[1]
+
+	file, err := os.Open(talkgroupFilename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	talkgroups, err := codeplug.ReadTalkgroups(file)
+	if err != nil {
+		return fmt.Errorf("%s: %s", talkgroupFilename, err.Error())
+	}
+
+	cp, err := loadCodeplug(codeplug.FileTypeNone, codeplugFilename)
+	if err != nil {
+		return err
+	}
+
+	result, err := cp.ImportTalkgroups(talkgroups, update)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range result.Added {
+		fmt.Printf("added %s\n", r.Name())
+	}
+	for _, r := range result.Updated {
+		fmt.Printf("updated %s\n", r.Name())
+	}
+	for _, r := range result.Unchanged {
+		fmt.Printf("unchanged %s\n", r.Name())
+	}
+	for _, msg := range result.Skipped {
+		errorf("%s: skipped, %s\n", talkgroupFilename, msg)
+	}
+
+	if len(result.Added) == 0 && len(result.Updated) == 0 {
+		return nil
+	}
+
+	return cp.SaveAs(codeplugFilename)
+}
+
+func binDiff() error {
+	var context int
+
+	flags := flag.NewFlagSet("binDiff", flag.ExitOnError)
+	flags.IntVar(&context, "context", 16, "show <n> bytes of context around unknown changes")
+
+	flags.Usage = func() {
+		errorf("Usage: %s %s [-context <n>] <codeplugFilename> <codeplugFilename>\n", os.Args[0], os.Args[1])
+		flags.PrintDefaults()
+		os.Exit(1)
+	}
+
+	flags.Parse(os.Args[2:])
+	args := flags.Args()
+	if len(args) != 2 || context < 0 {
+		flags.Usage()
+	}
+	oldFilename := args[0]
+	newFilename := args[1]
+
+	oldCp, err := loadCodeplug(codeplug.FileTypeNone, oldFilename)
+	if err != nil {
+		return fmt.Errorf("%s: %s", oldFilename, err.Error())
+	}
+
+	newCp, err := loadCodeplug(codeplug.FileTypeNone, newFilename)
+	if err != nil {
+		return fmt.Errorf("%s: %s", newFilename, err.Error())
+	}
+
+	diff, err := codeplug.BinaryDiff(oldCp, newCp, context)
+	if err != nil {
+		return err
+	}
+
+	for _, fd := range diff.Fields {
+		name := fd.RecordTypeName
+		if fd.RecordName != "" {
+			name += fmt.Sprintf("[%d:%s]", fd.RecordIndex, fd.RecordName)
+		} else if oldCp.MaxRecords(fd.RecordType) > 1 {
+			name += fmt.Sprintf("[%d]", fd.RecordIndex)
+		}
+		name += "." + fd.FieldTypeName
+		if fd.MaxFields > 1 {
+			name += fmt.Sprintf("[%d]", fd.FieldIndex)
+		}
+
+		fmt.Printf("%s: %q -> %q\n", name, fd.OldValue, fd.NewValue)
+		fmt.Printf("\toffset 0x%06x, bitOffset %d, bitSize %d\n", fd.Offset, fd.BitOffset, fd.BitSize)
+		for _, bd := range fd.Bytes {
+			fmt.Printf("\t0x%06x: %02x -> %02x, bits %08b\n", bd.Offset, bd.Old, bd.New, bd.Mask)
+		}
+	}
+
+	for _, ud := range diff.Unknown {
+		first := ud.Bytes[0].Offset
+		last := ud.Bytes[len(ud.Bytes)-1].Offset
+		fmt.Printf("unknown: 0x%06x-0x%06x\n", first, last)
+		unknown := make(map[int]bool)
+		for _, bd := range ud.Bytes {
+			fmt.Printf("\t0x%06x: %02x -> %02x, bits %08b\n", bd.Offset, bd.Old, bd.New, bd.Mask)
+			unknown[bd.Offset] = true
+		}
+
+		for i := 0; i < len(ud.OldContext); i += 16 {
+			end := i + 16
+			if end > len(ud.OldContext) {
+				end = len(ud.OldContext)
+			}
+
+			marks := make([]string, end-i)
+			for j := range marks {
+				marks[j] = "  "
+				if unknown[ud.ContextOffset+i+j] {
+					marks[j] = "^^"
+				}
+			}
+
+			fmt.Printf("\t0x%06x  % x\n", ud.ContextOffset+i, ud.OldContext[i:end])
+			fmt.Printf("\t          % x\n", ud.NewContext[i:end])
+			line := strings.TrimRight(strings.Join(marks, " "), " ")
+			if line != "" {
+				fmt.Printf("\t          %s\n", line)
+			}
+		}
+	}
+
+	return nil
+}
+
 func printVersion() error {
 	flags := flag.NewFlagSet("version", flag.ExitOnError)
 
@@ -643,23 +1433,38 @@ func main() {
 	subCommandName := strings.ToLower(os.Args[1])
 
 	subCommands := map[string]func() error{
-		"readcodeplug":     readCodeplug,
-		"writecodeplug":    writeCodeplug,
-		"readspiflash":     readSPIFlash,
-		"readmd380users":   readMD380Users,
-		"writemd380users":  writeMD380Users,
-		"writemd2017users": writeMD2017Users,
-		"writeuv380users":  writeUV380Users,
-		"getusers":         getUsers,
-		"getinputusers":    getInputUsers,
-		"writefirmware":    writeFirmware,
-		"texttocodeplug":   textToCodeplug,
-		"codeplugtotext":   codeplugToText,
-		"jsontocodeplug":   jsonToCodeplug,
-		"codeplugtojson":   codeplugToJSON,
-		"xlsxtocodeplug":   xlsxToCodeplug,
-		"codeplugtoxlsx":   codeplugToXLSX,
-		"version":          printVersion,
+		"readcodeplug":        readCodeplug,
+		"writecodeplug":       writeCodeplug,
+		"readspiflash":        readSPIFlash,
+		"readmd380users":      readMD380Users,
+		"writemd380users":     writeMD380Users,
+		"writemd2017users":    writeMD2017Users,
+		"writeuv380users":     writeUV380Users,
+		"getusers":            getUsers,
+		"getinputusers":       getInputUsers,
+		"writefirmware":       writeFirmware,
+		"texttocodeplug":      textToCodeplug,
+		"codeplugtotext":      codeplugToText,
+		"jsontocodeplug":      jsonToCodeplug,
+		"codeplugtojson":      codeplugToJSON,
+		"xlsxtocodeplug":      xlsxToCodeplug,
+		"codeplugtocsv":       codeplugToCSV,
+		"csvtocodeplug":       csvToCodeplug,
+		"codeplugtoxlsx":      codeplugToXLSX,
+		"codeplugtodmrconfig": codeplugToDmrconfig,
+		"dmrconfigtocodeplug": dmrconfigToCodeplug,
+		"codeplugtoqdmr":      codeplugToQdmr,
+		"qdmrtocodeplug":      qdmrToCodeplug,
+		"codeplugtoanytone":   codeplugToAnytone,
+		"codeplugtoopengd77":  codeplugToOpenGD77,
+		"codeplugtotytcsv":    codeplugToTytCSV,
+		"tytcsvtocodeplug":    tytCSVToCodeplug,
+		"addcontacts":         addContacts,
+		"importrepeaters":     importRepeaters,
+		"generatechannels":    generateChannels,
+		"importtalkgroups":    importTalkgroups,
+		"bindiff":             binDiff,
+		"version":             printVersion,
 	}
 
 	subCommand := subCommands[subCommandName]
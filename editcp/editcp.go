@@ -392,6 +392,7 @@ func (edt *editor) openCodeplug(fType codeplug.FileType, filename string) {
 			ui.ErrorPopup("Codeplug Load Error", err.Error())
 			return
 		}
+		cp.Register()
 		if !cp.Valid() {
 			fmtStr := `
 %d records with invalid field values were found in the codeplug.
@@ -787,6 +788,22 @@ func (edt *editor) updateMenuBar() {
 		edt.importJSON()
 	})
 
+	importMenu.AddAction("Import CSV directory...", func() {
+		edt.importCSV()
+	})
+
+	importMenu.AddAction("Import dmrconfig file...", func() {
+		edt.importDmrconfig()
+	})
+
+	importMenu.AddAction("Import qdmr file...", func() {
+		edt.importQdmr()
+	})
+
+	importMenu.AddAction("Import TYT CSV file...", func() {
+		edt.importTytCSV()
+	}).SetEnabled(cp != nil)
+
 	exportMenu := menu.AddMenu("Export...")
 	exportMenu.SetEnabled(cp != nil)
 
@@ -806,6 +823,34 @@ func (edt *editor) updateMenuBar() {
 		edt.exportJSON()
 	})
 
+	exportMenu.AddAction("Export to CSV directory...", func() {
+		edt.exportCSV()
+	})
+
+	exportMenu.AddAction("Export to dmrconfig...", func() {
+		edt.exportDmrconfig()
+	})
+
+	exportMenu.AddAction("Export to qdmr...", func() {
+		edt.exportQdmr()
+	})
+
+	exportMenu.AddAction("Export to Anytone CSV...", func() {
+		edt.exportAnytone()
+	})
+
+	exportMenu.AddAction("Export to OpenGD77 CSV...", func() {
+		edt.exportOpenGD77()
+	})
+
+	exportMenu.AddAction("Export contacts to TYT CSV...", func() {
+		edt.exportTytCSV(codeplug.RtContacts)
+	})
+
+	exportMenu.AddAction("Export channels to TYT CSV...", func() {
+		edt.exportTytCSV(codeplug.RtChannels_md380)
+	})
+
 	menu.AddSeparator()
 
 	menu.AddAction("Save", func() {
@@ -1216,6 +1261,252 @@ func (edt *editor) exportJSON() {
 	}
 }
 
+func (edt *editor) importCSV() {
+	dir := ui.OpenDirectory("Import CSV directory", settings.codeplugDirectory)
+	if dir == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(dir)
+	saveSettings()
+
+	newEditor(edt.app, codeplug.FileTypeCSV, dir)
+}
+
+func (edt *editor) exportCSV() {
+	dir := ui.OpenDirectory("Export to CSV directory", settings.codeplugDirectory)
+	if dir == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(dir)
+	saveSettings()
+
+	err := edt.codeplug.ExportCSV(dir)
+	if err != nil {
+		title := fmt.Sprintf("Export to %s", dir)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+}
+
+func (edt *editor) importDmrconfig() {
+	dir := settings.codeplugDirectory
+	filename := ui.OpenDmrconfigFilename("Import dmrconfig file", dir)
+	if filename == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(filename)
+	saveSettings()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		title := fmt.Sprintf("Import %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	defer file.Close()
+
+	edt = newEditor(edt.app, codeplug.FileTypeNew, "")
+	if edt == nil {
+		return
+	}
+	cp := edt.codeplug
+
+	unsupported, err := cp.ImportDmrconfig(file)
+	if err != nil {
+		title := fmt.Sprintf("Import %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	if len(unsupported) != 0 {
+		title := fmt.Sprintf("Import %s", filename)
+		msg := "The following settings were not imported:\n\n"
+		msg += strings.Join(unsupported, "\n")
+		ui.InfoPopup(title, msg)
+	}
+	edt.updateMenuBar()
+}
+
+func (edt *editor) exportDmrconfig() {
+	dir := settings.codeplugDirectory
+	base := baseFilename(edt.codeplug.Filename())
+	ext := "conf"
+	dir = filepath.Join(dir, base+"."+ext)
+	filename := ui.SaveFilename("Export to dmrconfig file", dir, ext)
+	if filename == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(filename)
+	saveSettings()
+
+	unsupported, err := edt.codeplug.ExportDmrconfig(filename)
+	if err != nil {
+		title := fmt.Sprintf("Export to %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	if len(unsupported) != 0 {
+		title := fmt.Sprintf("Export to %s", filename)
+		msg := "The following settings were not exported:\n\n"
+		msg += strings.Join(unsupported, "\n")
+		ui.InfoPopup(title, msg)
+	}
+}
+
+func (edt *editor) importQdmr() {
+	dir := settings.codeplugDirectory
+	filename := ui.OpenQdmrFilename("Import qdmr file", dir)
+	if filename == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(filename)
+	saveSettings()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		title := fmt.Sprintf("Import %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	defer file.Close()
+
+	edt = newEditor(edt.app, codeplug.FileTypeNew, "")
+	if edt == nil {
+		return
+	}
+	cp := edt.codeplug
+
+	unsupported, err := cp.ImportQdmr(file)
+	if err != nil {
+		title := fmt.Sprintf("Import %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	if len(unsupported) != 0 {
+		title := fmt.Sprintf("Import %s", filename)
+		msg := "The following settings were not imported:\n\n"
+		msg += strings.Join(unsupported, "\n")
+		ui.InfoPopup(title, msg)
+	}
+	edt.updateMenuBar()
+}
+
+func (edt *editor) exportQdmr() {
+	dir := settings.codeplugDirectory
+	base := baseFilename(edt.codeplug.Filename())
+	ext := "yaml"
+	dir = filepath.Join(dir, base+"."+ext)
+	filename := ui.SaveFilename("Export to qdmr file", dir, ext)
+	if filename == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(filename)
+	saveSettings()
+
+	err := edt.codeplug.ExportQdmr(filename)
+	if err != nil {
+		title := fmt.Sprintf("Export to %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+}
+
+func (edt *editor) exportAnytone() {
+	dir := ui.OpenDirectory("Export to Anytone CSV directory", settings.codeplugDirectory)
+	if dir == "" {
+		return
+	}
+	settings.codeplugDirectory = dir
+	saveSettings()
+
+	unsupported, err := edt.codeplug.ExportAnytone(dir)
+	if err != nil {
+		title := fmt.Sprintf("Export to %s", dir)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	if len(unsupported) != 0 {
+		title := fmt.Sprintf("Export to %s", dir)
+		msg := "The following settings were not exported:\n\n"
+		msg += strings.Join(unsupported, "\n")
+		ui.InfoPopup(title, msg)
+	}
+}
+
+func (edt *editor) exportOpenGD77() {
+	dir := ui.OpenDirectory("Export to OpenGD77 CSV directory", settings.codeplugDirectory)
+	if dir == "" {
+		return
+	}
+	settings.codeplugDirectory = dir
+	saveSettings()
+
+	unsupported, err := edt.codeplug.ExportOpenGD77(dir)
+	if err != nil {
+		title := fmt.Sprintf("Export to %s", dir)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	if len(unsupported) != 0 {
+		title := fmt.Sprintf("Export to %s", dir)
+		msg := "The following settings were not exported:\n\n"
+		msg += strings.Join(unsupported, "\n")
+		ui.InfoPopup(title, msg)
+	}
+}
+
+func (edt *editor) importTytCSV() {
+	dir := settings.codeplugDirectory
+	filename := ui.OpenCSVFilename("Import TYT CSV file", dir)
+	if filename == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(filename)
+	saveSettings()
+
+	file, err := os.Open(filename)
+	if err != nil {
+		title := fmt.Sprintf("Import %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	defer file.Close()
+
+	unsupported, err := edt.codeplug.ImportTytCSV(file)
+	edt.mainWindow.CodeplugChanged(nil)
+	edt.updateMenuBar()
+	if err != nil {
+		title := fmt.Sprintf("Import %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+	if len(unsupported) != 0 {
+		title := fmt.Sprintf("Import %s", filename)
+		msg := "The following settings were not imported:\n\n"
+		msg += strings.Join(unsupported, "\n")
+		ui.InfoPopup(title, msg)
+	}
+}
+
+func (edt *editor) exportTytCSV(rType codeplug.RecordType) {
+	dir := settings.codeplugDirectory
+	base := baseFilename(edt.codeplug.Filename())
+	ext := "csv"
+	dir = filepath.Join(dir, base+"_"+strings.ToLower(string(rType))+"."+ext)
+	filename := ui.SaveFilename("Export to TYT CSV file", dir, ext)
+	if filename == "" {
+		return
+	}
+	settings.codeplugDirectory = filepath.Dir(filename)
+	saveSettings()
+
+	err := edt.codeplug.ExportTytCSV(filename, rType)
+	if err != nil {
+		title := fmt.Sprintf("Export to %s", filename)
+		ui.ErrorPopup(title, err.Error())
+		return
+	}
+}
+
 func about() {
 	msg := fmt.Sprintf("editcp Version %s\n", version)
 	msg += `
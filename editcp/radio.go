@@ -197,8 +197,11 @@ func writeExpandedUsers(title, text string) {
 		file, err := os.Open(filename)
 		if err == nil {
 			defer file.Close()
-			users := dfu.ParseUV380Users(file)
-			err = df.WriteUV380Users(users)
+			var users [][]string
+			users, err = dfu.ParseUV380Users(file)
+			if err == nil {
+				err = df.WriteUV380Users(users)
+			}
 		}
 	}
 	if err != nil {
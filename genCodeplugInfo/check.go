@@ -0,0 +1,89 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of GenCodeplugInfo.
+//
+// GenCodeplugInfo is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU General Public License
+// as published by the Free Software Foundation.
+//
+// GenCodeplugInfo is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with GenCodeplugInfo.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"github.com/dalefarnsworth/codeplug/codeplug/layout"
+)
+
+// checkLayout returns a description of each inconsistency found in
+// the layout of the codeplug descriptions.
+func checkLayout(top *top) []string {
+	l := &layout.Layout{}
+
+	for _, c := range top.Codeplugs {
+		l.Codeplugs = append(l.Codeplugs, &layout.Codeplug{
+			Type:        c.Type,
+			RdtSize:     c.RdtSize,
+			RecordTypes: c.RecordTypes,
+		})
+	}
+
+	for _, r := range top.Records {
+		lr := &layout.Record{
+			Type:       r.Type,
+			Offset:     r.Offset,
+			Size:       r.Size,
+			Max:        r.Max,
+			FieldTypes: r.FieldTypes,
+		}
+		if r.DelDesc != nil {
+			lr.DelDesc = &layout.DelDesc{
+				Offset: r.DelDesc.Offset,
+				Size:   r.DelDesc.Size,
+			}
+		}
+		l.Records = append(l.Records, lr)
+	}
+
+	for _, f := range top.Fields {
+		lf := &layout.Field{
+			Type:         f.Type,
+			ValueType:    f.ValueType,
+			BitOffset:    f.BitOffset,
+			BitSize:      f.BitSize,
+			Max:          f.Max,
+			ExtOffset:    f.ExtOffset,
+			ExtSize:      f.ExtSize,
+			ExtIndex:     f.ExtIndex,
+			ExtBitOffset: f.ExtBitOffset,
+		}
+		if f.ListType != nil {
+			lf.ListType = *f.ListType
+		}
+		enables := f.EnablesIn
+		if f.EnableIn != nil {
+			enables = append(enables, f.EnableIn)
+		}
+		for _, enable := range enables {
+			lf.Enables = append(lf.Enables, layout.Enable{
+				Value:   enable.Value,
+				Enables: enable.Enables,
+			})
+		}
+		l.Fields = append(l.Fields, lf)
+	}
+
+	return l.Check()
+}
@@ -54,6 +54,8 @@ type TemplateVars struct {
 	ValueTypes       []string
 	ListRecordTypes  []string
 	FieldRefsMap     map[string][]FieldRef
+	Views            []*View
+	Enums            []*Enum
 	Sanitize         func(string) string
 	RecordTypeString func(string) string
 	FieldTypeString  func(string) string
@@ -299,6 +301,14 @@ func readCodeplugJson(filename string) TemplateVars {
 		sortedRecords[i] = r
 	}
 
+	problems := checkLayout(&top)
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Fprintln(os.Stderr, problem)
+		}
+		os.Exit(1)
+	}
+
 	sortRecords(sortedRecords)
 	templateVars.Records = top.Records
 	templateVars.SortedRecords = sortedRecords
@@ -324,6 +334,8 @@ func readCodeplugJson(filename string) TemplateVars {
 	sort.Strings(lrtStrings)
 	templateVars.ListRecordTypes = lrtStrings
 
+	templateVars.Views, templateVars.Enums = views(sortedRecords, fieldMap)
+
 	return templateVars
 }
 
@@ -365,6 +377,11 @@ func main() {
 	linesFilename := "genCodeplugInfo.lines"
 
 	filenames := os.Args[1:]
+	if len(filenames) == 2 && filenames[0] == "-check" {
+		readCodeplugJson(filenames[1])
+		os.Exit(0)
+	}
+
 	if len(filenames) > 0 {
 		writeTypesFile(codeFilename, filenames[0])
 	}
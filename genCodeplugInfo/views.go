@@ -0,0 +1,257 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of GenCodeplugInfo.
+//
+// GenCodeplugInfo is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU General Public License
+// as published by the Free Software Foundation.
+//
+// GenCodeplugInfo is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with GenCodeplugInfo.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// viewNames maps the record types for which typed views are generated
+// to the name of a single record's view.
+var viewNames = map[string]string{
+	"Channels":        "Channel",
+	"Contacts":        "Contact",
+	"GeneralSettings": "GeneralSettings",
+	"GroupLists":      "GroupList",
+	"ScanLists":       "ScanList",
+	"Zones":           "Zone",
+}
+
+// View describes a typed view of the records of a record type.
+type View struct {
+	Name      string
+	RType     string
+	TypeName  string
+	Accessors []*Accessor
+}
+
+// Accessor describes the getter and setter of a field in a view.
+type Accessor struct {
+	Name     string
+	FType    string
+	TypeName string
+	Kind     string
+	GoType   string
+	View     string
+}
+
+// Enum describes the string constants of an enumerated field.
+type Enum struct {
+	Type     string
+	TypeName string
+	Values   []EnumValue
+}
+
+// EnumValue is a single constant of an Enum.
+type EnumValue struct {
+	Name   string
+	String string
+}
+
+func viewName(rType string) string {
+	name := viewNames[RecordTypeString(rType)]
+	if name == "" {
+		return ""
+	}
+
+	index := strings.LastIndex(rType, "_")
+	if index > 0 {
+		name += rType[index:]
+	}
+
+	return name
+}
+
+var utcOffsetRegexp = regexp.MustCompile(`[-+][0-9]+:[0-9]+$`)
+
+// enumValueName returns a go identifier suffix for the string s.
+func enumValueName(s string) string {
+	if loc := utcOffsetRegexp.FindStringIndex(s); loc != nil {
+		sign := "Plus"
+		if s[loc[0]] == '-' {
+			sign = "Minus"
+		}
+		s = s[:loc[0]] + sign + s[loc[0]+1:]
+	}
+	s = strings.Replace(s, "+", " Plus ", -1)
+
+	var name []rune
+	upper := true
+	for _, c := range s {
+		switch {
+		case c == ' ':
+			upper = true
+
+		case c == '.' || c == ':' || c == '-' || c == '/':
+			name = append(name, '_')
+			upper = true
+
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			if upper {
+				c = unicode.ToUpper(c)
+			}
+			name = append(name, c)
+			upper = false
+		}
+	}
+
+	return string(name)
+}
+
+func newEnum(f *Field) *Enum {
+	var strs []string
+	if f.Strings != nil {
+		strs = *f.Strings
+	} else if f.IndexedStrings != nil {
+		for _, is := range *f.IndexedStrings {
+			strs = append(strs, is.String)
+		}
+	}
+
+	if len(strs) == 0 {
+		return nil
+	}
+
+	enum := &Enum{
+		Type:     f.Type,
+		TypeName: f.TypeName,
+	}
+	seen := make(map[string]bool)
+	for _, s := range strs {
+		name := f.Type + enumValueName(s)
+		if seen[name] {
+			fmt.Fprintf(os.Stderr, "duplicate enum constant %s\n", name)
+			os.Exit(1)
+		}
+		seen[name] = true
+		enum.Values = append(enum.Values, EnumValue{name, s})
+	}
+
+	return enum
+}
+
+func newAccessor(f *Field, enums map[string]*Enum) *Accessor {
+	a := &Accessor{
+		Name:     FieldTypeString(f.Type),
+		FType:    f.Type,
+		TypeName: f.TypeName,
+	}
+
+	switch f.ValueType {
+	case "offOn", "onOff":
+		a.Kind = "bool"
+		a.GoType = "bool"
+
+	case "frequency", "biFrequency", "frequencyOffset":
+		a.Kind = "frequency"
+		a.GoType = "float64"
+
+	case "callID", "span", "spanList", "privacyNumber":
+		a.Kind = "int"
+		a.GoType = "int"
+
+	case "iStrings", "indexedStrings", "callType", "bandwidth":
+		enum := newEnum(f)
+		if enum == nil {
+			break
+		}
+		enums[f.Type] = enum
+		a.Kind = "enum"
+		a.GoType = f.Type
+
+	case "listIndex", "memberListIndex", "contactListIndex":
+		if f.ListType == nil || viewName(*f.ListType) == "" {
+			break
+		}
+		a.View = viewName(*f.ListType)
+		a.Kind = "record"
+		a.GoType = "*" + a.View
+		if f.Max > 1 {
+			a.Kind = "records"
+			a.GoType = "[]*" + a.View
+		}
+	}
+
+	if a.Kind == "" {
+		a.Kind = "string"
+		a.GoType = "string"
+	}
+
+	return a
+}
+
+// views returns the typed views of the records in viewNames, along
+// with the enumerated types used by their accessors.
+func views(records []*Record, fieldMap map[string]*Field) ([]*View, []*Enum) {
+	var views []*View
+	enumMap := make(map[string]*Enum)
+
+	for _, r := range records {
+		name := viewName(r.Type)
+		if name == "" {
+			continue
+		}
+
+		view := &View{
+			Name:     name,
+			RType:    r.Type,
+			TypeName: r.TypeName,
+		}
+
+		seen := make(map[string]string)
+		for _, fType := range r.FieldTypes {
+			f := fieldMap[fType]
+			a := newAccessor(f, enumMap)
+			if seenType, ok := seen[a.Name]; ok {
+				if seenType == fType {
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "%s: duplicate accessor %s\n", r.Type, a.Name)
+				os.Exit(1)
+			}
+			seen[a.Name] = fType
+			view.Accessors = append(view.Accessors, a)
+		}
+
+		views = append(views, view)
+	}
+
+	enumTypes := make([]string, 0, len(enumMap))
+	for eType := range enumMap {
+		enumTypes = append(enumTypes, eType)
+	}
+	sort.Strings(enumTypes)
+
+	enums := make([]*Enum, len(enumTypes))
+	for i, eType := range enumTypes {
+		enums[i] = enumMap[eType]
+	}
+
+	return views, enums
+}
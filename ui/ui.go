@@ -156,13 +156,14 @@ func DelayedCall(f func()) {
 }
 
 type MainWindow struct {
-	qMainWindow      widgets.QMainWindow
-	codeplug         *codeplug.Codeplug
-	recordWindows    map[codeplug.RecordType]*Window
-	altRecordWindows map[codeplug.RecordType]*Window
-	connectClose     func() bool
-	connectChange    func(*codeplug.Change)
-	changing         bool
+	qMainWindow        widgets.QMainWindow
+	codeplug           *codeplug.Codeplug
+	recordWindows      map[codeplug.RecordType]*Window
+	altRecordWindows   map[codeplug.RecordType]*Window
+	connectClose       func() bool
+	connectChange      func(*codeplug.Change)
+	changeSubscription *codeplug.Subscription
+	changing           bool
 }
 
 func (mw *MainWindow) SetCodeplug(cp *codeplug.Codeplug) {
@@ -170,7 +171,12 @@ func (mw *MainWindow) SetCodeplug(cp *codeplug.Codeplug) {
 	mw.recordWindows = make(map[codeplug.RecordType]*Window)
 	mw.altRecordWindows = make(map[codeplug.RecordType]*Window)
 
-	mw.codeplug.ConnectChange(func(change *codeplug.Change) {
+	if mw.changeSubscription != nil {
+		mw.changeSubscription.Unsubscribe()
+	}
+
+	filter := codeplug.ChangeFilter{}
+	mw.changeSubscription = cp.SubscribeChanges(filter, func(change *codeplug.Change) {
 		mw := mainWindow(change.Codeplug())
 		windows := make([]*Window, 0)
 		for _, w := range mw.recordWindows {
@@ -217,6 +223,10 @@ func NewMainWindow() *MainWindow {
 			w.Close()
 		}
 
+		if mw.changeSubscription != nil {
+			mw.changeSubscription.Unsubscribe()
+		}
+
 		for i, mainWindow := range mainWindows {
 			if mainWindow == mw {
 				mainWindows = append(mainWindows[:i], mainWindows[i+1:]...)
@@ -2147,6 +2157,28 @@ func OpenXLSXFilename(title string, dir string) string {
 	return widgets.QFileDialog_GetOpenFileName(nil, title, dir, filter, selF, 0)
 }
 
+func OpenDmrconfigFilename(title string, dir string) string {
+	selF := "(*.conf)"
+	filter := "dmrconfig files " + selF + ";;All files (*)"
+	return widgets.QFileDialog_GetOpenFileName(nil, title, dir, filter, selF, 0)
+}
+
+func OpenQdmrFilename(title string, dir string) string {
+	selF := "(*.yaml)"
+	filter := "qdmr files " + selF + ";;All files (*)"
+	return widgets.QFileDialog_GetOpenFileName(nil, title, dir, filter, selF, 0)
+}
+
+func OpenCSVFilename(title string, dir string) string {
+	selF := "(*.csv)"
+	filter := "CSV files " + selF + ";;All files (*)"
+	return widgets.QFileDialog_GetOpenFileName(nil, title, dir, filter, selF, 0)
+}
+
+func OpenDirectory(title string, dir string) string {
+	return widgets.QFileDialog_GetExistingDirectory(nil, title, dir, widgets.QFileDialog__ShowDirsOnly)
+}
+
 func OpenCPFilenames(title string, dir string, exts []string) []string {
 	for i, ext := range exts {
 		exts[i] = "*." + ext
@@ -0,0 +1,311 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SourceStatus - Information about the data retrieved from a source URL
+type SourceStatus struct {
+	URL         string
+	Fetched     time.Time // time the data was last known to be current
+	FromCache   bool      // the cached data stood in for the source
+	Revalidated bool      // the source confirmed the cached data is current
+	Err         error     // download error that caused use of the cache
+	CacheErr    error     // failure to update the cache
+}
+
+// Age - Return the age of the source's data
+func (s *SourceStatus) Age() time.Duration {
+	return time.Since(s.Fetched)
+}
+
+// cacheMeta is stored alongside each cached source's data
+type cacheMeta struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+}
+
+// DefaultCacheDir - Return the default directory for cached source data
+func DefaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, "userdb")
+}
+
+// SetCacheDir - Set the directory used to cache source data.
+// An empty dir disables the cache.
+func (db *UsersDB) SetCacheDir(dir string) {
+	db.cacheDir = dir
+}
+
+// SetOffline - When offline, the database is built only from cached data.
+func (db *UsersDB) SetOffline(offline bool) {
+	db.offline = offline
+}
+
+// SourceStatuses - Return the status of each source used by the most
+// recent retrieval of users, ordered by URL.
+func (db *UsersDB) SourceStatuses() []*SourceStatus {
+	db.statusMutex.Lock()
+	defer db.statusMutex.Unlock()
+
+	urls := make([]string, 0, len(db.statuses))
+	for url := range db.statuses {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+
+	statuses := make([]*SourceStatus, len(urls))
+	for i, url := range urls {
+		status := *db.statuses[url]
+		statuses[i] = &status
+	}
+
+	return statuses
+}
+
+func (db *UsersDB) setStatus(status *SourceStatus) {
+	db.statusMutex.Lock()
+	defer db.statusMutex.Unlock()
+
+	if db.statuses == nil {
+		db.statuses = make(map[string]*SourceStatus)
+	}
+	db.statuses[status.URL] = status
+}
+
+func (db *UsersDB) resetStatuses() {
+	db.statusMutex.Lock()
+	defer db.statusMutex.Unlock()
+
+	db.statuses = make(map[string]*SourceStatus)
+}
+
+func cacheBaseName(url string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.' || r == '-':
+			return r
+		}
+		return '_'
+	}, url)
+
+	return name
+}
+
+func (db *UsersDB) cachePaths(url string) (dataPath, metaPath string) {
+	base := filepath.Join(db.cacheDir, cacheBaseName(url))
+	return base + ".data", base + ".json"
+}
+
+// readCache returns the cached data and its metadata for url.
+func (db *UsersDB) readCache(url string) ([]byte, *cacheMeta, error) {
+	dataPath, metaPath := db.cachePaths(url)
+
+	metaBytes, err := ioutil.ReadFile(metaPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var meta cacheMeta
+	err = json.Unmarshal(metaBytes, &meta)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %s", metaPath, err.Error())
+	}
+
+	bytes, err := ioutil.ReadFile(dataPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bytes, &meta, nil
+}
+
+// writeCache stores data and its metadata for url in the cache.
+func (db *UsersDB) writeCache(url string, bytes []byte, meta *cacheMeta) error {
+	err := os.MkdirAll(db.cacheDir, os.ModeDir|0755)
+	if err != nil {
+		return err
+	}
+
+	metaBytes, err := json.MarshalIndent(meta, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	dataPath, metaPath := db.cachePaths(url)
+
+	err = writeFileAtomic(dataPath, bytes)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(metaPath, metaBytes)
+}
+
+func writeFileAtomic(filename string, bytes []byte) (err error) {
+	dir, base := filepath.Split(filename)
+	tmpFile, err := ioutil.TempFile(dir, base)
+	if err != nil {
+		return err
+	}
+	tmpFilename := tmpFile.Name()
+
+	defer func() {
+		closeErr := tmpFile.Close()
+		if err == nil {
+			err = closeErr
+		}
+
+		if err != nil {
+			os.Remove(tmpFilename)
+			return
+		}
+
+		err = os.Rename(tmpFilename, filename)
+	}()
+
+	_, err = tmpFile.Write(bytes)
+
+	return err
+}
+
+// getCachedURLBytes retrieves the contents of url, using the cache
+// to avoid downloading unchanged data and to stand in for the source
+// when it cannot be reached.
+func (db *UsersDB) getCachedURLBytes(url string) ([]byte, error) {
+	cached, meta, cacheErr := db.readCache(url)
+
+	if db.offline {
+		if cacheErr != nil {
+			return nil, fmt.Errorf("offline: no cached data for %s", url)
+		}
+		db.setStatus(&SourceStatus{
+			URL:       url,
+			Fetched:   meta.Fetched,
+			FromCache: true,
+		})
+		return cached, nil
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cacheErr == nil {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	bytes, resp, err := doRequest(req)
+	if err == nil && resp.StatusCode == http.StatusNotModified && cacheErr != nil {
+		err = errors.New(resp.Status)
+	}
+	if err != nil {
+		if cacheErr != nil {
+			return nil, err
+		}
+		db.setStatus(&SourceStatus{
+			URL:       url,
+			Fetched:   meta.Fetched,
+			FromCache: true,
+			Err:       err,
+		})
+		return cached, nil
+	}
+
+	now := time.Now()
+
+	if resp.StatusCode == http.StatusNotModified {
+		meta.Fetched = now
+		db.setStatus(&SourceStatus{
+			URL:         url,
+			Fetched:     now,
+			Revalidated: true,
+			CacheErr:    db.writeCache(url, cached, meta),
+		})
+		return cached, nil
+	}
+
+	meta = &cacheMeta{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Fetched:      now,
+	}
+	db.setStatus(&SourceStatus{
+		URL:      url,
+		Fetched:  now,
+		CacheErr: db.writeCache(url, bytes, meta),
+	})
+
+	return bytes, nil
+}
+
+// doRequest performs req, returning the body of a successful response.
+// A 304 (Not Modified) response is returned without error.
+func doRequest(req *http.Request) ([]byte, *http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, resp, nil
+	default:
+		return nil, resp, errors.New(resp.Status)
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return bytes, resp, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testETag = `"v1"`
+
+var testData = []byte("1234567,N0CALL,Joe,Mesa,Arizona,United States\n")
+
+// newTestServer returns a server that serves testData with an ETag,
+// answering a matching If-None-Match with 304 (Not Modified).
+func newTestServer(requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Header.Get("If-None-Match") == testETag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", testETag)
+		w.Write(testData)
+	}))
+}
+
+// tempDir returns a new temporary directory and a function to remove it.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "userdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func sourceStatus(t *testing.T, db *UsersDB, url string) *SourceStatus {
+	for _, status := range db.SourceStatuses() {
+		if status.URL == url {
+			return status
+		}
+	}
+	t.Fatalf("no status for %s", url)
+	return nil
+}
+
+func TestCachedURLBytes(t *testing.T) {
+	var requests int
+	server := newTestServer(&requests)
+	defer server.Close()
+	url := server.URL + "/users.csv"
+
+	dir, removeDir := tempDir(t)
+	defer removeDir()
+
+	db := New()
+	db.SetCacheDir(dir)
+
+	// 200: the data is downloaded and cached
+	data, err := db.getCachedURLBytes(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, testData) {
+		t.Fatalf("200: got %q", data)
+	}
+	status := sourceStatus(t, db, url)
+	if status.FromCache || status.Revalidated || status.CacheErr != nil {
+		t.Fatalf("200: status %+v", status)
+	}
+
+	// 304: the cached data is revalidated
+	data, err = db.getCachedURLBytes(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, testData) {
+		t.Fatalf("304: got %q", data)
+	}
+	status = sourceStatus(t, db, url)
+	if status.FromCache || !status.Revalidated || status.CacheErr != nil {
+		t.Fatalf("304: status %+v", status)
+	}
+
+	// offline: the cached data is used without a request
+	db.SetOffline(true)
+	before := requests
+	data, err = db.getCachedURLBytes(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, testData) || requests != before {
+		t.Fatalf("offline: got %q after %d requests", data, requests-before)
+	}
+	status = sourceStatus(t, db, url)
+	if !status.FromCache || status.Revalidated {
+		t.Fatalf("offline: status %+v", status)
+	}
+
+	// offline without cached data
+	_, err = db.getCachedURLBytes(server.URL + "/other.csv")
+	if err == nil {
+		t.Fatal("offline: no error without cached data")
+	}
+	db.SetOffline(false)
+
+	// unreachable source: the cached data stands in
+	server.Close()
+	data, err = db.getCachedURLBytes(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status = sourceStatus(t, db, url)
+	if !bytes.Equal(data, testData) || !status.FromCache || status.Err == nil {
+		t.Fatalf("unreachable: got %q, status %+v", data, status)
+	}
+}
+
+func TestCachedURLBytesCacheWriteFailure(t *testing.T) {
+	var requests int
+	server := newTestServer(&requests)
+	defer server.Close()
+	url := server.URL + "/users.csv"
+
+	// A regular file in place of the cache directory
+	dir, removeDir := tempDir(t)
+	defer removeDir()
+
+	cacheDir := filepath.Join(dir, "cache")
+	err := ioutil.WriteFile(cacheDir, nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := New()
+	db.SetCacheDir(cacheDir)
+
+	data, err := db.getCachedURLBytes(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, testData) {
+		t.Fatalf("got %q", data)
+	}
+	status := sourceStatus(t, db, url)
+	if status.CacheErr == nil || status.FromCache {
+		t.Fatalf("status %+v", status)
+	}
+}
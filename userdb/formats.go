@@ -0,0 +1,379 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format - A file format for writing the users database.  Fields
+// gives the mapping from user fields to the format's columns and the
+// maximum length of each.
+type Format struct {
+	Name        string
+	Description string
+	Fields      []FormatField
+	write       func(w io.Writer, format *Format, users []*User) error
+}
+
+// FormatField - A column of a Format.  Field names a User field, or is
+// "Index" for a 1-based record number, or is empty for a column
+// containing the constant Value.  Values longer than MaxLen characters
+// are truncated.  A MaxLen of 0 means unlimited.
+type FormatField struct {
+	Name   string
+	Field  string
+	Value  string
+	MaxLen int
+}
+
+var md380ToolsFields = []FormatField{
+	{Name: "Radio ID", Field: "ID"},
+	{Name: "CallSign", Field: "Callsign"},
+	{Name: "Name", Field: "Name"},
+	{Name: "City", Field: "City"},
+	{Name: "State", Field: "State"},
+	{Name: "Firstname", Field: "Nick"},
+	{Name: "Country", Field: "Country"},
+}
+
+// Formats - The formats in which the users database may be written
+var Formats = []*Format{
+	{
+		Name:        "md380tools",
+		Description: "md380tools CSV, preceded by its size",
+		Fields:      md380ToolsFields,
+		write:       writeSizedCSV,
+	},
+	{
+		Name:        "uv380",
+		Description: "CSV with header, for MD-UV380 and MD-2017",
+		Fields: []FormatField{
+			{Name: "Radio ID", Field: "ID"},
+			{Name: "CallSign", Field: "Callsign", MaxLen: 15},
+			{Name: "Name", Field: "Name"},
+			{Name: "City", Field: "City"},
+			{Name: "State", Field: "State"},
+			{Name: "Firstname", Field: "Nick"},
+			{Name: "Country", Field: "Country"},
+		},
+		write: writeHeaderCSV,
+	},
+	{
+		Name:        "contacts",
+		Description: "quoted contacts CSV, for vendor CPS imports",
+		Fields: []FormatField{
+			{Name: "No.", Field: "Index"},
+			{Name: "Radio ID", Field: "ID"},
+			{Name: "Callsign", Field: "Callsign", MaxLen: 16},
+			{Name: "Name", Field: "Name", MaxLen: 16},
+			{Name: "City", Field: "City", MaxLen: 16},
+			{Name: "State", Field: "State", MaxLen: 16},
+			{Name: "Country", Field: "Country", MaxLen: 16},
+			{Name: "Remarks", Field: "Nick", MaxLen: 16},
+			{Name: "Call Type", Value: "Private Call"},
+			{Name: "Call Alert", Value: "None"},
+		},
+		write: writeQuotedCSV,
+	},
+	{
+		Name:        "json",
+		Description: "JSON array of users",
+		Fields:      md380ToolsFields,
+		write:       writeJSON,
+	},
+	{
+		Name:        "binary",
+		Description: "compact binary with length-prefixed fields",
+		Fields: []FormatField{
+			{Name: "id", Field: "ID"},
+			{Name: "callsign", Field: "Callsign", MaxLen: 8},
+			{Name: "name", Field: "Name", MaxLen: 24},
+			{Name: "city", Field: "City", MaxLen: 16},
+			{Name: "state", Field: "State", MaxLen: 16},
+			{Name: "nick", Field: "Nick", MaxLen: 16},
+			{Name: "country", Field: "Country", MaxLen: 16},
+		},
+		write: writeBinary,
+	},
+}
+
+// FindFormat - Return the format with the given name, or nil
+func FindFormat(name string) *Format {
+	for _, format := range Formats {
+		if strings.EqualFold(format.Name, name) {
+			return format
+		}
+	}
+
+	return nil
+}
+
+// FormatNames - Return the names of all formats
+func FormatNames() []string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = format.Name
+	}
+
+	return names
+}
+
+// values returns the format's column values for user u, the index'th
+// user written, truncated to each column's maximum length.
+func (format *Format) values(index int, u *User) []string {
+	values := make([]string, len(format.Fields))
+	for i, ff := range format.Fields {
+		var value string
+		switch ff.Field {
+		case "":
+			value = ff.Value
+		case "ID":
+			value = strconv.Itoa(u.ID)
+		case "Index":
+			value = strconv.Itoa(index + 1)
+		default:
+			value = *u.field(ff.Field)
+		}
+		values[i] = truncate(value, ff.MaxLen)
+	}
+
+	return values
+}
+
+// truncate returns value shortened to at most maxLen characters.
+// A maxLen of 0 means unlimited.
+func truncate(value string, maxLen int) string {
+	if maxLen <= 0 || utf8.RuneCountInString(value) <= maxLen {
+		return value
+	}
+
+	runes := []rune(value)
+
+	return strings.TrimSpace(string(runes[:maxLen]))
+}
+
+// Write - Write users to w in the format
+func (format *Format) Write(w io.Writer, users []*User) error {
+	bw := bufio.NewWriter(w)
+
+	err := format.write(bw, format, users)
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func writeSizedCSV(w io.Writer, format *Format, users []*User) error {
+	strs := make([]string, len(users))
+	length := 0
+	for i, u := range users {
+		strs[i] = strings.Join(format.values(i, u), ",") + "\n"
+		length += len(strs[i])
+	}
+
+	_, err := fmt.Fprintf(w, "%d\n", length)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range strs {
+		_, err := io.WriteString(w, s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeHeaderCSV(w io.Writer, format *Format, users []*User) error {
+	names := make([]string, len(format.Fields))
+	for i, ff := range format.Fields {
+		names[i] = ff.Name
+	}
+
+	_, err := fmt.Fprintln(w, strings.Join(names, ","))
+	if err != nil {
+		return err
+	}
+
+	for i, u := range users {
+		_, err := fmt.Fprintln(w, strings.Join(format.values(i, u), ","))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func quoteCSV(strs []string) string {
+	quoted := make([]string, len(strs))
+	for i, s := range strs {
+		quoted[i] = `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+	}
+
+	return strings.Join(quoted, ",")
+}
+
+func writeQuotedCSV(w io.Writer, format *Format, users []*User) error {
+	names := make([]string, len(format.Fields))
+	for i, ff := range format.Fields {
+		names[i] = ff.Name
+	}
+
+	_, err := fmt.Fprint(w, quoteCSV(names)+"\r\n")
+	if err != nil {
+		return err
+	}
+
+	for i, u := range users {
+		_, err := fmt.Fprint(w, quoteCSV(format.values(i, u))+"\r\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, format *Format, users []*User) error {
+	records := make([]map[string]interface{}, len(users))
+	for i, u := range users {
+		record := make(map[string]interface{})
+		for j, value := range format.values(i, u) {
+			ff := format.Fields[j]
+			if ff.Field == "ID" {
+				record[ff.Field] = u.ID
+				continue
+			}
+			record[ff.Field] = value
+		}
+		records[i] = record
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+
+	return encoder.Encode(records)
+}
+
+// binaryMagic begins a file written in the binary format.
+const binaryMagic = "DMRUSERS"
+
+// writeBinary writes the users in a compact binary form:
+//
+//	8 bytes		"DMRUSERS"
+//	1 byte		format version (1)
+//	4 bytes		little-endian count of users
+//
+// followed by, for each user:
+//
+//	3 bytes		little-endian DMR ID
+//	for each text field in Fields order:
+//		1 byte	length in bytes
+//		n bytes	UTF-8 text, not terminated
+func writeBinary(w io.Writer, format *Format, users []*User) error {
+	header := make([]byte, len(binaryMagic)+1+4)
+	copy(header, binaryMagic)
+	header[len(binaryMagic)] = 1
+	binary.LittleEndian.PutUint32(header[len(binaryMagic)+1:], uint32(len(users)))
+
+	_, err := w.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for i, u := range users {
+		if u.ID >= 1<<24 {
+			return fmt.Errorf("binary: user ID %d does not fit in 3 bytes", u.ID)
+		}
+
+		rec := []byte{byte(u.ID), byte(u.ID >> 8), byte(u.ID >> 16)}
+		for j, value := range format.values(i, u) {
+			if format.Fields[j].Field == "ID" {
+				continue
+			}
+			for len(value) > 255 {
+				_, size := utf8.DecodeLastRuneInString(value)
+				value = value[:len(value)-size]
+			}
+			rec = append(rec, byte(len(value)))
+			rec = append(rec, value...)
+		}
+
+		_, err := w.Write(rec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteFile - Retrieve the users and write them to a file in the
+// named format
+func (db *UsersDB) WriteFile(filename string, formatName string, progress func(cur int) error) (err error) {
+	format := FindFormat(formatName)
+	if format == nil {
+		return fmt.Errorf("unknown users file format: %s", formatName)
+	}
+
+	db.progressCallback = progress
+
+	users, err := db.Users()
+	if err != nil {
+		return err
+	}
+
+	return WriteUsersFile(filename, format, users)
+}
+
+// WriteUsersFile - Write users to a file in the given format.  The same
+// users may be written to several files in different formats.
+func WriteUsersFile(filename string, format *Format, users []*User) (err error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fErr := file.Close()
+		if err == nil {
+			err = fErr
+		}
+		return
+	}()
+
+	return format.Write(file, users)
+}
@@ -0,0 +1,145 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+)
+
+var formatTestUsers = []*User{
+	{ID: 1000001, Callsign: "N0CALL", Name: "Joe Smith", City: "Mesa", State: "AZ", Nick: "Joe", Country: "United States"},
+	{ID: 2000002, Callsign: "VE0CALL", Name: "Amélie Émilie Bélanger-Gagné", City: "Québec", State: "QC", Country: "Canada"},
+}
+
+func writeFormat(t *testing.T, name string) string {
+	format := FindFormat(name)
+	if format == nil {
+		t.Fatalf("no format %s", name)
+	}
+
+	var buf bytes.Buffer
+	err := format.Write(&buf, formatTestUsers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.String()
+}
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"md380tools", "119\n" +
+			"1000001,N0CALL,Joe Smith,Mesa,AZ,Joe,United States\n" +
+			"2000002,VE0CALL,Amélie Émilie Bélanger-Gagné,Québec,QC,,Canada\n"},
+		{"uv380", "Radio ID,CallSign,Name,City,State,Firstname,Country\n" +
+			"1000001,N0CALL,Joe Smith,Mesa,AZ,Joe,United States\n" +
+			"2000002,VE0CALL,Amélie Émilie Bélanger-Gagné,Québec,QC,,Canada\n"},
+		{"contacts", `"No.","Radio ID","Callsign","Name","City","State","Country","Remarks","Call Type","Call Alert"` + "\r\n" +
+			`"1","1000001","N0CALL","Joe Smith","Mesa","AZ","United States","Joe","Private Call","None"` + "\r\n" +
+			`"2","2000002","VE0CALL","Amélie Émilie Bé","Québec","QC","Canada","","Private Call","None"` + "\r\n"},
+	}
+
+	for _, test := range tests {
+		got := writeFormat(t, test.name)
+		if got != test.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", test.name, got, test.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("%s: invalid UTF-8", test.name)
+		}
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	var records []map[string]interface{}
+	err := json.Unmarshal([]byte(writeFormat(t, "json")), &records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 || records[1]["ID"] != float64(2000002) ||
+		records[1]["Name"] != formatTestUsers[1].Name {
+		t.Errorf("got %v", records)
+	}
+}
+
+func TestFormatBinary(t *testing.T) {
+	got := []byte(writeFormat(t, "binary"))
+
+	want := []byte("DMRUSERS\x01\x02\x00\x00\x00")
+	field := func(s string) {
+		want = append(want, byte(len(s)))
+		want = append(want, s...)
+	}
+
+	// 1000001 is 0x0f4241.
+	want = append(want, 0x41, 0x42, 0x0f)
+	for _, s := range []string{"N0CALL", "Joe Smith", "Mesa", "AZ", "Joe", "United States"} {
+		field(s)
+	}
+
+	// 2000002 is 0x1e8482.
+	want = append(want, 0x82, 0x84, 0x1e)
+	for _, s := range []string{"VE0CALL", "Amélie Émilie Bélanger-G", "Québec", "QC", "", "Canada"} {
+		field(s)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+
+	format := FindFormat("binary")
+	var buf bytes.Buffer
+	err := format.Write(&buf, []*User{{ID: 1 << 24, Callsign: "N0CALL"}})
+	if err == nil {
+		t.Error("writing a 4-byte ID succeeded")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		value  string
+		maxLen int
+		want   string
+	}{
+		{"abc", 0, "abc"},
+		{"abc", 3, "abc"},
+		{"abcd", 3, "abc"},
+		{"ab cd", 3, "ab"},
+		{"ééééé", 4, "éééé"},
+	}
+
+	for _, test := range tests {
+		got := truncate(test.value, test.maxLen)
+		if got != test.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", test.value, test.maxLen, got, test.want)
+		}
+	}
+}
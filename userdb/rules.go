@@ -0,0 +1,347 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Rules - User-supplied corrections applied while amending users,
+// in addition to the built-in changes selected by Options.
+type Rules struct {
+	Users          []*User           `json:"users"`
+	TitleCase      map[string]string `json:"titleCase"`
+	CountryAbbrevs map[string]string `json:"countryAbbreviations"`
+	StateAbbrevs   map[string]string `json:"stateAbbreviations"`
+	Rewrites       []*Rewrite        `json:"rewrites"`
+
+	usersByID             map[int]*User
+	reverseCountryAbbrevs map[string]string
+	reverseStateAbbrevs   map[string]string
+	changes               map[string][]UserChange
+}
+
+// Rewrite - A regular expression substitution applied to a user field.
+// An empty Field applies the substitution to the Name, City, State,
+// Nick and Country fields.
+type Rewrite struct {
+	Field   string `json:"field"`
+	Pattern string `json:"pattern"`
+	Replace string `json:"replace"`
+
+	regexp *regexp.Regexp
+}
+
+// UserChange - A change made to one field of a user by a rule
+type UserChange struct {
+	ID       int
+	Callsign string
+	Field    string
+	Old      string
+	New      string
+}
+
+// RuleChanges - The changes made by a single rule
+type RuleChanges struct {
+	Rule    string
+	Changes []UserChange
+}
+
+var rewriteFields = []string{
+	"Name",
+	"City",
+	"State",
+	"Nick",
+	"Country",
+}
+
+// LoadRules - Read normalization rules from a JSON file
+func LoadRules(filename string) (*Rules, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules Rules
+	err = json.Unmarshal(bytes, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err.Error())
+	}
+
+	err = rules.init()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err.Error())
+	}
+
+	return &rules, nil
+}
+
+// init validates the rules and builds their lookup tables.
+func (rules *Rules) init() error {
+	rules.usersByID = make(map[int]*User)
+	for _, u := range rules.Users {
+		if u == nil || u.ID == 0 {
+			return fmt.Errorf("user correction without an ID")
+		}
+		if rules.usersByID[u.ID] != nil {
+			return fmt.Errorf("duplicate user correction: %d", u.ID)
+		}
+		rules.usersByID[u.ID] = u
+	}
+
+	rules.reverseCountryAbbrevs = make(map[string]string)
+	for c, ac := range rules.CountryAbbrevs {
+		existing := rules.reverseCountryAbbrevs[ac]
+		if existing != "" {
+			return fmt.Errorf("%s has abbreviations %s & %s", ac, existing, c)
+		}
+		rules.reverseCountryAbbrevs[ac] = c
+	}
+
+	rules.reverseStateAbbrevs = make(map[string]string)
+	for s, as := range rules.StateAbbrevs {
+		existing := rules.reverseStateAbbrevs[as]
+		if existing != "" {
+			return fmt.Errorf("%s has abbreviations %s & %s", as, existing, s)
+		}
+		rules.reverseStateAbbrevs[as] = s
+	}
+
+	for i, rw := range rules.Rewrites {
+		if rw.Field != "" && (&User{}).field(rw.Field) == nil {
+			return fmt.Errorf("rewrite %d: unknown field: %s", i+1, rw.Field)
+		}
+		re, err := regexp.Compile(rw.Pattern)
+		if err != nil {
+			return fmt.Errorf("rewrite %d: %s", i+1, err.Error())
+		}
+		rw.regexp = re
+	}
+
+	return nil
+}
+
+// field returns the address of the user's field with the given name.
+func (u *User) field(name string) *string {
+	switch name {
+	case "Callsign":
+		return &u.Callsign
+	case "Name":
+		return &u.Name
+	case "City":
+		return &u.City
+	case "State":
+		return &u.State
+	case "Nick":
+		return &u.Nick
+	case "Country":
+		return &u.Country
+	}
+
+	return nil
+}
+
+// recordChange notes a change made by rule, when a dry run is active.
+func (rules *Rules) recordChange(rule string, u *User, field, old, new string) {
+	if rules.changes == nil || old == new {
+		return
+	}
+
+	change := UserChange{
+		ID:       u.ID,
+		Callsign: u.Callsign,
+		Field:    field,
+		Old:      old,
+		New:      new,
+	}
+	rules.changes[rule] = append(rules.changes[rule], change)
+}
+
+func (rules *Rules) titleCase(u *User, fieldName, field string) string {
+	if rules == nil || len(rules.TitleCase) == 0 {
+		return titleCase(field)
+	}
+
+	words := strings.Split(field, " ")
+	for i, word := range words {
+		title, ok := rules.TitleCase[word]
+		if ok {
+			rules.recordChange("titleCase "+word, u, fieldName, word, title)
+		} else {
+			title = titleCaseMap[word]
+		}
+		if title != "" {
+			words[i] = title
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+func (rules *Rules) abbreviateCountry(u *User, country string) string {
+	if rules != nil {
+		abbrev, ok := rules.CountryAbbrevs[country]
+		if ok {
+			rules.recordChange("country "+country, u, "Country", country, abbrev)
+			return abbrev
+		}
+	}
+
+	return AbbreviateCountry(country)
+}
+
+func (rules *Rules) unAbbreviateCountry(u *User, abbrev string) string {
+	if rules != nil {
+		country, ok := rules.reverseCountryAbbrevs[abbrev]
+		if ok {
+			rules.recordChange("country "+country, u, "Country", abbrev, country)
+			return country
+		}
+	}
+
+	return UnAbbreviateCountry(abbrev)
+}
+
+func (rules *Rules) abbreviateState(u *User, state string) string {
+	if rules != nil {
+		abbrev, ok := rules.StateAbbrevs[state]
+		if ok {
+			rules.recordChange("state "+state, u, "State", state, abbrev)
+			return abbrev
+		}
+	}
+
+	return AbbreviateState(state)
+}
+
+func (rules *Rules) unAbbreviateState(u *User, abbrev string) string {
+	if rules != nil {
+		state, ok := rules.reverseStateAbbrevs[abbrev]
+		if ok {
+			rules.recordChange("state "+state, u, "State", abbrev, state)
+			return state
+		}
+	}
+
+	return UnAbbreviateState(abbrev)
+}
+
+// rewrite applies the rules' regular expression substitutions to u.
+func (rules *Rules) rewrite(u *User) {
+	if rules == nil {
+		return
+	}
+
+	for i, rw := range rules.Rewrites {
+		fieldNames := rewriteFields
+		if rw.Field != "" {
+			fieldNames = []string{rw.Field}
+		}
+
+		rule := fmt.Sprintf("rewrite %d %q", i+1, rw.Pattern)
+		for _, fieldName := range fieldNames {
+			field := u.field(fieldName)
+			old := *field
+			*field = rw.regexp.ReplaceAllString(old, rw.Replace)
+			rules.recordChange(rule, u, fieldName, old, *field)
+		}
+	}
+}
+
+// correct applies the rules' per-ID corrections to u.  Non-empty fields
+// of the correction replace those of u.
+func (rules *Rules) correct(u *User) {
+	if rules == nil {
+		return
+	}
+
+	correction := rules.usersByID[u.ID]
+	if correction == nil {
+		return
+	}
+
+	rule := fmt.Sprintf("user %d", u.ID)
+	for _, fieldName := range append([]string{"Callsign"}, rewriteFields...) {
+		old := *u.field(fieldName)
+		new := *correction.field(fieldName)
+		if new == "" {
+			continue
+		}
+		*u.field(fieldName) = new
+		rules.recordChange(rule, u, fieldName, old, new)
+	}
+}
+
+// SetRules - Set the user-supplied rules applied to each user
+func (db *UsersDB) SetRules(rules *Rules) {
+	db.rules = rules
+}
+
+// DryRun - Retrieve and amend the users without writing a file, and
+// return the changes made by each of the user-supplied rules, ordered
+// by rule and then by user ID.
+func (db *UsersDB) DryRun() ([]RuleChanges, error) {
+	if db.rules == nil {
+		return nil, fmt.Errorf("no rules have been set")
+	}
+
+	rules := *db.rules
+	rules.changes = make(map[string][]UserChange)
+
+	savedRules := db.rules
+	db.rules = &rules
+	defer func() {
+		db.rules = savedRules
+	}()
+
+	_, err := db.Users()
+	if err != nil {
+		return nil, err
+	}
+
+	ruleNames := make([]string, 0, len(rules.changes))
+	for rule := range rules.changes {
+		ruleNames = append(ruleNames, rule)
+	}
+	sort.Strings(ruleNames)
+
+	ruleChanges := make([]RuleChanges, len(ruleNames))
+	for i, rule := range ruleNames {
+		changes := rules.changes[rule]
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].ID < changes[j].ID
+		})
+		ruleChanges[i] = RuleChanges{
+			Rule:    rule,
+			Changes: changes,
+		}
+	}
+
+	return ruleChanges, nil
+}
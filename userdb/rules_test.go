@@ -0,0 +1,147 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// loadTestRules writes rules to a file and loads it.
+func loadTestRules(t *testing.T, rules string) (*Rules, error) {
+	dir, removeDir := tempDir(t)
+	defer removeDir()
+
+	filename := filepath.Join(dir, "rules.json")
+	err := ioutil.WriteFile(filename, []byte(rules), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return LoadRules(filename)
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	tests := []struct {
+		rules string
+		err   string
+	}{
+		{`{"users": [{"ID": 0}]}`, "user correction without an ID"},
+		{`{"users": [{"ID": 1}, {"ID": 1}]}`, "duplicate user correction: 1"},
+		{`{"stateAbbreviations": {"Arizona": "AZ", "Arizona2": "AZ"}}`, "AZ has abbreviations"},
+		{`{"rewrites": [{"field": "Zip", "pattern": "x"}]}`, "rewrite 1: unknown field: Zip"},
+		{`{"rewrites": [{"pattern": "("}]}`, "rewrite 1: error parsing regexp"},
+		{`{"users": 1}`, "cannot unmarshal"},
+	}
+
+	for _, test := range tests {
+		_, err := loadTestRules(t, test.rules)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %v, want %q", test.rules, err, test.err)
+		}
+	}
+}
+
+// newRulesTestDB returns a users db holding a single user, amended
+// by rules.
+func newRulesTestDB(t *testing.T) *UsersDB {
+	rules, err := loadTestRules(t, `{
+		"users": [{"ID": 1000001, "Name": "Joseph Smith"}],
+		"stateAbbreviations": {"Arizona": "Ariz"},
+		"rewrites": [{"field": "City", "pattern": "^Mesa$", "replace": "East Mesa"}]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := New()
+	db.SetOptions(&Options{AbbrevStates: true})
+	db.SetRules(rules)
+	db.getUsersFuncs = []func(*UsersDB) ([]*User, error){
+		func(*UsersDB) ([]*User, error) {
+			return []*User{{
+				ID:       1000001,
+				Callsign: "N0CALL",
+				Name:     "Joe Smith",
+				City:     "Mesa",
+				State:    "Arizona",
+				Country:  "United States",
+			}}, nil
+		},
+	}
+
+	return db
+}
+
+func TestRules(t *testing.T) {
+	db := newRulesTestDB(t)
+
+	users, err := db.Users()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*User{{
+		ID:       1000001,
+		Callsign: "N0CALL",
+		Name:     "Joseph Smith",
+		City:     "East Mesa",
+		State:    "Ariz",
+		Nick:     "Joe",
+		Country:  "United States",
+	}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("got %+v, want %+v", users[0], want[0])
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	db := newRulesTestDB(t)
+
+	changes, err := db.DryRun()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []RuleChanges{
+		{`rewrite 1 "^Mesa$"`, []UserChange{{1000001, "N0CALL", "City", "Mesa", "East Mesa"}}},
+		{"state Arizona", []UserChange{{1000001, "N0CALL", "State", "Arizona", "Ariz"}}},
+		{"user 1000001", []UserChange{{1000001, "N0CALL", "Name", "Joe Smith", "Joseph Smith"}}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("got changes %+v, want %+v", changes, want)
+	}
+
+	if db.rules.changes != nil {
+		t.Error("dry run left changes recorded in the db's rules")
+	}
+
+	_, err = New().DryRun()
+	if err == nil {
+		t.Error("dry run without rules succeeded")
+	}
+}
@@ -0,0 +1,397 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SelectionPolicy - Rules used to choose which users to keep when the
+// number of users exceeds the capacity of a radio.  Users matching an
+// earlier rule are kept in preference to users matching a later rule.
+// Within the country, state and ID range rules, entries listed first
+// are preferred.
+type SelectionPolicy struct {
+	AlwaysInclude      []int     `json:"alwaysInclude"`
+	LastHeard          []int     `json:"-"` // most recently heard first
+	LastHeardFile      string    `json:"lastHeardFile"`
+	IDRanges           []IDRange `json:"idRanges"`
+	PreferredStates    []string  `json:"preferredStates"`
+	PreferredCountries []string  `json:"preferredCountries"`
+}
+
+// IDRange - An inclusive range of DMR IDs
+type IDRange struct {
+	Low  int `json:"low"`
+	High int `json:"high"`
+}
+
+// Selection rules, in order of preference
+const (
+	RuleAlwaysInclude    = "always include"
+	RuleLastHeard        = "last heard"
+	RuleIDRange          = "ID range"
+	RulePreferredState   = "preferred state"
+	RulePreferredCountry = "preferred country"
+	RuleNoPreference     = "no preference"
+)
+
+// A selectionRule matches users and ranks them within the rule.
+// Users matching a rule with a lower priority are preferred.
+type selectionRule struct {
+	name     string
+	priority int
+	match    func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (rank int, ok bool)
+}
+
+var selectionRules = []*selectionRule{
+	{
+		name:     RuleAlwaysInclude,
+		priority: 0,
+		match: func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (int, bool) {
+			rank, ok := always[u.ID]
+			return rank, ok
+		},
+	},
+	{
+		name:     RuleLastHeard,
+		priority: 1,
+		match: func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (int, bool) {
+			rank, ok := lastHeard[u.ID]
+			return rank, ok
+		},
+	},
+	{
+		name:     RuleIDRange,
+		priority: 2,
+		match: func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (int, bool) {
+			for i, r := range p.IDRanges {
+				if u.ID >= r.Low && u.ID <= r.High {
+					return i, true
+				}
+			}
+			return 0, false
+		},
+	},
+	{
+		name:     RulePreferredState,
+		priority: 3,
+		match: func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (int, bool) {
+			for i, state := range p.PreferredStates {
+				if sameState(u.State, state) {
+					return i, true
+				}
+			}
+			return 0, false
+		},
+	},
+	{
+		name:     RulePreferredCountry,
+		priority: 4,
+		match: func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (int, bool) {
+			for i, country := range p.PreferredCountries {
+				if sameCountry(u.Country, country) {
+					return i, true
+				}
+			}
+			return 0, false
+		},
+	},
+	{
+		name:     RuleNoPreference,
+		priority: 5,
+		match: func(p *SelectionPolicy, u *User, always, lastHeard map[int]int) (int, bool) {
+			return 0, true
+		},
+	},
+}
+
+// rulesByPriority returns the selection rules, most preferred first.
+func rulesByPriority() []*selectionRule {
+	rules := append([]*selectionRule(nil), selectionRules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].priority < rules[j].priority
+	})
+
+	return rules
+}
+
+// RuleCount - The number of users matched and dropped under a rule
+type RuleCount struct {
+	Rule    string
+	Matched int
+	Dropped int
+}
+
+// SelectionReport - The result of applying a SelectionPolicy
+type SelectionReport struct {
+	Total    int
+	Selected int
+	Invalid  int // users that could not be parsed and were dropped
+	Rules    []RuleCount
+}
+
+// Dropped - Return the total number of users dropped
+func (r *SelectionReport) Dropped() int {
+	return r.Total - r.Selected
+}
+
+func (r *SelectionReport) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%d of %d users selected\n", r.Selected, r.Total)
+	if r.Invalid > 0 {
+		fmt.Fprintf(&b, "\t%d invalid users dropped\n", r.Invalid)
+	}
+	for _, rc := range r.Rules {
+		if rc.Matched == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%s: %d matched, %d dropped\n",
+			rc.Rule, rc.Matched, rc.Dropped)
+	}
+
+	return b.String()
+}
+
+// LoadSelectionPolicy - Read a selection policy from a JSON file.
+// A relative lastHeardFile is relative to the policy file's directory.
+func LoadSelectionPolicy(filename string) (*SelectionPolicy, error) {
+	bytes, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var p SelectionPolicy
+	err = json.Unmarshal(bytes, &p)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err.Error())
+	}
+
+	for _, r := range p.IDRanges {
+		if r.Low > r.High {
+			return nil, fmt.Errorf("%s: bad ID range: %d-%d", filename, r.Low, r.High)
+		}
+	}
+
+	if p.LastHeardFile != "" {
+		path := p.LastHeardFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(filename), path)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		p.LastHeard, err = ReadLastHeard(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err.Error())
+		}
+	}
+
+	return &p, nil
+}
+
+var lastHeardTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ReadLastHeard - Read a "last heard" list and return its IDs, most
+// recently heard first.  Each line contains a DMR ID, optionally
+// followed by a comma and the time it was last heard.  Lines without
+// a time are taken to be in most-recent-first order and rank after
+// lines with a time.  Blank lines and lines starting with # are ignored.
+func ReadLastHeard(reader io.Reader) ([]int, error) {
+	type heard struct {
+		id   int
+		time time.Time
+	}
+
+	var entries []heard
+	seen := make(map[int]int)
+
+	scanner := bufio.NewScanner(reader)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 3)
+		id, err := stringToID(strings.TrimSpace(fields[0]))
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("line %d: bad DMR ID: %s", lineNo, fields[0])
+		}
+
+		var t time.Time
+		if len(fields) > 1 {
+			str := strings.TrimSpace(fields[1])
+			for _, format := range lastHeardTimeFormats {
+				t, err = time.Parse(format, str)
+				if err == nil {
+					break
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad time: %s", lineNo, str)
+			}
+		}
+
+		if i, ok := seen[id]; ok {
+			if t.After(entries[i].time) {
+				entries[i].time = t
+			}
+			continue
+		}
+		seen[id] = len(entries)
+
+		entries = append(entries, heard{id, t})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].time.After(entries[j].time)
+	})
+
+	ids := make([]int, len(entries))
+	for i, e := range entries {
+		ids[i] = e.id
+	}
+
+	return ids, nil
+}
+
+func sameCountry(a, b string) bool {
+	return strings.EqualFold(UnAbbreviateCountry(a), UnAbbreviateCountry(b))
+}
+
+func sameState(a, b string) bool {
+	return strings.EqualFold(UnAbbreviateState(a), UnAbbreviateState(b))
+}
+
+// rank returns the index within rules of the first rule that u
+// matches and the rank of u within that rule.  Lower values are
+// preferred.
+func (p *SelectionPolicy) rank(rules []*selectionRule, u *User, always, lastHeard map[int]int) (int, int) {
+	for i, rule := range rules {
+		if rank, ok := rule.match(p, u, always, lastHeard); ok {
+			return i, rank
+		}
+	}
+
+	return len(rules) - 1, 0
+}
+
+// Select - Return at most capacity users, chosen according to the
+// policy, sorted by ID.  A report of the users matched and dropped
+// under each rule is also returned.
+func (p *SelectionPolicy) Select(users []*User, capacity int) ([]*User, *SelectionReport) {
+	always := make(map[int]int)
+	for i, id := range p.AlwaysInclude {
+		if _, ok := always[id]; !ok {
+			always[id] = i
+		}
+	}
+
+	lastHeard := make(map[int]int)
+	for i, id := range p.LastHeard {
+		if _, ok := lastHeard[id]; !ok {
+			lastHeard[id] = i
+		}
+	}
+
+	type ranked struct {
+		user *User
+		rule int
+		rank int
+	}
+
+	rules := rulesByPriority()
+
+	rankedUsers := make([]ranked, 0, len(users))
+	for _, u := range users {
+		if u == nil {
+			continue
+		}
+		rule, rank := p.rank(rules, u, always, lastHeard)
+		rankedUsers = append(rankedUsers, ranked{u, rule, rank})
+	}
+
+	sort.SliceStable(rankedUsers, func(i, j int) bool {
+		a := rankedUsers[i]
+		b := rankedUsers[j]
+		if a.rule != b.rule {
+			return a.rule < b.rule
+		}
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		return a.user.ID < b.user.ID
+	})
+
+	report := &SelectionReport{
+		Total: len(rankedUsers),
+		Rules: make([]RuleCount, len(rules)),
+	}
+	for i, rule := range rules {
+		report.Rules[i].Rule = rule.name
+	}
+
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	selected := make([]*User, 0, capacity)
+	for i, ru := range rankedUsers {
+		report.Rules[ru.rule].Matched++
+		if i >= capacity {
+			report.Rules[ru.rule].Dropped++
+			continue
+		}
+		selected = append(selected, ru.user)
+	}
+	report.Selected = len(selected)
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].ID < selected[j].ID
+	})
+
+	return selected, report
+}
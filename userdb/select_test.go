@@ -0,0 +1,141 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var selectTestUsers = []*User{
+	{ID: 1000001, State: "Texas", Country: "United States"},
+	{ID: 1000002, State: "Arizona", Country: "United States"},
+	{ID: 2000001, Country: "Canada"},
+	{ID: 3000001, Country: "Germany"},
+	{ID: 3100001, State: "Arizona", Country: "United States"},
+	{ID: 4000001, Country: "Japan"},
+}
+
+var selectTestPolicy = SelectionPolicy{
+	AlwaysInclude:      []int{4000001},
+	LastHeard:          []int{3000001},
+	IDRanges:           []IDRange{{Low: 3100000, High: 3199999}},
+	PreferredStates:    []string{"AZ"},
+	PreferredCountries: []string{"Canada"},
+}
+
+func userIDs(users []*User) []int {
+	ids := make([]int, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		capacity int
+		ids      []int
+		dropped  map[string]int
+	}{
+		{6, []int{1000001, 1000002, 2000001, 3000001, 3100001, 4000001}, nil},
+		{5, []int{1000002, 2000001, 3000001, 3100001, 4000001},
+			map[string]int{RuleNoPreference: 1}},
+		{3, []int{3000001, 3100001, 4000001},
+			map[string]int{RuleNoPreference: 1, RulePreferredState: 1, RulePreferredCountry: 1}},
+		{1, []int{4000001},
+			map[string]int{RuleNoPreference: 1, RulePreferredState: 1, RulePreferredCountry: 1,
+				RuleIDRange: 1, RuleLastHeard: 1}},
+		{0, []int{}, nil},
+	}
+
+	for _, test := range tests {
+		selected, report := selectTestPolicy.Select(selectTestUsers, test.capacity)
+		ids := userIDs(selected)
+		if !reflect.DeepEqual(ids, test.ids) {
+			t.Errorf("capacity %d: selected %v, want %v", test.capacity, ids, test.ids)
+		}
+		if report.Total != len(selectTestUsers) || report.Selected != len(test.ids) {
+			t.Errorf("capacity %d: report %d of %d", test.capacity, report.Selected, report.Total)
+		}
+		if test.dropped == nil {
+			continue
+		}
+		for _, rc := range report.Rules {
+			if rc.Dropped != test.dropped[rc.Rule] {
+				t.Errorf("capacity %d: %s dropped %d, want %d",
+					test.capacity, rc.Rule, rc.Dropped, test.dropped[rc.Rule])
+			}
+		}
+	}
+}
+
+// TestSelectRulePriority checks that rules are applied in priority
+// order, regardless of their order in selectionRules.
+func TestSelectRulePriority(t *testing.T) {
+	saveRules := selectionRules
+	defer func() { selectionRules = saveRules }()
+
+	selectionRules = make([]*selectionRule, len(saveRules))
+	for i, rule := range saveRules {
+		selectionRules[len(saveRules)-1-i] = rule
+	}
+
+	selected, report := selectTestPolicy.Select(selectTestUsers, 3)
+	ids := userIDs(selected)
+	want := []int{3000001, 3100001, 4000001}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("selected %v, want %v", ids, want)
+	}
+
+	for i, rule := range []string{RuleAlwaysInclude, RuleLastHeard, RuleIDRange,
+		RulePreferredState, RulePreferredCountry, RuleNoPreference} {
+		if report.Rules[i].Rule != rule {
+			t.Errorf("report rule %d is %s, want %s", i, report.Rules[i].Rule, rule)
+		}
+	}
+}
+
+func TestReadLastHeard(t *testing.T) {
+	input := `# comment
+3000001
+1000001,2019-05-01 10:00:00
+2000001,2019-06-01
+1000001,2019-07-01T00:00:00
+`
+	ids, err := ReadLastHeard(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1000001, 2000001, 3000001}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("got %v, want %v", ids, want)
+	}
+
+	_, err = ReadLastHeard(strings.NewReader("N0CALL\n"))
+	if err == nil {
+		t.Error("no error for a bad DMR ID")
+	}
+}
@@ -28,15 +28,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/dalefarnsworth/codeplug/debug"
 )
 
 var specialUsersURL = "http://registry.dstar.su/api/node.php"
@@ -90,10 +90,13 @@ type User struct {
 
 // UsersDB - A structure holding information about the database of DMR users
 type UsersDB struct {
-	filename          string
-	getUsersFuncs     []func() ([]*User, error)
+	getUsersFuncs     []func(*UsersDB) ([]*User, error)
+	cacheDir          string
+	offline           bool
+	statuses          map[string]*SourceStatus
+	statusMutex       sync.Mutex
 	options           *Options
-	printFunc         func(*User) string
+	rules             *Rules
 	progressCallback  func(progressCounter int) error
 	progressFunc      func() error
 	progressIncrement int
@@ -115,7 +118,7 @@ var DefaultOptions = &Options{
 	TitleCase:          true,
 }
 
-var getInputUsersFuncs = []func() ([]*User, error){
+var getInputUsersFuncs = []func(*UsersDB) ([]*User, error){
 	getpd1wpUsers,
 	getFixedUsers,
 	getReflectorUsers,
@@ -125,7 +128,7 @@ var getInputUsersFuncs = []func() ([]*User, error){
 	getOverrideUsers,
 }
 
-var getCuratedUsersFuncs = []func() ([]*User, error){
+var getCuratedUsersFuncs = []func(*UsersDB) ([]*User, error){
 	getCuratedUsers,
 }
 
@@ -134,6 +137,10 @@ var titleCaseMap map[string]string
 var reverseCountryAbbrevs map[string]string
 var reverseStateAbbrevs map[string]string
 
+// abbreviationsErr records a conflict found in the abbreviation tables.
+// It is returned by Users.
+var abbreviationsErr error
+
 func init() {
 	stateAbbreviations = make(map[string]string)
 	titleCaseMap = make(map[string]string)
@@ -143,8 +150,7 @@ func init() {
 	for c, ac := range countryAbbreviations {
 		existing := reverseCountryAbbrevs[ac]
 		if existing != "" {
-			l.Fatalf("%s has abbreviations %s & %s", c, existing, ac)
-
+			abbreviationsErr = fmt.Errorf("%s has abbreviations %s & %s", c, existing, ac)
 		}
 		reverseCountryAbbrevs[ac] = c
 	}
@@ -153,7 +159,7 @@ func init() {
 		for s, as := range stateAbbreviations {
 			existing := reverseStateAbbrevs[as]
 			if existing != "" {
-				l.Fatalf("%s has abbreviations %s & %s", as, existing, s)
+				abbreviationsErr = fmt.Errorf("%s has abbreviations %s & %s", as, existing, s)
 			}
 			reverseStateAbbrevs[as] = s
 		}
@@ -283,7 +289,7 @@ func UnAbbreviateState(abbrev string) string {
 	return state
 }
 
-func (u *User) amend(options *Options) {
+func (u *User) amend(options *Options, rules *Rules) {
 	u.fixCallsigns()
 
 	if options.RemoveDupSurnames {
@@ -297,10 +303,10 @@ func (u *User) amend(options *Options) {
 		u.Country = removeRepeats(u.Country)
 	}
 	if options.TitleCase {
-		u.Name = titleCase(u.Name)
-		u.City = titleCase(u.City)
-		u.State = titleCase(u.State)
-		u.Country = titleCase(u.Country)
+		u.Name = rules.titleCase(u, "Name", u.Name)
+		u.City = rules.titleCase(u, "City", u.City)
+		u.State = rules.titleCase(u, "State", u.State)
+		u.Country = rules.titleCase(u, "Country", u.Country)
 	}
 	if options.RemoveMatchingNick {
 		u.removeMatchingNicks()
@@ -311,14 +317,14 @@ func (u *User) amend(options *Options) {
 		u.fixStateCountries()
 	}
 	if options.AbbrevCountries {
-		u.Country = AbbreviateCountry(u.Country)
+		u.Country = rules.abbreviateCountry(u, u.Country)
 	} else {
-		u.Country = UnAbbreviateCountry(u.Country)
+		u.Country = rules.unAbbreviateCountry(u, u.Country)
 	}
 	if options.AbbrevStates {
-		u.State = AbbreviateState(u.State)
+		u.State = rules.abbreviateState(u, u.State)
 	} else {
-		u.State = UnAbbreviateState(u.State)
+		u.State = rules.unAbbreviateState(u, u.State)
 	}
 	if options.AbbrevDirections {
 		u.City = abbreviateDirections(u.City)
@@ -338,6 +344,9 @@ func (u *User) amend(options *Options) {
 		u.Name = fixRomanNumerals(u.Name)
 	}
 
+	rules.rewrite(u)
+	rules.correct(u)
+
 	u.normalize()
 }
 
@@ -541,7 +550,15 @@ func (u *User) fixStateCountries() {
 	}
 }
 
-func getURLBytes(url string) ([]byte, error) {
+func (db *UsersDB) getURLBytes(url string) ([]byte, error) {
+	if db.cacheDir != "" {
+		return db.getCachedURLBytes(url)
+	}
+
+	if db.offline {
+		return nil, fmt.Errorf("offline: no cache directory for %s", url)
+	}
+
 	resp, err := client.Get(url)
 	if err != nil {
 		return nil, err
@@ -552,11 +569,21 @@ func getURLBytes(url string) ([]byte, error) {
 		return nil, errors.New(resp.Status)
 	}
 
-	return ioutil.ReadAll(resp.Body)
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	db.setStatus(&SourceStatus{
+		URL:     url,
+		Fetched: time.Now(),
+	})
+
+	return bytes, nil
 }
 
-func getURLLines(url string) ([]string, error) {
-	bytes, err := getURLBytes(url)
+func (db *UsersDB) getURLLines(url string) ([]string, error) {
+	bytes, err := db.getURLBytes(url)
 	if err != nil {
 		return nil, err
 	}
@@ -580,8 +607,8 @@ type RadioidUser struct {
 	Country  string `json:"country"`
 }
 
-func getRadioidUsers() ([]*User, error) {
-	bytes, err := getURLBytes(radioidUsersURL)
+func getRadioidUsers(db *UsersDB) ([]*User, error) {
+	bytes, err := db.getURLBytes(radioidUsersURL)
 	if err != nil {
 		return nil, err
 	}
@@ -632,8 +659,8 @@ func stringToID(s string) (int, error) {
 	return int(id64), nil
 }
 
-func getHamdigitalUsers() ([]*User, error) {
-	lines, err := getURLLines(hamdigitalUsersURL)
+func getHamdigitalUsers(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(hamdigitalUsersURL)
 	if err != nil {
 		errFmt := "error getting hamdigital users database: %s: %s"
 		err = fmt.Errorf(errFmt, hamdigitalUsersURL, err.Error())
@@ -669,8 +696,8 @@ func getHamdigitalUsers() ([]*User, error) {
 	return users, nil
 }
 
-func getCuratedUsers() ([]*User, error) {
-	lines, err := getURLLines(curatedUsersURL)
+func getCuratedUsers(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(curatedUsersURL)
 	if err != nil {
 		return nil, err
 	}
@@ -746,8 +773,8 @@ func linesToUsers(url string, lines []string) ([]*User, error) {
 	return users, err
 }
 
-func newFileUsersFuncs(path string) (func() ([]*User, error), error) {
-	return func() ([]*User, error) {
+func newFileUsersFuncs(path string) (func(*UsersDB) ([]*User, error), error) {
+	return func(db *UsersDB) ([]*User, error) {
 		file, err := os.Open(path)
 		if err != nil {
 			return nil, err
@@ -768,9 +795,9 @@ func newFileUsersFuncs(path string) (func() ([]*User, error), error) {
 	}, nil
 }
 
-func newURLUsersFuncs(uri string) (func() ([]*User, error), error) {
-	return func() ([]*User, error) {
-		lines, err := getURLLines(uri)
+func newURLUsersFuncs(uri string) (func(*UsersDB) ([]*User, error), error) {
+	return func(db *UsersDB) ([]*User, error) {
+		lines, err := db.getURLLines(uri)
 		if err != nil {
 			return nil, err
 		}
@@ -779,8 +806,8 @@ func newURLUsersFuncs(uri string) (func() ([]*User, error), error) {
 	}, nil
 }
 
-func getFixedUsers() ([]*User, error) {
-	lines, err := getURLLines(fixedUsersURL)
+func getFixedUsers(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(fixedUsersURL)
 	if err != nil {
 		errFmt := "getting fixed users: %s: %s"
 		err = fmt.Errorf(errFmt, fixedUsersURL, err.Error())
@@ -805,8 +832,8 @@ func getFixedUsers() ([]*User, error) {
 	return users, nil
 }
 
-func getpd1wpUsers() ([]*User, error) {
-	lines, err := getURLLines(pd1wpUsersURL)
+func getpd1wpUsers(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(pd1wpUsersURL)
 	if err != nil {
 		errFmt := "getting pd1wp users: %s: %s"
 		err = fmt.Errorf(errFmt, pd1wpUsersURL, err.Error())
@@ -836,8 +863,8 @@ func getpd1wpUsers() ([]*User, error) {
 	return users, nil
 }
 
-func getpd1wpUsersNames() ([]*User, error) {
-	lines, err := getURLLines(pd1wpUsersURL)
+func getpd1wpUsersNames(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(pd1wpUsersURL)
 	if err != nil {
 		errFmt := "getting pd1wp users: %s: %s"
 		err = fmt.Errorf(errFmt, pd1wpUsersURL, err.Error())
@@ -863,8 +890,8 @@ func getpd1wpUsersNames() ([]*User, error) {
 	return users, nil
 }
 
-func getOverrideUsers() ([]*User, error) {
-	lines, err := getURLLines(overrideUsersURL)
+func getOverrideUsers(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(overrideUsersURL)
 	if err != nil {
 		errFmt := "getting override users: %s: %s"
 		err = fmt.Errorf(errFmt, overrideUsersURL, err.Error())
@@ -900,8 +927,8 @@ type special struct {
 	Address string
 }
 
-func getSpecialURLs() ([]string, error) {
-	bytes, err := getURLBytes(specialUsersURL)
+func getSpecialURLs(db *UsersDB) ([]string, error) {
+	bytes, err := db.getURLBytes(specialUsersURL)
 	if err != nil {
 		return nil, err
 	}
@@ -918,8 +945,8 @@ func getSpecialURLs() ([]string, error) {
 	return urls, nil
 }
 
-func getSpecialUsers(url string) ([]*User, error) {
-	lines, err := getURLLines(url)
+func getSpecialUsers(db *UsersDB, url string) ([]*User, error) {
+	lines, err := db.getURLLines(url)
 	if err != nil {
 		errFmt := "getting special users: %s: %s"
 		err = fmt.Errorf(errFmt, url, err.Error())
@@ -946,8 +973,8 @@ func getSpecialUsers(url string) ([]*User, error) {
 	return users, nil
 }
 
-func getReflectorUsers() ([]*User, error) {
-	lines, err := getURLLines(reflectorUsersURL)
+func getReflectorUsers(db *UsersDB) ([]*User, error) {
+	lines, err := db.getURLLines(reflectorUsersURL)
 	if err != nil {
 		errFmt := "getting reflector users: %s: %s"
 		err = fmt.Errorf(errFmt, reflectorUsersURL, err.Error())
@@ -973,7 +1000,7 @@ func getReflectorUsers() ([]*User, error) {
 	return users, nil
 }
 
-func mergeAndSort(users []*User, opts *Options) []*User {
+func mergeAndSort(users []*User, opts *Options, rules *Rules) []*User {
 	idMap := make(map[int]*User)
 	for _, u := range users {
 		if u == nil || u.ID == 0 {
@@ -1008,7 +1035,7 @@ func mergeAndSort(users []*User, opts *Options) []*User {
 	}
 
 	for _, u := range idMap {
-		u.amend(opts)
+		u.amend(opts, rules)
 	}
 
 	ids := make([]int, 0, len(idMap))
@@ -1031,11 +1058,11 @@ type result struct {
 	err   error
 }
 
-func do(index int, f func() ([]*User, error), resultChan chan result) {
+func do(db *UsersDB, index int, f func(*UsersDB) ([]*User, error), resultChan chan result) {
 	var r result
 
 	r.index = index
-	r.users, r.err = f()
+	r.users, r.err = f(db)
 	resultChan <- r
 }
 
@@ -1052,12 +1079,18 @@ func (db *UsersDB) InputUsers() ([]*User, error) {
 
 // Users - Return the best current list of DMR users
 func (db *UsersDB) Users() ([]*User, error) {
+	if abbreviationsErr != nil {
+		return nil, abbreviationsErr
+	}
+
 	var users []*User
 	resultCount := len(db.getUsersFuncs)
 	resultChan := make(chan result, resultCount)
 
+	db.resetStatuses()
+
 	for i, f := range db.getUsersFuncs {
-		go do(i, f, resultChan)
+		go do(db, i, f, resultChan)
 	}
 
 	db.setMaxProgressCount(resultCount)
@@ -1081,49 +1114,13 @@ func (db *UsersDB) Users() ([]*User, error) {
 		users = append(users, r.users...)
 	}
 
-	users = mergeAndSort(users, db.options)
+	users = mergeAndSort(users, db.options, db.rules)
 
 	db.finalProgress()
 
 	return users, nil
 }
 
-func (db *UsersDB) writeSized() (err error) {
-	file, err := os.Create(db.filename)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		fErr := file.Close()
-		if err == nil {
-			err = fErr
-		}
-		return
-	}()
-
-	users, err := db.Users()
-	if err != nil {
-		return err
-	}
-
-	strs := make([]string, len(users))
-	for i, u := range users {
-		strs[i] = db.printFunc(u)
-	}
-
-	length := 0
-	for _, s := range strs {
-		length += len(s)
-	}
-	fmt.Fprintf(file, "%d\n", length)
-
-	for _, s := range strs {
-		fmt.Fprint(file, s)
-	}
-
-	return nil
-}
-
 func mergeUser(existing, u *User) *User {
 	if u.Callsign != "" {
 		existing.Callsign = u.Callsign
@@ -1147,43 +1144,77 @@ func mergeUser(existing, u *User) *User {
 	return existing
 }
 
-func (db *UsersDB) write(header bool) (err error) {
-	file, err := os.Create(db.filename)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		fErr := file.Close()
-		if err == nil {
-			err = fErr
+// WriteMD380ToolsFile - Write a user db file in MD380 format
+func (db *UsersDB) WriteMD380ToolsFile(filename string, progress func(cur int) error) error {
+	return db.WriteFile(filename, "md380tools", progress)
+}
+
+// ReadUsers - Read users from an md380tools format users file, as
+// written by WriteMD380ToolsFile.  A leading size line or header line
+// is ignored.
+func ReadUsers(reader io.Reader) ([]*User, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		fields := strings.SplitN(line, ",", 2)
+		if len(fields) < 2 {
+			continue
 		}
-		return
-	}()
-
-	if header {
-		fmt.Fprintln(file, "Radio ID,CallSign,Name,City,State,Firstname,Country")
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+		lines = append(lines, line)
 	}
-
-	users, err := db.Users()
-	if err != nil {
-		return err
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
+	return linesToUsers("users", lines)
+}
+
+// FindUsers - Return the users matching keys, each of which is either
+// a DMR ID or a callsign.  A callsign may match several users.  Keys
+// matching no user are returned in notFound.
+func FindUsers(users []*User, keys []string) (found []*User, notFound []string) {
+	idMap := make(map[int]*User)
+	callMap := make(map[string][]*User)
 	for _, u := range users {
-		fmt.Fprint(file, db.printFunc(u))
+		if u == nil {
+			continue
+		}
+		idMap[u.ID] = u
+		call := strings.ToUpper(u.Callsign)
+		callMap[call] = append(callMap[call], u)
 	}
 
-	return nil
-}
+	seen := make(map[int]bool)
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
 
-// WriteMD380ToolsFile - Write a user db file in MD380 format
-func (db *UsersDB) WriteMD380ToolsFile(filename string, progress func(cur int) error) error {
-	db.filename = filename
-	db.progressCallback = progress
-	db.printFunc = func(u *User) string {
-		return fmt.Sprintf("%d,%s,%s,%s,%s,%s,%s\n",
-			u.ID, u.Callsign, u.Name, u.City, u.State, u.Nick, u.Country)
+		var matches []*User
+		id, err := strconv.Atoi(key)
+		if err == nil {
+			if u := idMap[id]; u != nil {
+				matches = []*User{u}
+			}
+		} else {
+			matches = callMap[strings.ToUpper(key)]
+		}
+
+		if len(matches) == 0 {
+			notFound = append(notFound, key)
+			continue
+		}
+
+		for _, u := range matches {
+			if seen[u.ID] {
+				continue
+			}
+			seen[u.ID] = true
+			found = append(found, u)
+		}
 	}
 
-	return db.writeSized()
+	return found, notFound
 }
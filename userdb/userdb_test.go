@@ -0,0 +1,70 @@
+// Copyright 2017-2019 Dale Farnsworth. All rights reserved.
+
+// Dale Farnsworth
+// 1007 W Mendoza Ave
+// Mesa, AZ  85210
+// USA
+//
+// dale@farnsworth.org
+
+// This file is part of UserDB.
+//
+// UserDB is free software: you can redistribute it and/or modify
+// it under the terms of version 3 of the GNU Lesser General Public
+// License as published by the Free Software Foundation.
+//
+// UserDB is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with UserDB.  If not, see <http://www.gnu.org/licenses/>.
+
+package userdb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var readUsersTestFile = "2\r\n" +
+	"1000001,N0CALL,Joe Smith,Mesa,AZ,Joe,United States\r\n" +
+	"1000002,N1CALL,Ann Jones,Mesa,AZ,,United States\r\n"
+
+func TestReadUsers(t *testing.T) {
+	users, err := ReadUsers(strings.NewReader(readUsersTestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*User{
+		{1000001, "N0CALL", "Joe Smith", "Mesa", "AZ", "Joe", "United States"},
+		{1000002, "N1CALL", "Ann Jones", "Mesa", "AZ", "", "United States"},
+	}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("got %+v, want %+v", users, want)
+	}
+
+	_, err = ReadUsers(strings.NewReader("1000001,N0CALL\n"))
+	if err == nil || !strings.Contains(err.Error(), "too few fields") {
+		t.Errorf("short line: got error %v", err)
+	}
+}
+
+func TestFindUsers(t *testing.T) {
+	users := []*User{
+		{ID: 1000001, Callsign: "N0CALL"},
+		{ID: 1000002, Callsign: "N1CALL"},
+		{ID: 1000003, Callsign: "N1CALL"},
+	}
+
+	found, notFound := FindUsers(users, []string{"1000001", "n1call", "N2CALL", "1000002", "2000000"})
+	if ids := userIDs(found); !reflect.DeepEqual(ids, []int{1000001, 1000002, 1000003}) {
+		t.Errorf("found %v", ids)
+	}
+	if !reflect.DeepEqual(notFound, []string{"N2CALL", "2000000"}) {
+		t.Errorf("not found %v", notFound)
+	}
+}